@@ -12,8 +12,10 @@ import (
 	"github.com/icon-project/goloop/common/codec"
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/intconv"
 	"github.com/icon-project/goloop/common/log"
 	"github.com/icon-project/goloop/common/merkle"
+	"github.com/icon-project/goloop/common/txlocator"
 	"github.com/icon-project/goloop/service"
 	"github.com/icon-project/goloop/service/state"
 	"github.com/icon-project/goloop/service/transaction"
@@ -83,6 +85,7 @@ type Chain interface {
 	GenesisStorage() module.GenesisStorage
 	CommitVoteSetDecoder() module.CommitVoteSetDecoder
 	Genesis() []byte
+	TxAddressIndex() bool
 }
 
 type LocatorManager interface {
@@ -1002,6 +1005,17 @@ func (m *manager) finalize(bn *bnode, updatePCM bool) error {
 		if err != nil {
 			return err
 		}
+		if m.chain.TxAddressIndex() {
+			err = indexTransactionsByAddress(
+				m.chain.Database(),
+				block.Height(),
+				m.finalized.block.NormalTransactions(),
+				bn.in.mtransition().NormalReceipts(),
+			)
+			if err != nil {
+				return err
+			}
+		}
 	}
 	err := m.sm.Finalize(bn.preexe.mtransition(), module.FinalizeNormalTransaction)
 	if err != nil {
@@ -1359,6 +1373,22 @@ func (m *manager) GetLastBlock() (module.Block, error) {
 	return m.finalized.block, nil
 }
 
+func (m *manager) GetPendingResult() (module.Block, []byte, module.ValidatorList, error) {
+	m.syncer.begin()
+	defer m.syncer.end()
+
+	if !m.running {
+		return nil, nil, nil, errors.New("not running")
+	}
+
+	mtr := m.finalized.preexe.mtransition()
+	if mtr == nil || mtr.Result() == nil {
+		return nil, nil, nil, errors.NotFoundError.Errorf(
+			"NotExecuted(height=%d)", m.finalized.block.Height())
+	}
+	return m.finalized.block, mtr.Result(), mtr.NextValidators(), nil
+}
+
 func (m *manager) WaitForBlock(height int64) (<-chan module.Block, error) {
 	m.syncer.begin()
 	defer m.syncer.end()
@@ -1515,6 +1545,7 @@ func (m *manager) ExportBlocksWithFlag(from, to int64, dst db.Database, flag int
 		}
 	}
 
+	var prev module.Block
 	for h := from; h <= to; h++ {
 		blk, err := m.GetBlockByHeight(h)
 		if err != nil {
@@ -1523,10 +1554,34 @@ func (m *manager) ExportBlocksWithFlag(from, to int64, dst db.Database, flag int
 		if err := m._export(blk, ctx, flag); err != nil {
 			return errors.Wrapf(err, "fail to export block height=%d", blk.Height())
 		}
+		if hasBits(flag, exportIndex) && m.chain.TxAddressIndex() && prev != nil {
+			// receipts of transactions in the previous block are in the result of the block
+			rl, err := m.sm.ReceiptListFromResult(blk.Result(), module.TransactionGroupNormal)
+			if err != nil {
+				return errors.Wrapf(err, "fail to get receipts height=%d", h)
+			}
+			err = indexTransactionsByAddress(ctx.TargetDB(), h, prev.NormalTransactions(), rl)
+			if err != nil {
+				return errors.Wrapf(err, "fail to index transactions height=%d", prev.Height())
+			}
+		}
+		prev = blk
 	}
 	return nil
 }
 
+// indexTransactionsByAddress indexes normal transactions of the block at
+// height-1 with receipts in the result of the block at height.
+func indexTransactionsByAddress(
+	dbase db.Database, height int64, txs module.TransactionList, rl module.ReceiptList,
+) error {
+	ai, err := txlocator.NewAddressIndex(dbase)
+	if err != nil {
+		return err
+	}
+	return ai.IndexTransactions(height, txs, rl)
+}
+
 func (m *manager) _export(blk module.Block, ctx *merkle.CopyContext, flag int) error {
 	ctx.SetHeight(blk.Height())
 	if hasBits(flag, exportResult) {
@@ -1763,6 +1818,18 @@ func GetBlockResultByHeight(
 	return getHeaderField(dbase, c, height, 10)
 }
 
+func GetBlockTimestampByHeight(
+	dbase db.Database,
+	c codec.Codec,
+	height int64,
+) (int64, error) {
+	bs, err := getHeaderField(dbase, c, height, 2)
+	if err != nil {
+		return 0, err
+	}
+	return intconv.BytesToInt64(bs), nil
+}
+
 func GetBTPDigestFromResult(
 	dbase db.Database,
 	c codec.Codec,
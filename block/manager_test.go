@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/icon-project/goloop/btp/ntm"
 	"github.com/icon-project/goloop/common/crypto"
 	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/consensus"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/service/platform/basic"
@@ -66,6 +68,32 @@ func TestBlockManager_GetBlock(t *testing.T) {
 	assert.EqualValues(blk.ID(), blk2.ID())
 }
 
+func TestBlockManager_GetPendingResult(t *testing.T) {
+	nd := test.NewNode(t)
+	defer nd.Close()
+	assert := assert.New(t)
+
+	nd.ProposeFinalizeBlock(consensus.NewEmptyCommitVoteList())
+	blk := nd.GetLastBlock()
+
+	var pblk module.Block
+	var result []byte
+	var err error
+	for i := 0; i < 100; i++ {
+		pblk, result, _, err = nd.BM.GetPendingResult()
+		if !errors.NotFoundError.Equals(err) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.NoError(err)
+	assert.EqualValues(blk.ID(), pblk.ID())
+
+	// the pending result becomes the result of the next block
+	nd.ProposeFinalizeBlock(consensus.NewEmptyCommitVoteList())
+	assert.EqualValues(result, nd.GetLastBlock().Result())
+}
+
 func TestBlockManager_NewManager(t *testing.T) {
 	nd := test.NewNode(t)
 	defer nd.Close()
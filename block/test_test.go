@@ -68,6 +68,10 @@ func (c *testChain) CID() int {
 	return 1
 }
 
+func (c *testChain) TxAddressIndex() bool {
+	return false
+}
+
 func (c *testChain) NetID() int {
 	return 1
 }
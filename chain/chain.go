@@ -270,6 +270,10 @@ func (c *singleChain) ValidateTxOnSend() bool {
 	return c.cfg.ValidateTxOnSend
 }
 
+func (c *singleChain) TxAddressIndex() bool {
+	return c.cfg.TxAddressIndex
+}
+
 func (c *singleChain) State() (string, int64, error) {
 	c.mtx.RLock()
 	defer c.mtx.RUnlock()
@@ -49,6 +49,7 @@ type Config struct {
 	ChildrenLimit    *int   `json:"children_limit,omitempty"`
 	NephewsLimit     *int   `json:"nephews_limit,omitempty"`
 	ValidateTxOnSend bool   `json:"validate_tx_on_send,omitempty"`
+	TxAddressIndex   bool   `json:"tx_address_index,omitempty"`
 
 	// runtime
 	Channel        string `json:"channel"`
@@ -289,6 +289,24 @@ func (c *ClientV3) GetTransactionByHash(param *v3.TransactionHashParam) (*Transa
 	return t, nil
 }
 
+func (c *ClientV3) GetTransactionsByAddress(param *v3.TransactionsByAddressParam) (interface{}, error) {
+	var result interface{}
+	_, err := c.Do("icx_getTransactionsByAddress", param, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *ClientV3) GetEventLogs(param *v3.EventLogsParam) (interface{}, error) {
+	var result interface{}
+	_, err := c.Do("icx_getEventLogs", param, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 var txSerializeExcludes = map[string]bool{"signature": true}
 
 func SignTransaction(w module.Wallet, param *v3.TransactionParam) error {
@@ -490,6 +508,15 @@ func (c *ClientV3) MonitorBtp(param *server.BTPRequest, cb func(v *server.BTPNot
 	}, cancelCh)
 }
 
+func (c *ClientV3) MonitorTxPool(param *server.TxPoolRequest, cb func(v *server.TxPoolNotification), cancelCh <-chan bool) error {
+	resp := &server.TxPoolNotification{}
+	return c.Monitor("/txpool", param, resp, func(v interface{}) {
+		if tn, ok := v.(*server.TxPoolNotification); ok {
+			cb(tn)
+		}
+	}, cancelCh)
+}
+
 func (c *ClientV3) Monitor(reqUrl string, reqPtr, respPtr interface{},
 	cb func(v interface{}), cancelCh <-chan bool) error {
 	if cb == nil {
@@ -145,6 +145,7 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 				param.NephewsLimit = &nephewsLimit
 			}
 			param.ValidateTxOnSend, _ = fs.GetBool("validate_tx_on_send")
+			param.TxAddressIndex, _ = fs.GetBool("tx_address_index")
 
 			var buf *bytes.Buffer
 			if len(genesisZip) > 0 {
@@ -202,6 +203,7 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 	joinFlags.Int("children_limit", -1, "Maximum number of child connections (-1: uses system default value)")
 	joinFlags.Int("nephews_limit", -1, "Maximum number of nephew connections (-1: uses system default value)")
 	joinFlags.Bool("validate_tx_on_send", false, "Validate transaction on send")
+	joinFlags.Bool("tx_address_index", false, "Index transactions by address")
 
 	leaveCmd := &cobra.Command{
 		Use:   "leave CID",
@@ -479,6 +481,33 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 	configFlags.String("value", "", "use if value starts with '-'.\n"+
 		"(if the third arg is used, this flag will be ignored)")
 
+	txPoolCmd := &cobra.Command{
+		Use:   "txpool CID [ADDRESS]",
+		Short: "List pending transactions in the transaction pool",
+		Args:  ArgsWithDefaultErrorFunc(cobra.RangeArgs(1, 2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reqUrl := node.UrlChain + "/" + args[0] + "/txpool"
+			params := &url.Values{}
+			if len(args) > 1 {
+				params.Add("from", args[1])
+			}
+			if limit, _ := cmd.Flags().GetInt("limit"); limit > 0 {
+				params.Add("limit", strconv.Itoa(limit))
+			}
+			var v []interface{}
+			resp, err := adminClient.Get(reqUrl, &v, params)
+			if err != nil {
+				return err
+			}
+			if err = JsonPrettyPrintln(os.Stdout, v); err != nil {
+				return errors.Errorf("failed JsonIntend resp=%+v, err=%+v", resp, err)
+			}
+			return nil
+		},
+	}
+	rootCmd.AddCommand(txPoolCmd)
+	txPoolCmd.Flags().Int("limit", 0, "Maximum number of transactions (0: uses server default value)")
+
 	rootCmd.Use = "chain TASK CID [PARAM]"
 	rootCmd.Args = ArgsWithDefaultErrorFunc(cobra.RangeArgs(2, 3))
 	rootCmd.RunE = func(cmd *cobra.Command, args []string) error {
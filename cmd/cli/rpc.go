@@ -196,19 +196,40 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 			},
 		})
 
+	txsByAddressCmd := &cobra.Command{
+		Use:   "txsbyaddress ADDRESS",
+		Short: "GetTransactionsByAddress",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			param := &v3.TransactionsByAddressParam{Address: jsonrpc.Address(args[0])}
+			if cursor, _ := cmd.Flags().GetInt64("cursor"); cursor > 0 {
+				param.Cursor = jsonrpc.HexInt(intconv.FormatInt(cursor))
+			}
+			if limit, _ := cmd.Flags().GetInt64("limit"); limit > 0 {
+				param.Limit = jsonrpc.HexInt(intconv.FormatInt(limit))
+			}
+			txs, err := rpcClient.GetTransactionsByAddress(param)
+			if err != nil {
+				return err
+			}
+			return JsonPrettyPrintln(os.Stdout, txs)
+		},
+	}
+	rootCmd.AddCommand(txsByAddressCmd)
+	txsByAddressCmd.Flags().Int64("cursor", 0, "Cursor for next transactions (0: from the latest)")
+	txsByAddressCmd.Flags().Int64("limit", 0, "Maximum number of transactions (0: uses server default value)")
+
 	balanceCmd := &cobra.Command{
 		Use:   "balance ADDRESS",
 		Short: "GetBalance",
 		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			param := &v3.AddressParam{Address: jsonrpc.Address(args[0])}
-			height, err := intconv.ParseInt(cmd.Flag("height").Value.String(), 64)
+			height, err := heightFromFlag(cmd)
 			if err != nil {
 				return err
 			}
-			if height != -1 {
-				param.Height = jsonrpc.HexInt(intconv.FormatInt(height))
-			}
+			param.Height = height
 			balance, err := rpcClient.GetBalance(param)
 			if err != nil {
 				return err
@@ -218,7 +239,7 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 	}
 	rootCmd.AddCommand(balanceCmd)
 	flags := balanceCmd.Flags()
-	flags.Int("height", -1, "BlockHeight")
+	flags.String("height", "", "BlockHeight or block tag(latest, finalized, pending)")
 
 	scoreAPICmd := &cobra.Command{
 		Use:   "scoreapi ADDRESS",
@@ -226,13 +247,11 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			param := &v3.ScoreAddressParam{Address: jsonrpc.Address(args[0])}
-			height, err := intconv.ParseInt(cmd.Flag("height").Value.String(), 64)
+			height, err := heightFromFlag(cmd)
 			if err != nil {
 				return err
 			}
-			if height != -1 {
-				param.Height = jsonrpc.HexInt(intconv.FormatInt(height))
-			}
+			param.Height = height
 			scoreApi, err := rpcClient.GetScoreApi(param)
 			if err != nil {
 				return err
@@ -242,7 +261,7 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 	}
 	rootCmd.AddCommand(scoreAPICmd)
 	flags = scoreAPICmd.Flags()
-	flags.Int("height", -1, "BlockHeight")
+	flags.String("height", "", "BlockHeight or block tag(latest, finalized, pending)")
 
 	tsCmd := &cobra.Command{
 		Use:   "totalsupply",
@@ -250,14 +269,12 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var param *v3.HeightParam
-			height, err := intconv.ParseInt(cmd.Flag("height").Value.String(), 64)
+			height, err := heightFromFlag(cmd)
 			if err != nil {
 				return err
 			}
-			if height != -1 {
-				param = &v3.HeightParam{
-					Height: jsonrpc.HexInt(intconv.FormatInt(height)),
-				}
+			if height != "" {
+				param = &v3.HeightParam{Height: height}
 			}
 			supply, err := rpcClient.GetTotalSupply(param)
 			if err != nil {
@@ -268,7 +285,7 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 	}
 	rootCmd.AddCommand(tsCmd)
 	flags = tsCmd.Flags()
-	flags.Int("height", -1, "BlockHeight")
+	flags.String("height", "", "BlockHeight or block tag(latest, finalized, pending)")
 
 	callCmd := &cobra.Command{
 		Use:   "call",
@@ -280,13 +297,11 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 				ToAddress:   jsonrpc.Address(cmd.Flag("to").Value.String()),
 				DataType:    "call", //refer server/v3/validation.go:27 isCall
 			}
-			height, err := intconv.ParseInt(cmd.Flag("height").Value.String(), 64)
+			height, err := heightFromFlag(cmd)
 			if err != nil {
 				return err
 			}
-			if height != -1 {
-				param.Height = jsonrpc.HexInt(intconv.FormatInt(height))
-			}
+			param.Height = height
 
 			dataM := make(map[string]interface{})
 			if dataJson := cmd.Flag("raw").Value.String(); dataJson != "" {
@@ -329,7 +344,7 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 	callFlags := callCmd.Flags()
 	callFlags.String("from", "", "FromAddress")
 	callFlags.String("to", "", "ToAddress")
-	callFlags.Int("height", -1, "BlockHeight")
+	callFlags.String("height", "", "BlockHeight or block tag(latest, finalized, pending)")
 	callFlags.String("method", "",
 		"Name of the function to invoke in SCORE, if '--raw' used, will overwrite")
 	callFlags.String("params", "",
@@ -461,13 +476,11 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			param := &v3.ScoreAddressParam{Address: jsonrpc.Address(args[0])}
-			height, err := intconv.ParseInt(cmd.Flag("height").Value.String(), 64)
+			height, err := heightFromFlag(cmd)
 			if err != nil {
 				return err
 			}
-			if height != -1 {
-				param.Height = jsonrpc.HexInt(intconv.FormatInt(height))
-			}
+			param.Height = height
 			scoreStatus, err := rpcClient.GetScoreStatus(param)
 			if err != nil {
 				return err
@@ -477,7 +490,7 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 	}
 	rootCmd.AddCommand(scoreStatusCmd)
 	flags = scoreStatusCmd.Flags()
-	flags.Int("height", -1, "BlockHeight")
+	flags.String("height", "", "BlockHeight or block tag(latest, finalized, pending)")
 
 	networkInfoCmd := &cobra.Command{
 		Use: "networkinfo",
@@ -598,6 +611,18 @@ func newHexIntByString(s string) (i jsonrpc.HexInt, err error) {
 	return
 }
 
+// heightFromFlag returns the height or the block tag given by "height" flag.
+// It returns empty for the last block.
+func heightFromFlag(cmd *cobra.Command) (jsonrpc.HexInt, error) {
+	value := cmd.Flag("height").Value.String()
+	switch h := jsonrpc.HexInt(value); h {
+	case "", v3.HeightLatest, v3.HeightFinalized, v3.HeightPending:
+		return h, nil
+	default:
+		return newHexIntByString(value)
+	}
+}
+
 func newBTPQueryParam(args []string) (p *v3.BTPQueryParam, err error) {
 	if len(args) < 1 {
 		return nil, fmt.Errorf("invalid args")
@@ -1276,5 +1301,32 @@ func NewMonitorCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comma
 		"BTP Network ID")
 	monitorBTPFlags.Bool("proof_flag", false, "Includes proof")
 
+	monitorTxPoolCmd := &cobra.Command{
+		Use:   "txpool",
+		Short: "MonitorTxPool",
+		Args:  ArgsWithDefaultErrorFunc(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			param := &server.TxPoolRequest{}
+			if from := cmd.Flag("from").Value.String(); from != "" {
+				addr, err := common.NewAddressFromString(from)
+				if err != nil {
+					return err
+				}
+				param.From = addr
+			}
+
+			OnInterrupt(rpcClient.Cleanup)
+			err := rpcClient.MonitorTxPool(param, func(v *server.TxPoolNotification) {
+				JsonPrettyPrintln(os.Stdout, v)
+			}, nil)
+			if err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+	rootCmd.AddCommand(monitorTxPoolCmd)
+	monitorTxPoolCmd.Flags().String("from", "", "Sender of transactions to monitor")
+
 	return rootCmd
 }
@@ -17,10 +17,303 @@
 package main
 
 import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
 	"github.com/icon-project/goloop/chain"
+	"github.com/icon-project/goloop/chain/gs"
+	"github.com/icon-project/goloop/cmd/cli"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/log"
 	"github.com/icon-project/goloop/havah"
+	"github.com/icon-project/goloop/havah/hvh"
+	"github.com/icon-project/goloop/havah/hvh/hvhstate"
 )
 
 func init() {
 	chain.RegisterPlatform("havah", havah.NewPlatform)
 }
+
+func newHavahCmd(c string) *cobra.Command {
+	cmd := &cobra.Command{Use: c, Short: "HAVAH platform tools"}
+	cmd.AddCommand(
+		newHavahSimulateCmd("simulate"),
+		newHavahExportCmd("export"),
+		newHavahGenesisCmd("genesis"),
+		newHavahDryRunCmd("dryrun"),
+	)
+	return cmd
+}
+
+func newHavahSimulateCmd(c string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("%s [config file]", c),
+		Short: "Simulate per-term coin issuance with assumed planets",
+		Long: "Simulate per-term coin issuance with assumed planets\n" +
+			"Config file contains platform config (termPeriod, issueAmount, usdtPrice, ...)\n" +
+			"with terms, initial balances and planet groups ({count, usdt, isCompany, isPrivate, price, working})",
+		Args: cli.ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+	}
+	flags := cmd.Flags()
+	csv := flags.Bool("csv", false, "Print results in CSV format")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		raw, err := os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		cfg := new(hvh.SimulationConfig)
+		if err = json.Unmarshal(raw, cfg); err != nil {
+			return err
+		}
+
+		logger := log.New()
+		logger.SetLevel(log.WarnLevel)
+		results, err := hvh.Simulate(cfg, logger)
+		if err != nil {
+			return err
+		}
+
+		w := cmd.OutOrStdout()
+		if *csv {
+			return printSimTermResultsInCSV(w, results)
+		}
+		jso := make([]interface{}, len(results))
+		for i, r := range results {
+			jso[i] = r.ToJSON()
+		}
+		return cli.JsonPrettyPrintln(w, jso)
+	}
+	return cmd
+}
+
+func printSimTermResultsInCSV(w io.Writer, results []*hvh.SimTermResult) error {
+	cw := csv.NewWriter(w)
+	header := []string{
+		"termSequence", "issueAmount", "activePlanets", "workingPlanets", "rewardPerPlanet",
+		"planetReward", "hooverUsed", "ecoSystemReward", "missedReward", "hooverRefill",
+		"totalSupply", "hooverFund", "sustainableFund",
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, r := range results {
+		record := []string{
+			strconv.FormatInt(r.TermSequence, 10),
+			r.IssueAmount.String(),
+			r.ActivePlanets.String(),
+			strconv.FormatInt(r.WorkingPlanets, 10),
+			r.RewardPerPlanet.String(),
+			r.PlanetReward.String(),
+			r.HooverUsed.String(),
+			r.EcoSystemReward.String(),
+			r.MissedReward.String(),
+			r.HooverRefill.String(),
+			r.TotalSupply.String(),
+			r.HooverFund.String(),
+			r.SustainableFund.String(),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func newHavahExportCmd(c string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   c,
+		Short: "Export HAVAH state at a given height",
+		Long: "Export HAVAH state at a given height\n" +
+			"The state includes platform config, planets, validators and balances of system accounts\n" +
+			"The chain should be stopped while its database is read",
+		Args: cli.ArgsWithDefaultErrorFunc(cobra.NoArgs),
+	}
+	flags := cmd.Flags()
+	dbPath := flags.String("db_path", "", "DB path of the chain. For example, .chain/hx.../<cid>/db/<cid>")
+	dbType := flags.String("db_type", "goleveldb", fmt.Sprintf("DB type %v", db.GetSupportedTypes()))
+	height := flags.Int64("height", 0, "Block height of the state")
+	maxPlanetID := flags.Int64("max_planet_id", 1_000_000, "Maximum planet id to look up")
+	out := flags.StringP("out", "o", "", "Output file path of the state in JSON (default: stdout)")
+	csvOut := flags.String("csv", "", "Output file path of planets in CSV")
+	cli.MarkAnnotationRequired(flags, "db_path", "height")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		dbase, err := db.Open(*dbPath, *dbType, "")
+		if err != nil {
+			return err
+		}
+		defer dbase.Close()
+
+		dump, err := havah.ExportState(dbase, *height, *maxPlanetID)
+		if err != nil {
+			return err
+		}
+
+		if len(*csvOut) > 0 {
+			f, err := os.Create(*csvOut)
+			if err != nil {
+				return err
+			}
+			err = printPlanetsInCSV(f, dump.State.Planets)
+			if cErr := f.Close(); err == nil {
+				err = cErr
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(*out) == 0 {
+			return cli.JsonPrettyPrintln(cmd.OutOrStdout(), dump)
+		}
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return cli.JsonPrettyPrintln(f, dump)
+	}
+	return cmd
+}
+
+func printPlanetsInCSV(w io.Writer, planets []*hvhstate.PlanetDump) error {
+	cw := csv.NewWriter(w)
+	header := []string{
+		"id", "owner", "isPrivate", "isCompany", "usdt", "price", "height",
+		"rewardTotal", "rewardRemain", "lastTermNumber",
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, p := range planets {
+		record := []string{
+			strconv.FormatInt(p.ID.Value, 10),
+			p.Owner.String(),
+			strconv.FormatBool(p.IsPrivate.Value),
+			strconv.FormatBool(p.IsCompany.Value),
+			p.USDT.String(),
+			p.Price.String(),
+			strconv.FormatInt(p.Height.Value, 10),
+			p.RewardTotal.String(),
+			p.RewardRemain.String(),
+			strconv.FormatInt(p.LastTermNumber.Value, 10),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func newHavahGenesisCmd(c string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("%s [state file]", c),
+		Short: "Generate genesis with exported HAVAH state",
+		Long: "Generate genesis with exported HAVAH state\n" +
+			"The havah section and system account balances of the template are filled with the state",
+		Args: cli.ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+	}
+	flags := cmd.Flags()
+	template := flags.StringP("template", "t", "genesis.json", "Genesis template file path")
+	genesisOut := flags.String("genesis_out", "",
+		"Output file path of the genesis (default: genesis_state.json next to the template)")
+	out := flags.StringP("out", "o", "", "Output file path of the genesis storage")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		raw, err := os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		dump := new(havah.StateDump)
+		if err = json.Unmarshal(raw, dump); err != nil {
+			return err
+		}
+		tmpl, err := os.ReadFile(*template)
+		if err != nil {
+			return err
+		}
+		genesis, err := havah.NewGenesisWithState(tmpl, dump)
+		if err != nil {
+			return err
+		}
+
+		// Contract paths in the template are relative to its directory
+		path := *genesisOut
+		if len(path) == 0 {
+			path = filepath.Join(filepath.Dir(*template), "genesis_state.json")
+		}
+		if err = os.WriteFile(path, genesis, 0644); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Genesis: %s\n", path)
+
+		if len(*out) > 0 {
+			f, err := os.OpenFile(*out, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0600)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			if err = gs.WriteFromPath(f, path); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Genesis storage: %s\n", *out)
+		}
+		return nil
+	}
+	return cmd
+}
+
+func newHavahDryRunCmd(c string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   c,
+		Short: "Preview the effect of a revision change at a given height",
+		Long: "Preview the effect of a revision change at a given height\n" +
+			"setRevision is called on a throwaway state as if governance sent it in the block of the height\n" +
+			"State differences are logged and the events are printed. Nothing is written to the database",
+		Args: cli.ArgsWithDefaultErrorFunc(cobra.NoArgs),
+	}
+	flags := cmd.Flags()
+	dbPath := flags.String("db_path", "", "DB path of the chain. For example, .chain/hx.../<cid>/db/<cid>")
+	dbType := flags.String("db_type", "goleveldb", fmt.Sprintf("DB type %v", db.GetSupportedTypes()))
+	height := flags.Int64("height", 0, "Block height of the state")
+	revision := flags.Int("revision", 0, "Target revision")
+	cid := flags.String("cid", "0x0", "Chain ID of the chain")
+	cli.MarkAnnotationRequired(flags, "db_path", "height", "revision")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		chainID, err := strconv.ParseInt(*cid, 0, 64)
+		if err != nil {
+			return err
+		}
+		dbase, err := db.Open(*dbPath, *dbType, "")
+		if err != nil {
+			return err
+		}
+		defer dbase.Close()
+
+		w := cmd.OutOrStdout()
+		logger := log.New()
+		logger.SetLevel(log.WarnLevel)
+		logger.SetOutput(w)
+		ret, err := havah.DryRunRevision(dbase, *height, int(chainID), *revision, logger)
+		if err != nil {
+			return err
+		}
+		jso, err := ret.ToJSON()
+		if err != nil {
+			return err
+		}
+		return cli.JsonPrettyPrintln(w, jso)
+	}
+	return cmd
+}
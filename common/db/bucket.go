@@ -57,6 +57,10 @@ const (
 	// TransactionLocatorByHash maps transaction locator from transaction hash.
 	TransactionLocatorByHash BucketID = "T"
 
+	// TransactionLocatorByAddress maps transactions which an address takes
+	// part in from the address.
+	TransactionLocatorByAddress BucketID = "A"
+
 	// BlockHeaderHashByHeight maps hash of encoded block header from height.
 	BlockHeaderHashByHeight BucketID = "H"
 
@@ -34,6 +34,18 @@ func New(database db.Database) trie.Manager {
 
 type BytesDifferenceHandler func(diff int, key, expect, real []byte)
 
+// compareKeys compares the current keys of two iterators.
+// An exhausted iterator is regarded as being after the other one
+func compareKeys(hasExp bool, ke []byte, hasReal bool, kr []byte) int {
+	if !hasExp {
+		return 1
+	}
+	if !hasReal {
+		return -1
+	}
+	return bytes.Compare(ke, kr)
+}
+
 func CompareImmutable(exp, real trie.Immutable, handler BytesDifferenceHandler) error {
 	for ie, ir := exp.Iterator(), real.Iterator(); ie.Has() || ir.Has(); {
 		ve, ke, err := ie.Get()
@@ -44,7 +56,7 @@ func CompareImmutable(exp, real trie.Immutable, handler BytesDifferenceHandler)
 		if err != nil {
 			return err
 		}
-		switch bytes.Compare(ke, kr) {
+		switch compareKeys(ie.Has(), ke, ir.Has(), kr) {
 		case -1:
 			handler(-1, ke, ve, nil)
 			if err := ie.Next(); err != nil {
@@ -82,7 +94,7 @@ func CompareImmutableForObject(exp, real trie.ImmutableForObject, handler Object
 		if err != nil {
 			return err
 		}
-		switch bytes.Compare(ke, kr) {
+		switch compareKeys(ie.Has(), ke, ir.Has(), kr) {
 		case -1:
 			handler(-1, ke, ve, nil)
 			if err := ie.Next(); err != nil {
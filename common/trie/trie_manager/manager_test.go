@@ -1043,3 +1043,37 @@ func TestOldValue_MutableSetDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestCompareImmutable(t *testing.T) {
+	m := New(db.NewMapDB())
+	exp := m.NewMutable(nil)
+	updateString(exp, "doe", "reindeer")
+	updateString(exp, "dog", "puppy")
+	real := m.NewMutable(nil)
+	updateString(real, "dog", "kitten")
+	updateString(real, "dogglesworth", "cat")
+
+	type diff struct {
+		op  int
+		key string
+	}
+	var diffs []diff
+	handler := func(op int, key, e, r []byte) {
+		diffs = append(diffs, diff{op, string(key)})
+	}
+
+	err := CompareImmutable(exp.GetSnapshot(), real.GetSnapshot(), handler)
+	assert.NoError(t, err)
+	assert.Equal(t, []diff{{-1, "doe"}, {0, "dog"}, {1, "dogglesworth"}}, diffs)
+
+	// One of them is empty
+	diffs = nil
+	err = CompareImmutable(m.NewImmutable(nil), real.GetSnapshot(), handler)
+	assert.NoError(t, err)
+	assert.Equal(t, []diff{{1, "dog"}, {1, "dogglesworth"}}, diffs)
+
+	diffs = nil
+	err = CompareImmutable(exp.GetSnapshot(), m.NewImmutable(nil), handler)
+	assert.NoError(t, err)
+	assert.Equal(t, []diff{{-1, "doe"}, {-1, "dog"}}, diffs)
+}
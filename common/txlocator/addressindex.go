@@ -0,0 +1,242 @@
+/*
+ * Copyright 2023 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txlocator
+
+import (
+	"encoding/binary"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/txresult"
+)
+
+// keyIndexedHeight is the key for the height of the last block whose
+// receipts are indexed. It never collides with address keys.
+var keyIndexedHeight = []byte("indexed_height")
+
+// keyFirstIndexedHeight is the key for the height of the first block whose
+// transactions are indexed.
+var keyFirstIndexedHeight = []byte("first_indexed_height")
+
+// AddressTransaction is a normal transaction which an address takes part in.
+type AddressTransaction struct {
+	ID          []byte
+	BlockHeight int64
+	Index       int
+}
+
+// AddressIndex keeps normal transactions which each address takes part in.
+// An address takes part in a transaction if it's the sender or the receiver
+// of the transaction, or an indexed Address parameter of its event logs.
+//
+// Keys in the bucket
+//
+//	address : number of transactions of the address
+//	address + seq(big endian uint64) : seq-th AddressTransaction of the address
+type AddressIndex struct {
+	bk db.Bucket
+}
+
+func NewAddressIndex(dbase db.Database) (*AddressIndex, error) {
+	bk, err := dbase.GetBucket(db.TransactionLocatorByAddress)
+	if err != nil {
+		return nil, err
+	}
+	return &AddressIndex{bk: bk}, nil
+}
+
+func keyForAddressTransaction(addr module.Address, seq int64) []byte {
+	ab := addr.Bytes()
+	key := make([]byte, len(ab)+8)
+	copy(key, ab)
+	binary.BigEndian.PutUint64(key[len(ab):], uint64(seq))
+	return key
+}
+
+func (ai *AddressIndex) getInt64(key []byte) (int64, error) {
+	bs, err := ai.bk.Get(key)
+	if err != nil || bs == nil {
+		return 0, err
+	}
+	var value int64
+	if _, err := codec.BC.UnmarshalFromBytes(bs, &value); err != nil {
+		return 0, errors.CriticalFormatError.Wrap(err, "InvalidAddressIndexData")
+	}
+	return value, nil
+}
+
+func (ai *AddressIndex) setInt64(key []byte, value int64) error {
+	return ai.bk.Set(key, codec.BC.MustMarshalToBytes(value))
+}
+
+// IndexedHeight returns the height of the last block whose receipts are
+// indexed. It returns 0 if nothing is indexed.
+func (ai *AddressIndex) IndexedHeight() (int64, error) {
+	return ai.getInt64(keyIndexedHeight)
+}
+
+// FirstIndexedHeight returns the height of the first block whose
+// transactions are indexed. Transactions of earlier blocks, which were
+// finalized before the index is enabled, are not indexed.
+// It returns -1 if nothing is indexed.
+func (ai *AddressIndex) FirstIndexedHeight() (int64, error) {
+	if ok, err := ai.bk.Has(keyFirstIndexedHeight); err != nil || !ok {
+		return -1, err
+	}
+	return ai.getInt64(keyFirstIndexedHeight)
+}
+
+// Count returns the number of transactions which the address takes part in.
+func (ai *AddressIndex) Count(addr module.Address) (int64, error) {
+	return ai.getInt64(addr.Bytes())
+}
+
+// IndexTransactions indexes normal transactions of the block at height-1
+// with their receipts in the result of the block at height.
+// It ignores the height which is already indexed, so it's safe to call it
+// again for the same block.
+func (ai *AddressIndex) IndexTransactions(
+	height int64,
+	txs module.TransactionList,
+	rcts module.ReceiptList,
+) error {
+	indexed, err := ai.IndexedHeight()
+	if err != nil {
+		return err
+	} else if height <= indexed {
+		return nil
+	}
+	counts := make(map[string]int64)
+	for itr := txs.Iterator(); itr.Has(); log.Must(itr.Next()) {
+		tx, idx, err := itr.Get()
+		if err != nil {
+			return err
+		}
+		rct, err := rcts.Get(idx)
+		if err != nil {
+			return err
+		}
+		value := codec.BC.MustMarshalToBytes(&AddressTransaction{
+			ID:          tx.ID(),
+			BlockHeight: height - 1,
+			Index:       idx,
+		})
+		for _, addr := range addressesOf(tx, rct) {
+			count, ok := counts[string(addr.Bytes())]
+			if !ok {
+				if count, err = ai.Count(addr); err != nil {
+					return err
+				}
+			}
+			if err = ai.bk.Set(keyForAddressTransaction(addr, count), value); err != nil {
+				return err
+			}
+			counts[string(addr.Bytes())] = count + 1
+		}
+	}
+	for key, count := range counts {
+		if err := ai.setInt64([]byte(key), count); err != nil {
+			return err
+		}
+	}
+	if indexed == 0 {
+		if err := ai.setInt64(keyFirstIndexedHeight, height-1); err != nil {
+			return err
+		}
+	}
+	return ai.setInt64(keyIndexedHeight, height)
+}
+
+// GetTransactions returns up to limit transactions of the address in
+// reverse order, starting from the one right before cursor.
+// Negative cursor means the number of transactions of the address.
+// It returns the cursor for the next transactions, which is 0 if there
+// are no more transactions, and the number of transactions of the address.
+func (ai *AddressIndex) GetTransactions(
+	addr module.Address, cursor int64, limit int,
+) ([]*AddressTransaction, int64, int64, error) {
+	total, err := ai.Count(addr)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if cursor < 0 {
+		cursor = total
+	} else if cursor > total {
+		return nil, 0, 0, errors.IllegalArgumentError.Errorf(
+			"InvalidCursor(cursor=%d,total=%d)", cursor, total)
+	}
+	txs := make([]*AddressTransaction, 0, limit)
+	for ; cursor > 0 && len(txs) < limit; cursor-- {
+		bs, err := ai.bk.Get(keyForAddressTransaction(addr, cursor-1))
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		if bs == nil {
+			return nil, 0, 0, errors.CriticalFormatError.Errorf(
+				"NoAddressTransaction(addr=%s,seq=%d)", addr, cursor-1)
+		}
+		tx := new(AddressTransaction)
+		if _, err := codec.BC.UnmarshalFromBytes(bs, tx); err != nil {
+			return nil, 0, 0, errors.CriticalFormatError.Wrap(err, "InvalidAddressTransaction")
+		}
+		txs = append(txs, tx)
+	}
+	return txs, cursor, total, nil
+}
+
+// addressesOf returns addresses which take part in the transaction without
+// duplication.
+func addressesOf(tx module.Transaction, rct module.Receipt) []module.Address {
+	addrs := make([]module.Address, 0, 2)
+	seen := make(map[string]bool)
+	add := func(addr module.Address) {
+		// receipts may return typed nil addresses
+		if common.AddressToPtr(addr) == nil || seen[string(addr.Bytes())] {
+			return
+		}
+		seen[string(addr.Bytes())] = true
+		addrs = append(addrs, addr)
+	}
+	add(tx.From())
+	add(rct.To())
+	add(rct.SCOREAddress())
+	for itr := rct.EventLogIterator(); itr.Has(); log.Must(itr.Next()) {
+		ev, err := itr.Get()
+		if err != nil {
+			break
+		}
+		indexed := ev.Indexed()
+		if len(indexed) < 2 {
+			continue
+		}
+		_, params := txresult.DecomposeEventSignature(string(indexed[0]))
+		for i, value := range indexed[1:] {
+			if i >= len(params) || params[i] != "Address" {
+				continue
+			}
+			if addr, err := common.NewAddress(value); err == nil {
+				add(addr)
+			}
+		}
+	}
+	return addrs
+}
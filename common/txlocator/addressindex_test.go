@@ -0,0 +1,159 @@
+/*
+ * Copyright 2023 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package txlocator
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/txresult"
+)
+
+type testAddressTx struct {
+	from module.Address
+	testDummyTx
+}
+
+func (t *testAddressTx) From() module.Address {
+	return t.from
+}
+
+type testAddressTxList struct {
+	txs []*testAddressTx
+	module.TransactionList
+}
+
+func (l *testAddressTxList) Iterator() module.TransactionIterator {
+	return &testAddressTxIterator{l: l}
+}
+
+type testAddressTxIterator struct {
+	l   *testAddressTxList
+	idx int
+}
+
+func (t *testAddressTxIterator) Has() bool {
+	return t.idx < len(t.l.txs)
+}
+
+func (t *testAddressTxIterator) Next() error {
+	t.idx += 1
+	return nil
+}
+
+func (t *testAddressTxIterator) Get() (module.Transaction, int, error) {
+	return t.l.txs[t.idx], t.idx, nil
+}
+
+func TestAddressIndex_Basic(t *testing.T) {
+	dbase := db.NewMapDB()
+	ai, err := NewAddressIndex(dbase)
+	assert.NoError(t, err)
+
+	eoa1 := common.MustNewAddressFromString("hx01")
+	eoa2 := common.MustNewAddressFromString("hx02")
+	eoa3 := common.MustNewAddressFromString("hx03")
+	score := common.MustNewAddressFromString("cx01")
+
+	txs := &testAddressTxList{
+		txs: []*testAddressTx{
+			{from: eoa1, testDummyTx: testDummyTx{id: txIDFromInt(0)}},
+			{from: eoa2, testDummyTx: testDummyTx{id: txIDFromInt(1)}},
+		},
+	}
+	rct0 := txresult.NewReceipt(dbase, module.AllRevision, eoa2)
+	rct1 := txresult.NewReceipt(dbase, module.AllRevision, score)
+	rct1.AddLog(score, [][]byte{
+		[]byte("Transfer(Address,Address,int)"),
+		eoa2.Bytes(),
+		eoa3.Bytes(),
+	}, [][]byte{{0x01}})
+	for _, rct := range []txresult.Receipt{rct0, rct1} {
+		rct.SetResult(module.StatusSuccess, big.NewInt(100), big.NewInt(10), nil)
+	}
+	rcts := txresult.NewReceiptListFromSlice(dbase, []txresult.Receipt{rct0, rct1})
+
+	height, err := ai.FirstIndexedHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-1), height)
+
+	err = ai.IndexTransactions(11, txs, rcts)
+	assert.NoError(t, err)
+	height, err = ai.IndexedHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(11), height)
+	height, err = ai.FirstIndexedHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), height)
+
+	// Indexing the same block again does nothing
+	err = ai.IndexTransactions(11, txs, rcts)
+	assert.NoError(t, err)
+
+	// Later blocks keep the first indexed height
+	err = ai.IndexTransactions(12, &testAddressTxList{}, txresult.NewReceiptListFromSlice(dbase, nil))
+	assert.NoError(t, err)
+	height, err = ai.FirstIndexedHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), height)
+
+	for _, c := range []struct {
+		addr  module.Address
+		count int64
+	}{
+		{eoa1, 1}, {eoa2, 2}, {eoa3, 1}, {score, 1},
+	} {
+		count, err := ai.Count(c.addr)
+		assert.NoError(t, err)
+		assert.Equal(t, c.count, count, "addr=%s", c.addr)
+	}
+
+	// Newer transactions come first
+	atxs, next, total, err := ai.GetTransactions(eoa2, -1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, int64(0), next)
+	assert.Equal(t, 2, len(atxs))
+	assert.Equal(t, txIDFromInt(1), atxs[0].ID)
+	assert.Equal(t, int64(10), atxs[0].BlockHeight)
+	assert.Equal(t, 1, atxs[0].Index)
+	assert.Equal(t, txIDFromInt(0), atxs[1].ID)
+
+	// Pagination
+	atxs, next, _, err = ai.GetTransactions(eoa2, -1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(atxs))
+	assert.Equal(t, int64(1), next)
+	atxs, next, _, err = ai.GetTransactions(eoa2, next, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, txIDFromInt(0), atxs[0].ID)
+	assert.Equal(t, int64(0), next)
+
+	_, _, _, err = ai.GetTransactions(eoa2, 3, 1)
+	assert.Error(t, err)
+
+	atxs, _, total, err = ai.GetTransactions(common.MustNewAddressFromString("hx04"), -1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+	assert.Equal(t, 0, len(atxs))
+}
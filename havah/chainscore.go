@@ -604,6 +604,445 @@ var chainMethods = []*chainMethod{
 			scoreapi.Dict,
 		},
 	}, hvhmodule.RevisionDecentralization, 0},
+	{scoreapi.Method{scoreapi.Function, "getRewardHistoryOf",
+		scoreapi.FlagReadOnly, 3,
+		[]scoreapi.Parameter{
+			{"id", scoreapi.Integer, nil, nil},
+			{"fromTerm", scoreapi.Integer, nil, nil},
+			{"toTerm", scoreapi.Integer, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, hvhmodule.RevisionPlanetRewardHistory, 0},
+	{scoreapi.Method{scoreapi.Function, "reportPlanetWorks",
+		scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"ids", scoreapi.ListTypeOf(1, scoreapi.Integer), nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionBatchedPlanetWork, 0},
+	{scoreapi.Method{scoreapi.Function, "setPlanetClaimDelegate",
+		scoreapi.FlagExternal, 2,
+		[]scoreapi.Parameter{
+			{"id", scoreapi.Integer, nil, nil},
+			{"delegate", scoreapi.Address, nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionPlanetClaimDelegate, 0},
+	{scoreapi.Method{scoreapi.Function, "setPlanetRewardBeneficiary",
+		scoreapi.FlagExternal, 2,
+		[]scoreapi.Parameter{
+			{"id", scoreapi.Integer, nil, nil},
+			{"beneficiary", scoreapi.Address, nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionPlanetClaimDelegate, 0},
+	{scoreapi.Method{scoreapi.Function, "getPlanetClaimConfig",
+		scoreapi.FlagReadOnly, 1,
+		[]scoreapi.Parameter{
+			{"id", scoreapi.Integer, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, hvhmodule.RevisionPlanetClaimDelegate, 0},
+	{scoreapi.Method{scoreapi.Function, "getPlanetsOf",
+		scoreapi.FlagReadOnly, 1,
+		[]scoreapi.Parameter{
+			{"owner", scoreapi.Address, nil, nil},
+			{"start", scoreapi.Integer, nil, nil},
+			{"limit", scoreapi.Integer, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, hvhmodule.RevisionPlanetOwnerIndex, 0},
+	{scoreapi.Method{scoreapi.Function, "indexPlanets",
+		scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"ids", scoreapi.ListTypeOf(1, scoreapi.Integer), nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionPlanetOwnerIndex, 0},
+	{scoreapi.Method{scoreapi.Function, "addPriceReporter",
+		scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"address", scoreapi.Address, nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionPriceOracle, 0},
+	{scoreapi.Method{scoreapi.Function, "removePriceReporter",
+		scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"address", scoreapi.Address, nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionPriceOracle, 0},
+	{scoreapi.Method{scoreapi.Function, "reportUSDTPrice",
+		scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"price", scoreapi.Integer, nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionPriceOracle, 0},
+	{scoreapi.Method{scoreapi.Function, "setPriceReportParameters",
+		scoreapi.FlagExternal, 2,
+		[]scoreapi.Parameter{
+			{"ttl", scoreapi.Integer, nil, nil},
+			{"minReports", scoreapi.Integer, nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionPriceOracle, 0},
+	{scoreapi.Method{scoreapi.Function, "getPriceReports",
+		scoreapi.FlagReadOnly, 0,
+		nil,
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, hvhmodule.RevisionPriceOracle, 0},
+	{scoreapi.Method{scoreapi.Function, "setValidatorRewardRate",
+		scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"rate", scoreapi.Integer, nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionValidatorReward, 0},
+	{scoreapi.Method{scoreapi.Function, "claimValidatorReward",
+		scoreapi.FlagExternal, 0,
+		nil,
+		nil,
+	}, hvhmodule.RevisionValidatorReward, 0},
+	{scoreapi.Method{scoreapi.Function, "getValidatorRewardInfo",
+		scoreapi.FlagReadOnly, 1,
+		[]scoreapi.Parameter{
+			{"owner", scoreapi.Address, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, hvhmodule.RevisionValidatorReward, 0},
+	{scoreapi.Method{scoreapi.Function, "handleDoubleSignReport",
+		scoreapi.FlagExternal, 3,
+		[]scoreapi.Parameter{
+			{"type", scoreapi.String, nil, nil},
+			{"blockHeight", scoreapi.Integer, nil, nil},
+			{"signer", scoreapi.Address, nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionDoubleSignSlashing, 0},
+	{scoreapi.Method{scoreapi.Function, "getDoubleSignEvidence",
+		scoreapi.FlagReadOnly, 1,
+		[]scoreapi.Parameter{
+			{"owner", scoreapi.Address, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, hvhmodule.RevisionDoubleSignSlashing, 0},
+	{scoreapi.Method{scoreapi.Function, "setJailParameters",
+		scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"cooldown", scoreapi.Integer, nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionValidatorJail, 0},
+	{scoreapi.Method{scoreapi.Function, "getJailParameters",
+		scoreapi.FlagReadOnly, 0,
+		nil,
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, hvhmodule.RevisionValidatorJail, 0},
+	{scoreapi.Method{scoreapi.Function, "getJailedValidators",
+		scoreapi.FlagReadOnly, 0,
+		nil,
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, hvhmodule.RevisionValidatorJail, 0},
+	{scoreapi.Method{scoreapi.Function, "getValidatorUptime",
+		scoreapi.FlagReadOnly, 1,
+		[]scoreapi.Parameter{
+			{"owner", scoreapi.Address, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, hvhmodule.RevisionValidatorUptime, 0},
+	{scoreapi.Method{scoreapi.Function, "getValidatorUptimesOf",
+		scoreapi.FlagReadOnly, 1,
+		[]scoreapi.Parameter{
+			{"grade", scoreapi.String, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, hvhmodule.RevisionValidatorUptime, 0},
+	{scoreapi.Method{scoreapi.Function, "submitProposal",
+		scoreapi.FlagExternal, 3,
+		[]scoreapi.Parameter{
+			{"type", scoreapi.String, nil, nil},
+			{"value", scoreapi.String, nil, nil},
+			{"description", scoreapi.String, nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionGovernanceProposal, 0},
+	{scoreapi.Method{scoreapi.Function, "voteProposal",
+		scoreapi.FlagExternal, 2,
+		[]scoreapi.Parameter{
+			{"id", scoreapi.Integer, nil, nil},
+			{"agree", scoreapi.Bool, nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionGovernanceProposal, 0},
+	{scoreapi.Method{scoreapi.Function, "cancelProposal",
+		scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"id", scoreapi.Integer, nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionGovernanceProposal, 0},
+	{scoreapi.Method{scoreapi.Function, "getProposal",
+		scoreapi.FlagReadOnly, 1,
+		[]scoreapi.Parameter{
+			{"id", scoreapi.Integer, nil, nil},
+			{"voter", scoreapi.Address, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, hvhmodule.RevisionGovernanceProposal, 0},
+	{scoreapi.Method{scoreapi.Function, "getProposals",
+		scoreapi.FlagReadOnly, 0,
+		nil,
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, hvhmodule.RevisionGovernanceProposal, 0},
+	{scoreapi.Method{scoreapi.Function, "scheduleParameterChange",
+		scoreapi.FlagExternal, 2,
+		[]scoreapi.Parameter{
+			{"type", scoreapi.String, nil, nil},
+			{"value", scoreapi.String, nil, nil},
+			{"height", scoreapi.Integer, nil, nil},
+			{"term", scoreapi.Integer, nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionParameterChange, 0},
+	{scoreapi.Method{scoreapi.Function, "cancelParameterChange",
+		scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"id", scoreapi.Integer, nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionParameterChange, 0},
+	{scoreapi.Method{scoreapi.Function, "getPendingChanges",
+		scoreapi.FlagReadOnly, 0,
+		nil,
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, hvhmodule.RevisionParameterChange, 0},
+	{scoreapi.Method{scoreapi.Function, "addVestingSchedule",
+		scoreapi.FlagExternal, 3,
+		[]scoreapi.Parameter{
+			{"name", scoreapi.String, nil, nil},
+			{"type", scoreapi.String, nil, nil},
+			{"cliff", scoreapi.Integer, nil, nil},
+			{"duration", scoreapi.Integer, nil, nil},
+			{"steps", scoreapi.Integer, nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionVestingSchedule, 0},
+	{scoreapi.Method{scoreapi.Function, "setClassVesting",
+		scoreapi.FlagExternal, 2,
+		[]scoreapi.Parameter{
+			{"class", scoreapi.String, nil, nil},
+			{"name", scoreapi.String, nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionVestingSchedule, 0},
+	{scoreapi.Method{scoreapi.Function, "setPlanetVesting",
+		scoreapi.FlagExternal, 2,
+		[]scoreapi.Parameter{
+			{"id", scoreapi.Integer, nil, nil},
+			{"name", scoreapi.String, nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionVestingSchedule, 0},
+	{scoreapi.Method{scoreapi.Function, "getVestingSchedules",
+		scoreapi.FlagReadOnly, 0,
+		nil,
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, hvhmodule.RevisionVestingSchedule, 0},
+	{scoreapi.Method{scoreapi.Function, "getPlanetVestingInfo",
+		scoreapi.FlagReadOnly, 1,
+		[]scoreapi.Parameter{
+			{"id", scoreapi.Integer, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, hvhmodule.RevisionVestingSchedule, 0},
+	{scoreapi.Method{scoreapi.Function, "setPlanetClassWeight",
+		scoreapi.FlagExternal, 2,
+		[]scoreapi.Parameter{
+			{"class", scoreapi.String, nil, nil},
+			{"weight", scoreapi.Integer, nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionPlanetWeight, 0},
+	{scoreapi.Method{scoreapi.Function, "setPlanetWeight",
+		scoreapi.FlagExternal, 2,
+		[]scoreapi.Parameter{
+			{"id", scoreapi.Integer, nil, nil},
+			{"weight", scoreapi.Integer, nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionPlanetWeight, 0},
+	{scoreapi.Method{scoreapi.Function, "getPlanetWeights",
+		scoreapi.FlagReadOnly, 0,
+		nil,
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, hvhmodule.RevisionPlanetWeight, 0},
+	{scoreapi.Method{scoreapi.Function, "getPlanetWeight",
+		scoreapi.FlagReadOnly, 1,
+		[]scoreapi.Parameter{
+			{"id", scoreapi.Integer, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, hvhmodule.RevisionPlanetWeight, 0},
+	{scoreapi.Method{scoreapi.Function, "claimAllPlanetRewards",
+		scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"cursor", scoreapi.Integer, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Integer,
+		},
+	}, hvhmodule.RevisionClaimAllPlanets, 0},
+	{scoreapi.Method{scoreapi.Function, "getClaimablePlanetRewards",
+		scoreapi.FlagReadOnly, 2,
+		[]scoreapi.Parameter{
+			{"owner", scoreapi.Address, nil, nil},
+			{"cursor", scoreapi.Integer, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, hvhmodule.RevisionClaimAllPlanets, 0},
+	{scoreapi.Method{scoreapi.Function, "createMultisig",
+		scoreapi.FlagExternal, 2,
+		[]scoreapi.Parameter{
+			{"signers", scoreapi.ListTypeOf(1, scoreapi.Address), nil, nil},
+			{"threshold", scoreapi.Integer, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Address,
+		},
+	}, hvhmodule.RevisionMultisig, 0},
+	{scoreapi.Method{scoreapi.Function, "setMultisigSigners",
+		scoreapi.FlagExternal, 2,
+		[]scoreapi.Parameter{
+			{"signers", scoreapi.ListTypeOf(1, scoreapi.Address), nil, nil},
+			{"threshold", scoreapi.Integer, nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionMultisig, 0},
+	{scoreapi.Method{scoreapi.Function, "submitMultisigOperation",
+		scoreapi.FlagExternal, 3,
+		[]scoreapi.Parameter{
+			{"multisig", scoreapi.Address, nil, nil},
+			{"to", scoreapi.Address, nil, nil},
+			{"method", scoreapi.String, nil, nil},
+			{"params", scoreapi.String, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Integer,
+		},
+	}, hvhmodule.RevisionMultisig, 0},
+	{scoreapi.Method{scoreapi.Function, "approveMultisigOperation",
+		scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"id", scoreapi.Integer, nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionMultisig, 0},
+	{scoreapi.Method{scoreapi.Function, "executeMultisigOperation",
+		scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"id", scoreapi.Integer, nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionMultisig, 0},
+	{scoreapi.Method{scoreapi.Function, "cancelMultisigOperation",
+		scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"id", scoreapi.Integer, nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionMultisig, 0},
+	{scoreapi.Method{scoreapi.Function, "getMultisig",
+		scoreapi.FlagReadOnly, 1,
+		[]scoreapi.Parameter{
+			{"address", scoreapi.Address, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, hvhmodule.RevisionMultisig, 0},
+	{scoreapi.Method{scoreapi.Function, "getMultisigOperation",
+		scoreapi.FlagReadOnly, 1,
+		[]scoreapi.Parameter{
+			{"id", scoreapi.Integer, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, hvhmodule.RevisionMultisig, 0},
+	{scoreapi.Method{scoreapi.Function, "getFundLedger",
+		scoreapi.FlagReadOnly, 3,
+		[]scoreapi.Parameter{
+			{"fund", scoreapi.String, nil, nil},
+			{"fromTerm", scoreapi.Integer, nil, nil},
+			{"toTerm", scoreapi.Integer, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, hvhmodule.RevisionFundLedger, 0},
+	{scoreapi.Method{scoreapi.Function, "setPlanetNodeKey",
+		scoreapi.FlagExternal, 2,
+		[]scoreapi.Parameter{
+			{"id", scoreapi.Integer, nil, nil},
+			{"nodeKey", scoreapi.Bytes, nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionPlanetNodeKey, 0},
+	{scoreapi.Method{scoreapi.Function, "getPlanetNodeKey",
+		scoreapi.FlagReadOnly, 1,
+		[]scoreapi.Parameter{
+			{"id", scoreapi.Integer, nil, nil},
+		},
+		[]scoreapi.DataType{
+			scoreapi.Dict,
+		},
+	}, hvhmodule.RevisionPlanetNodeKey, 0},
+	{scoreapi.Method{scoreapi.Function, "reportAttestedPlanetWork",
+		scoreapi.FlagExternal, 2,
+		[]scoreapi.Parameter{
+			{"id", scoreapi.Integer, nil, nil},
+			{"signature", scoreapi.Bytes, nil, nil},
+		},
+		nil,
+	}, hvhmodule.RevisionPlanetNodeKey, 0},
 }
 
 func initFeeConfig(cfg *FeeConfig, as state.AccountState) error {
@@ -685,7 +1124,7 @@ func applyStepPrice(as state.AccountState, price *big.Int) error {
 	return scoredb.NewVarDB(as, state.VarStepPrice).Set(price)
 }
 
-func (s *chainScore) initPlatformConfig(cfg *hvh.PlatformConfig) error {
+func (s *chainScore) initPlatformConfig(cfg *hvh.PlatformConfig, revision int) error {
 	if cfg == nil {
 		return nil
 	}
@@ -700,6 +1139,11 @@ func (s *chainScore) initPlatformConfig(cfg *hvh.PlatformConfig) error {
 	if err = es.InitPlatformConfig(cfg); err != nil {
 		return err
 	}
+	if cfg.State != nil {
+		if err = es.ImportState(revision, cfg.State); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -813,7 +1257,7 @@ func (s *chainScore) Install(param []byte) error {
 
 	platformConfig := cfg.Havah
 	if platformConfig != nil {
-		if err := s.initPlatformConfig(platformConfig); err != nil {
+		if err := s.initPlatformConfig(platformConfig, revision); err != nil {
 			return transaction.InvalidGenesisError.Wrap(err, "Failed to initialize platformConfig")
 		}
 	} else {
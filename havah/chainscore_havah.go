@@ -23,9 +23,12 @@ import (
 	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/havah/hvh"
+	"github.com/icon-project/goloop/havah/hvh/hvhstate"
 	"github.com/icon-project/goloop/havah/hvhmodule"
 	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/contract"
 	"github.com/icon-project/goloop/service/scoreresult"
+	"github.com/icon-project/goloop/service/state"
 )
 
 func (s *chainScore) checkNFT(charge bool) error {
@@ -119,6 +122,376 @@ func (s *chainScore) Ex_setUSDTPrice(price *common.HexInt) error {
 	return es.SetUSDTPrice(price.Value())
 }
 
+func (s *chainScore) Ex_addPriceReporter(address module.Address) error {
+	if err := s.checkGovernance(true); err != nil {
+		return err
+	}
+	es, _, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	return es.AddPriceReporter(address)
+}
+
+func (s *chainScore) Ex_removePriceReporter(address module.Address) error {
+	if err := s.checkGovernance(true); err != nil {
+		return err
+	}
+	es, _, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	return es.RemovePriceReporter(address)
+}
+
+func (s *chainScore) Ex_reportUSDTPrice(price *common.HexInt) error {
+	if err := s.tryChargeCall(); err != nil {
+		return err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	return es.ReportUSDTPrice(ctx, price.Value())
+}
+
+func (s *chainScore) Ex_setPriceReportParameters(ttl, minReports *common.HexInt) error {
+	if err := s.checkGovernance(true); err != nil {
+		return err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	return es.SetPriceReportParameters(ctx, ttl.Int64(), minReports.Int64())
+}
+
+func (s *chainScore) Ex_getPriceReports() (map[string]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return nil, err
+	}
+	return es.GetPriceReports(ctx)
+}
+
+func (s *chainScore) Ex_setValidatorRewardRate(rate *common.HexInt) error {
+	if err := s.checkGovernance(true); err != nil {
+		return err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	return es.SetValidatorRewardRate(ctx, rate.Int64())
+}
+
+func (s *chainScore) Ex_claimValidatorReward() error {
+	if err := s.tryChargeCall(); err != nil {
+		return err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	return es.ClaimValidatorReward(ctx)
+}
+
+func (s *chainScore) Ex_getValidatorRewardInfo(owner module.Address) (map[string]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return nil, err
+	}
+	return es.GetValidatorRewardInfo(ctx, owner)
+}
+
+// Ex_handleDoubleSignReport is called only by the system on handling a double sign report transaction
+func (s *chainScore) Ex_handleDoubleSignReport(
+	dsType string, dsBlockHeight *common.HexInt, signer module.Address) error {
+	if !state.SystemAddress.Equal(s.from) {
+		return scoreresult.AccessDeniedError.Errorf("NoPermission(from=%s)", s.from)
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	return es.HandleDoubleSignReport(ctx, dsType, dsBlockHeight.Int64(), signer)
+}
+
+func (s *chainScore) Ex_getDoubleSignEvidence(owner module.Address) (map[string]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return nil, err
+	}
+	return es.GetDoubleSignEvidence(ctx, owner)
+}
+
+func (s *chainScore) Ex_setJailParameters(cooldown *common.HexInt) error {
+	if err := s.checkGovernance(true); err != nil {
+		return err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	return es.SetJailParameters(ctx, cooldown.Int64())
+}
+
+func (s *chainScore) Ex_getJailParameters() (map[string]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return nil, err
+	}
+	return es.GetJailParameters(ctx)
+}
+
+func (s *chainScore) Ex_getJailedValidators() (map[string]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return nil, err
+	}
+	return es.GetJailedValidators(ctx)
+}
+
+func (s *chainScore) Ex_getValidatorUptime(owner module.Address) (map[string]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return nil, err
+	}
+	return es.GetValidatorUptime(ctx, owner)
+}
+
+func (s *chainScore) Ex_getValidatorUptimesOf(grade string) (map[string]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return nil, err
+	}
+	return es.GetValidatorUptimesOf(ctx, grade)
+}
+
+func (s *chainScore) Ex_submitProposal(pType, value, description string) error {
+	if err := s.tryChargeCall(); err != nil {
+		return err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	return es.SubmitProposal(ctx, pType, value, description)
+}
+
+func (s *chainScore) Ex_voteProposal(id *common.HexInt, agree bool) error {
+	if err := s.tryChargeCall(); err != nil {
+		return err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	return es.VoteProposal(ctx, id.Int64(), agree)
+}
+
+func (s *chainScore) Ex_cancelProposal(id *common.HexInt) error {
+	if err := s.tryChargeCall(); err != nil {
+		return err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	return es.CancelProposal(ctx, id.Int64())
+}
+
+func (s *chainScore) Ex_getProposal(id *common.HexInt, voter module.Address) (map[string]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return nil, err
+	}
+	return es.GetProposal(ctx, id.Int64(), voter)
+}
+
+func (s *chainScore) Ex_getProposals() (map[string]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return nil, err
+	}
+	return es.GetProposals(ctx)
+}
+
+func (s *chainScore) Ex_scheduleParameterChange(
+	pType, value string, height *common.HexInt, term *common.HexInt) error {
+	if err := s.checkGovernance(true); err != nil {
+		return err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	var h, ts int64
+	if height != nil {
+		h = height.Int64()
+	}
+	if term != nil {
+		ts = term.Int64()
+	}
+	return es.ScheduleParameterChange(ctx, pType, value, h, ts)
+}
+
+func (s *chainScore) Ex_cancelParameterChange(id *common.HexInt) error {
+	if err := s.checkGovernance(true); err != nil {
+		return err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	return es.CancelParameterChange(ctx, id.Int64())
+}
+
+func (s *chainScore) Ex_getPendingChanges() (map[string]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return nil, err
+	}
+	return es.GetPendingChanges(ctx)
+}
+
+func (s *chainScore) Ex_addVestingSchedule(
+	name, vType string, cliff *common.HexInt, duration *common.HexInt, steps *common.HexInt) error {
+	if err := s.checkGovernance(true); err != nil {
+		return err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	var d, st int64
+	if duration != nil {
+		d = duration.Int64()
+	}
+	if steps != nil {
+		st = steps.Int64()
+	}
+	return es.AddVestingSchedule(ctx, name, vType, cliff.Int64(), d, st)
+}
+
+func (s *chainScore) Ex_setClassVesting(class, name string) error {
+	if err := s.checkGovernance(true); err != nil {
+		return err
+	}
+	es, _, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	return es.SetClassVesting(class, name)
+}
+
+func (s *chainScore) Ex_setPlanetVesting(id *common.HexInt, name string) error {
+	if err := s.checkGovernance(true); err != nil {
+		return err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	return es.SetPlanetVesting(ctx, id.Int64(), name)
+}
+
+func (s *chainScore) Ex_getVestingSchedules() (map[string]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return nil, err
+	}
+	return es.GetVestingSchedules(ctx)
+}
+
+func (s *chainScore) Ex_getPlanetVestingInfo(id *common.HexInt) (map[string]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return nil, err
+	}
+	return es.GetPlanetVestingInfo(ctx, id.Int64())
+}
+
+func (s *chainScore) Ex_setPlanetClassWeight(class string, weight *common.HexInt) error {
+	if err := s.checkGovernance(true); err != nil {
+		return err
+	}
+	es, _, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	return es.SetPlanetClassWeight(class, weight.Int64())
+}
+
+func (s *chainScore) Ex_setPlanetWeight(id *common.HexInt, weight *common.HexInt) error {
+	if err := s.checkGovernance(true); err != nil {
+		return err
+	}
+	es, _, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	return es.SetPlanetWeight(id.Int64(), weight.Int64())
+}
+
+func (s *chainScore) Ex_getPlanetWeights() (map[string]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return nil, err
+	}
+	return es.GetPlanetWeights(ctx)
+}
+
+func (s *chainScore) Ex_getPlanetWeight(id *common.HexInt) (map[string]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return nil, err
+	}
+	return es.GetPlanetWeight(ctx, id.Int64())
+}
+
 func (s *chainScore) Ex_getIssueInfo() (map[string]interface{}, error) {
 	if s.cc.Revision().Value() >= hvhmodule.RevisionFixStepCharge {
 		if err := s.tryChargeCall(); err != nil {
@@ -230,6 +603,41 @@ func (s *chainScore) Ex_getPlanetInfo(id *common.HexInt) (map[string]interface{}
 	return es.GetPlanetInfo(ctx, id.Int64())
 }
 
+func (s *chainScore) Ex_getPlanetsOf(
+	owner module.Address, start, limit *common.HexInt) (map[string]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return nil, err
+	}
+	var st int64
+	lm := hvhmodule.MaxCountToQuery
+	if start != nil {
+		st = start.Int64()
+	}
+	if limit != nil {
+		lm = int(limit.Int64())
+	}
+	return es.GetPlanetsOf(ctx, owner, st, lm)
+}
+
+func (s *chainScore) Ex_indexPlanets(ids []interface{}) error {
+	if err := s.checkGovernance(true); err != nil {
+		return err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	planetIds := make([]int64, len(ids))
+	for i := 0; i < len(ids); i++ {
+		planetIds[i] = (ids[i].(*common.HexInt)).Int64()
+	}
+	return es.IndexPlanets(ctx, planetIds)
+}
+
 func (s *chainScore) Ex_reportPlanetWork(id *common.HexInt) error {
 	if err := s.tryChargeCall(); err != nil {
 		return err
@@ -248,6 +656,37 @@ func (s *chainScore) Ex_reportPlanetWork(id *common.HexInt) error {
 	return es.ReportPlanetWork(ctx, id.Int64())
 }
 
+func (s *chainScore) Ex_reportPlanetWorks(ids []interface{}) error {
+	if err := s.tryChargeCall(); err != nil {
+		return err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	ok, err := es.IsPlanetManager(s.from)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return scoreresult.AccessDeniedError.Errorf("NoPermission: %s", s.from)
+	}
+
+	// Each planet in a batch costs as much as a reportPlanetWork call does
+	if len(ids) > 1 && !s.gov {
+		if !s.cc.ApplySteps(state.StepTypeContractCall, len(ids)-1) {
+			return scoreresult.OutOfStepError.New("OutOfStepFor(contractCall)")
+		}
+	}
+
+	planetIds := make([]int64, len(ids))
+	for i := 0; i < len(ids); i++ {
+		planetIds[i] = (ids[i].(*common.HexInt)).Int64()
+	}
+	// The number of ids is checked in ExtensionStateImpl.ReportPlanetWorks()
+	return es.ReportPlanetWorks(ctx, planetIds)
+}
+
 func (s *chainScore) Ex_claimPlanetReward(ids []interface{}) error {
 	if err := s.tryChargeCall(); err != nil {
 		return err
@@ -261,10 +700,266 @@ func (s *chainScore) Ex_claimPlanetReward(ids []interface{}) error {
 	for i := 0; i < len(ids); i++ {
 		planetIds[i] = (ids[i].(*common.HexInt)).Int64()
 	}
-	// PlanetOwner is checked in ExtensionStateImpl.ClaimPlanetReward()
+	// PlanetOwner or its claim delegate is checked in ExtensionStateImpl.ClaimPlanetReward()
 	return es.ClaimPlanetReward(ctx, planetIds)
 }
 
+func (s *chainScore) Ex_claimAllPlanetRewards(cursor *common.HexInt) (int64, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return 0, err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return 0, err
+	}
+	return es.ClaimAllPlanetRewards(ctx, cursor.Int64())
+}
+
+func (s *chainScore) Ex_getClaimablePlanetRewards(
+	owner module.Address, cursor *common.HexInt) (map[string]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return nil, err
+	}
+	return es.GetClaimablePlanetRewards(ctx, owner, cursor.Int64())
+}
+
+func toMultisigSigners(signers []interface{}) []module.Address {
+	addresses := make([]module.Address, len(signers))
+	for i, signer := range signers {
+		addresses[i], _ = signer.(module.Address)
+	}
+	return addresses
+}
+
+func (s *chainScore) Ex_createMultisig(signers []interface{}, threshold *common.HexInt) (module.Address, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return nil, err
+	}
+	return es.CreateMultisig(ctx, toMultisigSigners(signers), threshold.Int64())
+}
+
+// Ex_setMultisigSigners changes the signers of the multisig account which calls it
+// through an approved operation
+func (s *chainScore) Ex_setMultisigSigners(signers []interface{}, threshold *common.HexInt) error {
+	if err := s.tryChargeCall(); err != nil {
+		return err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	return es.SetMultisigSigners(ctx, toMultisigSigners(signers), threshold.Int64())
+}
+
+func (s *chainScore) Ex_submitMultisigOperation(
+	multisig, to module.Address, method, params string) (int64, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return 0, err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return 0, err
+	}
+	op, ready, err := es.SubmitMultisigOperation(ctx, multisig, to, method, params)
+	if err != nil {
+		return 0, err
+	}
+	if ready {
+		if err = s.executeMultisigOperation(es, ctx, op); err != nil {
+			return 0, err
+		}
+	}
+	return op.ID(), nil
+}
+
+func (s *chainScore) Ex_approveMultisigOperation(id *common.HexInt) error {
+	if err := s.tryChargeCall(); err != nil {
+		return err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	op, ready, err := es.ApproveMultisigOperation(ctx, id.Int64())
+	if err != nil {
+		return err
+	}
+	if ready {
+		return s.executeMultisigOperation(es, ctx, op)
+	}
+	return nil
+}
+
+// Ex_executeMultisigOperation executes a pending operation which already has enough approvals,
+// which happens when the signers or the threshold of its multisig account are changed after its last approval
+func (s *chainScore) Ex_executeMultisigOperation(id *common.HexInt) error {
+	if err := s.tryChargeCall(); err != nil {
+		return err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	op, err := es.GetExecutableMultisigOperation(ctx, id.Int64())
+	if err != nil {
+		return err
+	}
+	return s.executeMultisigOperation(es, ctx, op)
+}
+
+// executeMultisigOperation calls the method of an operation on behalf of its multisig account.
+// The called method sees the multisig account as its sender
+func (s *chainScore) executeMultisigOperation(
+	es *hvh.ExtensionStateImpl, ctx hvhmodule.CallContext, op *hvhstate.MultisigOperation) error {
+	return es.ExecuteMultisigOperation(ctx, op, func() error {
+		data := map[string]interface{}{"method": op.Method()}
+		if len(op.Params()) > 0 {
+			data["params"] = json.RawMessage(op.Params())
+		}
+		bs, err := json.Marshal(data)
+		if err != nil {
+			return scoreresult.InvalidParameterError.Wrap(err, "InvalidOperationData")
+		}
+		handler, err := s.cc.ContractManager().GetHandler(
+			op.Multisig(), op.To(), hvhmodule.BigIntZero, contract.CTypeCall, bs)
+		if err != nil {
+			return err
+		}
+		status, steps, _, _ := s.cc.Call(handler, s.cc.StepAvailable())
+		s.cc.DeductSteps(steps)
+		return status
+	})
+}
+
+func (s *chainScore) Ex_cancelMultisigOperation(id *common.HexInt) error {
+	if err := s.tryChargeCall(); err != nil {
+		return err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	return es.CancelMultisigOperation(ctx, id.Int64())
+}
+
+func (s *chainScore) Ex_getMultisig(address module.Address) (map[string]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return nil, err
+	}
+	return es.GetMultisig(ctx, address)
+}
+
+func (s *chainScore) Ex_getMultisigOperation(id *common.HexInt) (map[string]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return nil, err
+	}
+	return es.GetMultisigOperation(ctx, id.Int64())
+}
+
+func (s *chainScore) Ex_getFundLedger(
+	fund string, fromTerm, toTerm *common.HexInt) (map[string]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return nil, err
+	}
+	return es.GetFundLedger(ctx, fund, fromTerm.Int64(), toTerm.Int64())
+}
+
+func (s *chainScore) Ex_setPlanetNodeKey(id *common.HexInt, nodeKey []byte) error {
+	if err := s.tryChargeCall(); err != nil {
+		return err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	// PlanetOwner is checked in ExtensionStateImpl.SetPlanetNodeKey()
+	return es.SetPlanetNodeKey(ctx, id.Int64(), nodeKey)
+}
+
+func (s *chainScore) Ex_getPlanetNodeKey(id *common.HexInt) (map[string]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return nil, err
+	}
+	return es.GetPlanetNodeKey(ctx, id.Int64())
+}
+
+func (s *chainScore) Ex_reportAttestedPlanetWork(id *common.HexInt, signature []byte) error {
+	if err := s.tryChargeCall(); err != nil {
+		return err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	ok, err := es.IsPlanetManager(s.from)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return scoreresult.AccessDeniedError.Errorf("NoPermission: %s", s.from)
+	}
+	return es.ReportAttestedPlanetWork(ctx, id.Int64(), signature)
+}
+
+func (s *chainScore) Ex_setPlanetClaimDelegate(id *common.HexInt, delegate module.Address) error {
+	if err := s.tryChargeCall(); err != nil {
+		return err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	// PlanetOwner is checked in ExtensionStateImpl.SetPlanetClaimDelegate()
+	return es.SetPlanetClaimDelegate(ctx, id.Int64(), delegate)
+}
+
+func (s *chainScore) Ex_setPlanetRewardBeneficiary(id *common.HexInt, beneficiary module.Address) error {
+	if err := s.tryChargeCall(); err != nil {
+		return err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return err
+	}
+	// PlanetOwner is checked in ExtensionStateImpl.SetPlanetRewardBeneficiary()
+	return es.SetPlanetRewardBeneficiary(ctx, id.Int64(), beneficiary)
+}
+
+func (s *chainScore) Ex_getPlanetClaimConfig(id *common.HexInt) (map[string]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return nil, err
+	}
+	return es.GetPlanetClaimConfig(ctx, id.Int64())
+}
+
 func (s *chainScore) Ex_getRewardInfoOf(id *common.HexInt) (map[string]interface{}, error) {
 	if err := s.tryChargeCall(); err != nil {
 		return nil, err
@@ -291,6 +986,18 @@ func (s *chainScore) Ex_getRewardInfosOf(ids []interface{}) (map[string]interfac
 	return es.GetRewardInfosOf(ctx, planetIds)
 }
 
+func (s *chainScore) Ex_getRewardHistoryOf(
+	id, fromTerm, toTerm *common.HexInt) (map[string]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	es, ctx, err := s.getExtensionStateAndContext()
+	if err != nil {
+		return nil, err
+	}
+	return es.GetRewardHistoryOf(ctx, id.Int64(), fromTerm.Int64(), toTerm.Int64())
+}
+
 func (s *chainScore) Ex_getRewardInfo() (map[string]interface{}, error) {
 	if err := s.tryChargeCall(); err != nil {
 		return nil, err
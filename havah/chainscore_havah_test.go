@@ -0,0 +1,108 @@
+package havah
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/havah/hvh"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/contract"
+	"github.com/icon-project/goloop/service/eeproxy"
+	"github.com/icon-project/goloop/service/state"
+)
+
+// testChain provides the chain properties which inter-call in a test needs
+type testChain struct {
+	dryRunChain
+}
+
+func (c *testChain) TransactionTimeout() time.Duration {
+	return 5 * time.Second
+}
+
+func newTestChainScore(t *testing.T, cc contract.CallContext, from module.Address) *chainScore {
+	score, err := newChainScore(cc, from, hvhmodule.BigIntZero)
+	assert.NoError(t, err)
+	return score.(*chainScore)
+}
+
+func TestChainScore_Multisig(t *testing.T) {
+	dbase := db.NewMapDB()
+	plt, err := NewPlatform("", 0)
+	assert.NoError(t, err)
+	ess := hvh.NewExtensionSnapshot(dbase, nil)
+	ws := state.NewWorldState(dbase, nil, nil, ess, nil)
+	wc := state.NewWorldContext(ws, common.NewBlockInfo(100, 1_000_000), nil, plt)
+	logger := log.New()
+	cm, err := plt.NewContractManager(dbase, t.TempDir(), logger)
+	assert.NoError(t, err)
+	ctx := contract.NewContext(wc, cm, nil, &testChain{}, logger, nil, eeproxy.ForTransaction)
+	cc := contract.NewCallContext(ctx, big.NewInt(math.MaxInt64), false)
+
+	err = contract.DeployAndInstallSystemSCORE(
+		cc, contract.CID_CHAIN, nil, state.SystemAddress, []byte(`{"revision":"0x8","havah":{"termPeriod":"0x10","usdtPrice":"0x1"}}`), nil)
+	assert.NoError(t, err)
+
+	signers := []interface{}{
+		common.MustNewAddressFromString("hx1"),
+		common.MustNewAddressFromString("hx2"),
+		common.MustNewAddressFromString("hx3"),
+	}
+	s := newTestChainScore(t, cc, signers[0].(module.Address))
+	multisig, err := s.Ex_createMultisig(signers, common.NewHexInt(2))
+	assert.NoError(t, err)
+
+	// An operation which is pending while the threshold is lowered
+	pendingID, err := s.Ex_submitMultisigOperation(
+		multisig, state.SystemAddress, "setMultisigSigners",
+		`{"signers":["hx0000000000000000000000000000000000000001","hx0000000000000000000000000000000000000002"],"threshold":"0x2"}`)
+	assert.NoError(t, err)
+	err = s.Ex_executeMultisigOperation(common.NewHexInt(pendingID))
+	assert.Error(t, err)
+
+	// The multisig account changes its own signers through an operation
+	id, err := s.Ex_submitMultisigOperation(
+		multisig, state.SystemAddress, "setMultisigSigners",
+		`{"signers":["hx0000000000000000000000000000000000000001","hx0000000000000000000000000000000000000002"],"threshold":"0x1"}`)
+	assert.NoError(t, err)
+	err = newTestChainScore(t, cc, signers[1].(module.Address)).Ex_approveMultisigOperation(common.NewHexInt(id))
+	assert.NoError(t, err)
+
+	jso, err := s.Ex_getMultisigOperation(common.NewHexInt(id))
+	assert.NoError(t, err)
+	assert.Equal(t, "executed", jso["status"])
+	jso, err = s.Ex_getMultisig(multisig)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), jso["threshold"])
+
+	// A failed call closes the operation without reverting the approval
+	id, err = s.Ex_submitMultisigOperation(multisig, state.SystemAddress, "setRevision", `{"code":"0x9"}`)
+	assert.NoError(t, err)
+	jso, err = s.Ex_getMultisigOperation(common.NewHexInt(id))
+	assert.NoError(t, err)
+	assert.Equal(t, "failed", jso["status"])
+
+	// The pending operation has enough approvals with the lowered threshold
+	err = s.Ex_approveMultisigOperation(common.NewHexInt(pendingID))
+	assert.Error(t, err)
+	err = s.Ex_executeMultisigOperation(common.NewHexInt(pendingID))
+	assert.NoError(t, err)
+	jso, err = s.Ex_getMultisigOperation(common.NewHexInt(pendingID))
+	assert.NoError(t, err)
+	assert.Equal(t, "executed", jso["status"])
+	jso, err = s.Ex_getMultisig(multisig)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), jso["threshold"])
+
+	// Signers cannot call setMultisigSigners directly
+	err = s.Ex_setMultisigSigners(signers[:1], common.NewHexInt(1))
+	assert.Error(t, err)
+}
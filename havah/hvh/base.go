@@ -309,6 +309,14 @@ func (es *ExtensionStateImpl) OnBaseTx(cc hvhmodule.CallContext, data []byte) er
 		"height=%d istart=%d tperiod=%d blockIndex=%d tseq=%d issue=%v",
 		height, issueStart, termPeriod, blockIndexInTerm, termSeq, baseData.IssueAmount.Value())
 
+	// Apply scheduled parameter changes before handling a new term
+	// so that the changes for a term are applied at its first block
+	if cc.Revision().Value() >= hvhmodule.RevisionParameterChange {
+		if err = es.applyPendingChanges(cc); err != nil {
+			return err
+		}
+	}
+
 	termStart := blockIndexInTerm == 0
 	ns, err := es.state.GetNetworkStatus()
 	if err != nil {
@@ -318,9 +326,16 @@ func (es *ExtensionStateImpl) OnBaseTx(cc hvhmodule.CallContext, data []byte) er
 	if termStart {
 		// The code below should be executed only at the first block of each term
 		issueLimit := es.state.GetIssueLimit()
-		if termSeq > 0 && (issueLimit == 0 || termSeq <= issueLimit) {
-			if err = es.onTermEnd(cc); err != nil {
-				return err
+		if termSeq > 0 {
+			if issueLimit == 0 || termSeq <= issueLimit {
+				if err = es.onTermEnd(cc, termSeq-1); err != nil {
+					return err
+				}
+			} else if cc.Revision().Value() >= hvhmodule.RevisionFundLedger {
+				// No term is settled after the issue limit, but the funds still keep a ledger for every term
+				if err = es.closeFundLedgers(cc, termSeq-1); err != nil {
+					return err
+				}
 			}
 		}
 		if issueLimit == 0 || termSeq < issueLimit {
@@ -356,6 +371,11 @@ func (es *ExtensionStateImpl) OnBaseTx(cc hvhmodule.CallContext, data []byte) er
 
 	// Check BlockVote for NonVotePenalty every blockVoteCheckPeriod
 	if ns.IsDecentralized() {
+		if cc.Revision().Value() >= hvhmodule.RevisionValidatorJail {
+			if err = es.releaseJailedValidators(cc); err != nil {
+				return err
+			}
+		}
 		if hvhstate.IsItTimeToCheckBlockVote(blockIndexInTerm, ns.BlockVoteCheckPeriod()) {
 			if err = es.handleBlockVote(cc); err != nil {
 				return err
@@ -363,13 +383,21 @@ func (es *ExtensionStateImpl) OnBaseTx(cc hvhmodule.CallContext, data []byte) er
 		}
 	}
 
+	// Apply approved proposals and close expired ones
+	if cc.Revision().Value() >= hvhmodule.RevisionGovernanceProposal {
+		if err = es.handleProposals(cc); err != nil {
+			return err
+		}
+	}
+
 	es.Logger().Debugf("OnBaseTx() end: height=%d termStart=%t termSeq=%d", height, termStart, termSeq)
 	return nil
 }
 
-func (es *ExtensionStateImpl) onTermEnd(cc hvhmodule.CallContext) error {
+// onTermEnd settles the rewards and fees of the term which is ending; termSeq is its sequence
+func (es *ExtensionStateImpl) onTermEnd(cc hvhmodule.CallContext, termSeq int64) error {
 	height := cc.BlockHeight()
-	es.Logger().Debugf("onTermEnd() start: height=%d", height)
+	es.Logger().Debugf("onTermEnd() start: height=%d termSeq=%d", height, termSeq)
 
 	var err error
 	if err = es.TransferEcoSystemReward(cc); err != nil {
@@ -384,12 +412,23 @@ func (es *ExtensionStateImpl) onTermEnd(cc hvhmodule.CallContext) error {
 		return err
 	}
 
+	if cc.Revision().Value() >= hvhmodule.RevisionValidatorReward {
+		if err = es.distributeValidatorReward(cc); err != nil {
+			return err
+		}
+	}
+
 	if err = es.refillHooverFund(cc); err != nil {
 		return err
 	}
 	if err = es.state.OnTermEnd(); err != nil {
 		return err
 	}
+	if cc.Revision().Value() >= hvhmodule.RevisionFundLedger {
+		if err = es.closeFundLedgers(cc, termSeq); err != nil {
+			return err
+		}
+	}
 
 	es.Logger().Debugf("onTermEnd() end: height=%d", height)
 	return nil
@@ -432,6 +471,9 @@ func (es *ExtensionStateImpl) TransferMissedReward(cc hvhmodule.CallContext) err
 	if err = increaseVarDBInSustainableFund(cc, hvhmodule.VarMissingReward, missed); err != nil {
 		return err
 	}
+	if err = es.addFundFlow(cc, hvhstate.FundFlowMissedReward, missed); err != nil {
+		return err
+	}
 
 	es.Logger().Debugf(
 		"TransferMissedReward() end: missed=%d publicTreasury=%d",
@@ -476,6 +518,9 @@ func (es *ExtensionStateImpl) refillHooverFund(cc hvhmodule.CallContext) error {
 		if err := increaseVarDBInSustainableFund(cc, hvhmodule.VarHooverRefill, amount); err != nil {
 			return err
 		}
+		if err := es.addFundFlow(cc, hvhstate.FundFlowHooverRefill, amount); err != nil {
+			return err
+		}
 
 		hfBalance = cc.GetBalance(hf)
 		sfBalance = cc.GetBalance(sf)
@@ -512,10 +557,35 @@ func (es *ExtensionStateImpl) onTermStart(cc hvhmodule.CallContext, termSeq int6
 		return err
 	}
 
+	if cc.Revision().Value() >= hvhmodule.RevisionPriceOracle {
+		if err = es.updateUSDTPriceByReports(cc, termSeq); err != nil {
+			return err
+		}
+	}
+
+	// Validators share a part of issueAmount with planets after decentralization
+	planetIssueAmount := issueAmount
+	if cc.Revision().Value() >= hvhmodule.RevisionValidatorReward {
+		share, err := es.setValidatorRewardPool(issueAmount)
+		if err != nil {
+			return err
+		}
+		planetIssueAmount = new(big.Int).Sub(issueAmount, share)
+	}
+
 	// Reset reward-related states
-	if err = es.state.OnTermStart(issueAmount); err != nil {
+	if err = es.state.OnTermStart(planetIssueAmount); err != nil {
 		return err
 	}
+	if cc.Revision().Value() >= hvhmodule.RevisionPlanetWeight {
+		// Weight changes reserved during the previous term are applied before sharing rewards
+		if err = es.state.ApplyPendingPlanetWeights(); err != nil {
+			return err
+		}
+		if err = es.state.SetActivePlanetWeight(); err != nil {
+			return err
+		}
+	}
 
 	// Log TermStarted(int,int,int) event
 	rev := cc.Revision().Value()
@@ -528,6 +598,54 @@ func (es *ExtensionStateImpl) onTermStart(cc hvhmodule.CallContext, termSeq int6
 	return nil
 }
 
+// setValidatorRewardPool returns the part of issueAmount which validators share in a new term
+func (es *ExtensionStateImpl) setValidatorRewardPool(issueAmount *big.Int) (*big.Int, error) {
+	ns, err := es.state.GetNetworkStatus()
+	if err != nil {
+		return nil, err
+	}
+	share := hvhmodule.BigIntZero
+	if ns.IsDecentralized() {
+		share = es.state.CalcValidatorRewardShare(issueAmount)
+	}
+	if share.Sign() > 0 || es.state.GetValidatorRewardPool().Sign() > 0 {
+		if err = es.state.SetValidatorRewardPool(share); err != nil {
+			return nil, err
+		}
+	}
+	es.Logger().Debugf("setValidatorRewardPool(): issue=%d share=%d", issueAmount, share)
+	return share, nil
+}
+
+// distributeValidatorReward gives validators the reward pool of the term in proportion to their block votes.
+// The remainder of the pool goes to SustainableFund like missed planet rewards
+func (es *ExtensionStateImpl) distributeValidatorReward(cc hvhmodule.CallContext) error {
+	es.Logger().Debugf("distributeValidatorReward() start")
+
+	pool := es.state.GetValidatorRewardPool()
+	count, remain, err := es.state.DistributeValidatorReward()
+	if err != nil {
+		return err
+	}
+	if remain.Sign() > 0 {
+		if err = cc.Transfer(hvhmodule.PublicTreasury, hvhmodule.SustainableFund, remain, module.Transfer); err != nil {
+			return err
+		}
+		if err = increaseVarDBInSustainableFund(cc, hvhmodule.VarMissingReward, remain); err != nil {
+			return err
+		}
+		if err = es.addFundFlow(cc, hvhstate.FundFlowMissedReward, remain); err != nil {
+			return err
+		}
+	}
+	if pool.Sign() > 0 {
+		onValidatorRewardDistributedEvent(cc, pool, count, remain)
+	}
+
+	es.Logger().Debugf("distributeValidatorReward() end: pool=%d count=%d remain=%d", pool, count, remain)
+	return nil
+}
+
 func (es *ExtensionStateImpl) issueCoin(cc hvhmodule.CallContext, termSeq int64, amount *big.Int) error {
 	es.Logger().Debugf("issueCoin() start: termSeq=%d amount=%d", termSeq, amount)
 
@@ -552,6 +670,9 @@ func (es *ExtensionStateImpl) distributeServiceFee(cc hvhmodule.CallContext, pro
 	if err = increaseVarDBInSustainableFund(cc, hvhmodule.VarServiceFee, sfAmount); err != nil {
 		return err
 	}
+	if err = es.addFundFlow(cc, hvhstate.FundFlowServiceFee, sfAmount); err != nil {
+		return err
+	}
 
 	es.Logger().Debugf("distributeServiceFee() end: from=%s sfAmount=%d", from, sfAmount)
 	return nil
@@ -568,6 +689,9 @@ func (es *ExtensionStateImpl) distributeTxFee(cc hvhmodule.CallContext, proporti
 	if err = increaseVarDBInSustainableFund(cc, hvhmodule.VarTxFee, sfAmount); err != nil {
 		return err
 	}
+	if err = es.addFundFlow(cc, hvhstate.FundFlowTxFee, sfAmount); err != nil {
+		return err
+	}
 
 	es.Logger().Debugf("distributeTxFee() end: from=%s sfAmount=%d", from, sfAmount)
 	return nil
@@ -600,6 +724,24 @@ func (es *ExtensionStateImpl) distributeFee(
 	return ecoAmount, susAmount, nil
 }
 
+// addFundFlow records a transfer from or to HooverFund and SustainableFund in their ledgers
+func (es *ExtensionStateImpl) addFundFlow(cc hvhmodule.CallContext, flow hvhstate.FundFlow, amount *big.Int) error {
+	if cc.Revision().Value() < hvhmodule.RevisionFundLedger {
+		return nil
+	}
+	return es.state.AddFundFlow(flow, amount)
+}
+
+// closeFundLedgers moves the current ledgers of HooverFund and SustainableFund into their history
+func (es *ExtensionStateImpl) closeFundLedgers(cc hvhmodule.CallContext, termSeq int64) error {
+	for _, fund := range []hvhstate.Fund{hvhstate.FundHoover, hvhstate.FundSustainable} {
+		if err := es.state.CloseFundLedger(fund, termSeq, cc.GetBalance(fund.Address())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func increaseVarDBInSustainableFund(cc hvhmodule.CallContext, key string, amount *big.Int) error {
 	return increaseScoreVarDB(cc, hvhmodule.SustainableFund, key, amount)
 }
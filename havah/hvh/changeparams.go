@@ -0,0 +1,253 @@
+package hvh
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/havah/hvh/hvhstate"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/service/scoredb"
+	"github.com/icon-project/goloop/service/scoreresult"
+	"github.com/icon-project/goloop/service/state"
+)
+
+// Parameter types which can be changed by proposals or scheduled changes
+const (
+	ParamTypeStepPrice                = "stepPrice"
+	ParamTypeActiveValidatorCount     = "activeValidatorCount"
+	ParamTypeBlockVoteCheckParameters = "blockVoteCheckParameters"
+	ParamTypePrivateClaimableRate     = "privateClaimableRate"
+	ParamTypeValidatorRewardRate      = "validatorRewardRate"
+	ParamTypeJailParameters           = "jailParameters"
+	ParamTypePriceReportParameters    = "priceReportParameters"
+	ParamTypeProposalDuration         = "proposalDuration"
+	ParamTypeUSDTPrice                = "usdtPrice"
+	ParamTypePlanetClassWeight        = "planetClassWeight"
+)
+
+type changeParams interface {
+	validate() error
+	apply(es *ExtensionStateImpl, cc hvhmodule.CallContext) error
+}
+
+type stepPriceParams struct {
+	Price common.HexInt `json:"price"`
+}
+
+func (p *stepPriceParams) validate() error {
+	if p.Price.Sign() < 0 {
+		return scoreresult.InvalidParameterError.Errorf("InvalidArgument(price=%s)", &p.Price)
+	}
+	return nil
+}
+
+func (p *stepPriceParams) apply(_ *ExtensionStateImpl, cc hvhmodule.CallContext) error {
+	as := cc.GetAccountState(state.SystemID)
+	return scoredb.NewVarDB(as, state.VarStepPrice).Set(&p.Price)
+}
+
+type usdtPriceParams struct {
+	Price common.HexInt `json:"price"`
+}
+
+func (p *usdtPriceParams) validate() error {
+	if p.Price.Sign() <= 0 {
+		return scoreresult.InvalidParameterError.Errorf("InvalidArgument(price=%s)", &p.Price)
+	}
+	return nil
+}
+
+func (p *usdtPriceParams) apply(es *ExtensionStateImpl, _ hvhmodule.CallContext) error {
+	return es.SetUSDTPrice(p.Price.Value())
+}
+
+type planetClassWeightParams struct {
+	Class  string          `json:"class"`
+	Weight common.HexInt64 `json:"weight"`
+}
+
+func (p *planetClassWeightParams) validate() error {
+	if !hvhstate.IsValidPlanetClass(p.Class) {
+		return scoreresult.InvalidParameterError.Errorf("InvalidPlanetClass(%s)", p.Class)
+	}
+	if weight := p.Weight.Value; weight < 0 || weight > hvhmodule.MaxPlanetWeight {
+		return scoreresult.InvalidParameterError.Errorf("InvalidArgument(weight=%d)", weight)
+	}
+	return nil
+}
+
+func (p *planetClassWeightParams) apply(es *ExtensionStateImpl, _ hvhmodule.CallContext) error {
+	return es.SetPlanetClassWeight(p.Class, p.Weight.Value)
+}
+
+type activeValidatorCountParams struct {
+	Count common.HexInt64 `json:"count"`
+}
+
+func (p *activeValidatorCountParams) validate() error {
+	if count := p.Count.Value; !(count > 0 && count <= hvhmodule.MaxValidatorCount) {
+		return scoreresult.InvalidParameterError.Errorf("InvalidArgument(count=%d)", count)
+	}
+	return nil
+}
+
+func (p *activeValidatorCountParams) apply(es *ExtensionStateImpl, cc hvhmodule.CallContext) error {
+	return es.SetActiveValidatorCount(cc, p.Count.Value)
+}
+
+type blockVoteCheckParams struct {
+	Period    common.HexInt64 `json:"period"`
+	Allowance common.HexInt64 `json:"allowance"`
+}
+
+func (p *blockVoteCheckParams) validate() error {
+	if p.Period.Value < 0 || p.Allowance.Value < 0 {
+		return scoreresult.InvalidParameterError.Errorf(
+			"InvalidArgument(period=%d,allowance=%d)", p.Period.Value, p.Allowance.Value)
+	}
+	return nil
+}
+
+func (p *blockVoteCheckParams) apply(es *ExtensionStateImpl, cc hvhmodule.CallContext) error {
+	return es.SetBlockVoteCheckParameters(cc, p.Period.Value, p.Allowance.Value)
+}
+
+type privateClaimableRateParams struct {
+	Numerator   common.HexInt64 `json:"numerator"`
+	Denominator common.HexInt64 `json:"denominator"`
+}
+
+func (p *privateClaimableRateParams) validate() error {
+	if !hvhstate.ValidatePrivateClaimableRate(p.Numerator.Value, p.Denominator.Value) {
+		return scoreresult.InvalidParameterError.Errorf(
+			"InvalidPrivateClaimableRate: num=%d denom=%d", p.Numerator.Value, p.Denominator.Value)
+	}
+	return nil
+}
+
+func (p *privateClaimableRateParams) apply(es *ExtensionStateImpl, _ hvhmodule.CallContext) error {
+	return es.SetPrivateClaimableRate(p.Numerator.Value, p.Denominator.Value)
+}
+
+type validatorRewardRateParams struct {
+	Rate common.HexInt64 `json:"rate"`
+}
+
+func (p *validatorRewardRateParams) validate() error {
+	if rate := p.Rate.Value; rate < 0 || rate > hvhmodule.ValidatorRewardRateDenom {
+		return scoreresult.InvalidParameterError.Errorf("InvalidArgument(rate=%d)", rate)
+	}
+	return nil
+}
+
+func (p *validatorRewardRateParams) apply(es *ExtensionStateImpl, cc hvhmodule.CallContext) error {
+	return es.SetValidatorRewardRate(cc, p.Rate.Value)
+}
+
+type jailParams struct {
+	Cooldown common.HexInt64 `json:"cooldown"`
+}
+
+func (p *jailParams) validate() error {
+	if p.Cooldown.Value < 1 {
+		return scoreresult.InvalidParameterError.Errorf("InvalidArgument(cooldown=%d)", p.Cooldown.Value)
+	}
+	return nil
+}
+
+func (p *jailParams) apply(es *ExtensionStateImpl, cc hvhmodule.CallContext) error {
+	return es.SetJailParameters(cc, p.Cooldown.Value)
+}
+
+type priceReportParams struct {
+	TTL        common.HexInt64 `json:"ttl"`
+	MinReports common.HexInt64 `json:"minReports"`
+}
+
+func (p *priceReportParams) validate() error {
+	if p.TTL.Value < 1 {
+		return scoreresult.InvalidParameterError.Errorf("InvalidArgument(ttl=%d)", p.TTL.Value)
+	}
+	if minReports := p.MinReports.Value; minReports < 1 || minReports > hvhmodule.MaxPriceReporterCount {
+		return scoreresult.InvalidParameterError.Errorf("InvalidArgument(minReports=%d)", minReports)
+	}
+	return nil
+}
+
+func (p *priceReportParams) apply(es *ExtensionStateImpl, cc hvhmodule.CallContext) error {
+	return es.SetPriceReportParameters(cc, p.TTL.Value, p.MinReports.Value)
+}
+
+type proposalDurationParams struct {
+	Duration common.HexInt64 `json:"duration"`
+}
+
+func (p *proposalDurationParams) validate() error {
+	if p.Duration.Value < 1 {
+		return scoreresult.InvalidParameterError.Errorf("InvalidArgument(duration=%d)", p.Duration.Value)
+	}
+	return nil
+}
+
+func (p *proposalDurationParams) apply(es *ExtensionStateImpl, _ hvhmodule.CallContext) error {
+	return es.state.SetProposalDuration(p.Duration.Value)
+}
+
+// newChangeParams parses the parameters of a proposal in JSON and validates them
+func newChangeParams(pType string, value []byte) (changeParams, error) {
+	var params changeParams
+	switch pType {
+	case ParamTypeStepPrice:
+		params = new(stepPriceParams)
+	case ParamTypeActiveValidatorCount:
+		params = new(activeValidatorCountParams)
+	case ParamTypeBlockVoteCheckParameters:
+		params = new(blockVoteCheckParams)
+	case ParamTypePrivateClaimableRate:
+		params = new(privateClaimableRateParams)
+	case ParamTypeValidatorRewardRate:
+		params = new(validatorRewardRateParams)
+	case ParamTypeJailParameters:
+		params = new(jailParams)
+	case ParamTypePriceReportParameters:
+		params = new(priceReportParams)
+	case ParamTypeProposalDuration:
+		params = new(proposalDurationParams)
+	case ParamTypeUSDTPrice:
+		params = new(usdtPriceParams)
+	case ParamTypePlanetClassWeight:
+		params = new(planetClassWeightParams)
+	default:
+		return nil, scoreresult.InvalidParameterError.Errorf("UnknownParamType(%s)", pType)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(params); err != nil {
+		return nil, scoreresult.InvalidParameterError.Wrapf(err, "InvalidProposalValue(%s)", value)
+	}
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// applyChangeParams applies params on the snapshots of the extension state and the system account.
+// Both are restored if params fail to be applied, so that partial writes are not left behind
+func (es *ExtensionStateImpl) applyChangeParams(cc hvhmodule.CallContext, params changeParams) error {
+	ess := es.state.GetSnapshot()
+	as := cc.GetAccountState(state.SystemID)
+	ass := as.GetSnapshot()
+
+	err := params.apply(es, cc)
+	if err != nil {
+		if rerr := es.state.Reset(ess); rerr != nil {
+			return rerr
+		}
+		if rerr := as.Reset(ass); rerr != nil {
+			return rerr
+		}
+	}
+	return err
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/icon-project/goloop/havah/hvh/hvhstate"
 	"github.com/icon-project/goloop/havah/hvhmodule"
 	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoreresult"
 	"github.com/icon-project/goloop/service/state"
 )
 
@@ -93,6 +94,7 @@ func (es *ExtensionStateImpl) initActiveValidatorSet(cc hvhmodule.CallContext) e
 type penalizedInfo struct {
 	owner module.Address
 	validator module.Validator
+	reason string
 }
 
 func (es *ExtensionStateImpl) handleBlockVote(cc hvhmodule.CallContext) error {
@@ -117,8 +119,16 @@ func (es *ExtensionStateImpl) handleBlockVote(cc hvhmodule.CallContext) error {
 		if penalized, owner, err := es.state.OnBlockVote(node, vote); err == nil {
 			if penalized {
 				penalizedInfos = append(
-					penalizedInfos, &penalizedInfo{owner: owner, validator: voter})
+					penalizedInfos, &penalizedInfo{owner: owner, validator: voter, reason: "penalized"})
 				onActiveValidatorPenalized(cc, owner, node)
+				if cc.Revision().Value() >= hvhmodule.RevisionValidatorJail {
+					// Penalized validators are released automatically after jailCooldown
+					until := cc.BlockHeight() + es.state.GetJailCooldown()
+					if err = es.state.JailValidator(owner, until); err != nil {
+						return err
+					}
+					onValidatorJailedEvent(cc, owner, node, until)
+				}
 			}
 		}
 	}
@@ -131,6 +141,84 @@ func (es *ExtensionStateImpl) handleBlockVote(cc hvhmodule.CallContext) error {
 	return es.replacePenalizedActiveValidators(cc, penalizedInfos)
 }
 
+// OnExecutionBegin counts the block votes and the proposer of the previous block in ConsensusInfo
+// at every block. Unlike the penalty check in handleBlockVote, it doesn't wait for blockVoteCheckPeriod
+func (es *ExtensionStateImpl) OnExecutionBegin(wc state.WorldContext) error {
+	rev := wc.Revision().Value()
+	if rev < hvhmodule.RevisionValidatorReward && rev < hvhmodule.RevisionValidatorUptime {
+		return nil
+	}
+	ci := wc.ConsensusInfo()
+	if ci == nil || ci.Voters() == nil {
+		return nil
+	}
+	ns, err := es.state.GetNetworkStatus()
+	if err != nil {
+		return err
+	}
+	if !ns.IsDecentralized() {
+		return nil
+	}
+	// ConsensusInfo is for the previous block
+	return es.recordBlockVotes(rev, wc.BlockHeight()-1, ci)
+}
+
+func (es *ExtensionStateImpl) recordBlockVotes(rev int, height int64, ci module.ConsensusInfo) error {
+	recordUptime := rev >= hvhmodule.RevisionValidatorUptime
+	if recordUptime {
+		if err := es.recordProposal(ci.Proposer(), height); err != nil {
+			return err
+		}
+	}
+
+	voters := ci.Voters()
+	for i, vote := range ci.Voted() {
+		voter, _ := voters.Get(i)
+		owner, err := es.state.GetOwnerByNode(voter.Address())
+		if err != nil {
+			// The voter is not a registered validator
+			continue
+		}
+		if recordUptime {
+			if err = es.state.RecordBlockVote(owner, vote, height); err != nil {
+				return err
+			}
+		}
+		if vote && rev >= hvhmodule.RevisionValidatorReward {
+			// Block votes are counted to distribute validator rewards at the end of a term
+			if err = es.state.IncreaseValidatorVotes(owner); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// releaseJailedValidators makes the validators whose jail terms have expired available again.
+// They are added to active validator set when other validators are penalized or a new term starts
+func (es *ExtensionStateImpl) releaseJailedValidators(cc hvhmodule.CallContext) error {
+	owners, err := es.state.ReleaseJailedValidators(cc.BlockHeight())
+	if err != nil {
+		return err
+	}
+	for _, owner := range owners {
+		onValidatorUnjailedEvent(cc, owner)
+	}
+	return nil
+}
+
+func (es *ExtensionStateImpl) recordProposal(proposer module.Address, height int64) error {
+	if proposer == nil {
+		return nil
+	}
+	owner, err := es.state.GetOwnerByNode(proposer)
+	if err != nil {
+		// The proposer is not a registered validator
+		return nil
+	}
+	return es.state.RecordProposal(owner, height)
+}
+
 func (es *ExtensionStateImpl) IsItTimeToCheckBlockVote(blockIndexInTerm int64) bool {
 	return es.state.IsItTimeToCheckBlockVote(blockIndexInTerm)
 }
@@ -166,7 +254,7 @@ func (es *ExtensionStateImpl) replacePenalizedActiveValidators(
 	var validator module.Validator
 	for idx := range m {
 		info, _ := m[idx]
-		onActiveValidatorRemoved(cc, info.owner, info.validator.Address(), "penalized")
+		onActiveValidatorRemoved(cc, info.owner, info.validator.Address(), info.reason)
 
 		if i < len(validatorsToAdd) {
 			validator, err = state.ValidatorFromAddress(validatorsToAdd[i])
@@ -193,3 +281,108 @@ func (es *ExtensionStateImpl) replacePenalizedActiveValidators(
 	es.logger.Debugf("replaceActiveValidators() end: validatorsToAdd=%v", validatorsToAdd)
 	return nil
 }
+
+// HandleDoubleSignReport slashes the validator which signed two conflicting blocks or votes
+// and removes it from active validator set
+func (es *ExtensionStateImpl) HandleDoubleSignReport(
+	cc hvhmodule.CallContext, dsType string, dsHeight int64, signer module.Address) error {
+	height := cc.BlockHeight()
+	es.logger.Debugf(
+		"HandleDoubleSignReport() start: height=%d type=%s dsHeight=%d signer=%s",
+		height, dsType, dsHeight, signer)
+
+	owner, slashed, err := es.state.OnDoubleSignReport(dsType, dsHeight, signer, height)
+	if err != nil {
+		return err
+	}
+	if !slashed {
+		es.logger.Debugf("HandleDoubleSignReport() end: owner=%s already slashed", owner)
+		return nil
+	}
+	onValidatorSlashedEvent(cc, owner, signer, dsType, dsHeight)
+
+	ns, err := es.state.GetNetworkStatus()
+	if err != nil {
+		return err
+	}
+	if ns.IsDecentralized() {
+		validator, err := state.ValidatorFromAddress(signer)
+		if err != nil {
+			return err
+		}
+		infos := []*penalizedInfo{{owner: owner, validator: validator, reason: "slashed"}}
+		if err = es.replacePenalizedActiveValidators(cc, infos); err != nil {
+			return err
+		}
+	}
+
+	es.logger.Debugf("HandleDoubleSignReport() end: owner=%s", owner)
+	return nil
+}
+
+func (es *ExtensionStateImpl) GetDoubleSignEvidence(
+	cc hvhmodule.CallContext, owner module.Address) (map[string]interface{}, error) {
+	if owner == nil {
+		return nil, scoreresult.InvalidParameterError.New("Invalid owner")
+	}
+	dse, err := es.state.GetDoubleSignEvidence(owner)
+	if err != nil {
+		return nil, err
+	}
+	jso := map[string]interface{}{
+		"height": cc.BlockHeight(),
+		"owner":  owner,
+	}
+	if dse != nil {
+		jso["evidence"] = dse.ToJSON()
+	}
+	return jso, nil
+}
+
+// GetValidatorUptime returns the uptime counters of a validator.
+// They are cumulative counters of every block since RevisionValidatorUptime, not the ones of a rolling window
+func (es *ExtensionStateImpl) GetValidatorUptime(
+	cc hvhmodule.CallContext, owner module.Address) (map[string]interface{}, error) {
+	if owner == nil {
+		return nil, scoreresult.InvalidParameterError.New("Invalid owner")
+	}
+	if _, err := es.state.GetValidatorInfo(owner); err != nil {
+		return nil, err
+	}
+	vu, err := es.state.GetValidatorUptime(owner)
+	if err != nil {
+		return nil, err
+	}
+	jso := vu.ToJSON()
+	jso["height"] = cc.BlockHeight()
+	jso["owner"] = owner
+	return jso, nil
+}
+
+func (es *ExtensionStateImpl) GetValidatorUptimesOf(
+	cc hvhmodule.CallContext, gradeFilterName string) (map[string]interface{}, error) {
+	gradeFilter := hvhstate.StringToGradeFilter(gradeFilterName)
+	if gradeFilter == hvhstate.GradeFilterNone {
+		return nil, scoreresult.InvalidParameterError.Errorf("InvalidArgument(%s)", gradeFilterName)
+	}
+	owners, err := es.state.GetValidatorsOf(gradeFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	validators := make([]interface{}, len(owners))
+	for i, owner := range owners {
+		vu, err := es.state.GetValidatorUptime(owner)
+		if err != nil {
+			return nil, err
+		}
+		jso := vu.ToJSON()
+		jso["owner"] = owner
+		validators[i] = jso
+	}
+	return map[string]interface{}{
+		"height":     cc.BlockHeight(),
+		"grade":      gradeFilterName,
+		"validators": validators,
+	}, nil
+}
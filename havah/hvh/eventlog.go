@@ -31,6 +31,58 @@ const (
 	SigActiveValidatorPenalized = "ActiveValidatorPenalized(Address,Address)"
 	// ActiveValidatorCountChanged(oc int, nc int)
 	SigActiveValidatorCountChanged = "ActiveValidatorCountChanged(int,int)"
+	// PlanetWorkRejected(termSeq int, id int, reason str)
+	SigPlanetWorkRejected = "PlanetWorkRejected(int,int,str)"
+	// ClaimDelegateSet(id int, owner Address, delegate Address)
+	SigClaimDelegateSet = "ClaimDelegateSet(int,Address,Address)"
+	// RewardBeneficiarySet(id int, owner Address, beneficiary Address)
+	SigRewardBeneficiarySet = "RewardBeneficiarySet(int,Address,Address)"
+	// PlanetTransferred(id int, from Address, to Address)
+	SigPlanetTransferred = "PlanetTransferred(int,Address,Address)"
+	// USDTPriceReported(reporter Address, price int)
+	SigUSDTPriceReported = "USDTPriceReported(Address,int)"
+	// USDTPriceUpdated(termSeq int, price int, reportCount int)
+	SigUSDTPriceUpdated = "USDTPriceUpdated(int,int,int)"
+	// ValidatorRewardDistributed(pool int, validatorCount int, remain int)
+	SigValidatorRewardDistributed = "ValidatorRewardDistributed(int,int,int)"
+	// ValidatorRewardClaimed(owner Address, amount int)
+	SigValidatorRewardClaimed = "ValidatorRewardClaimed(Address,int)"
+	// ValidatorSlashed(owner Address, node Address, dsType str, dsHeight int)
+	SigValidatorSlashed = "ValidatorSlashed(Address,Address,str,int)"
+	// ValidatorJailed(owner Address, node Address, jailedUntil int)
+	SigValidatorJailed = "ValidatorJailed(Address,Address,int)"
+	// ValidatorUnjailed(owner Address)
+	SigValidatorUnjailed = "ValidatorUnjailed(Address)"
+	// ProposalSubmitted(id int, proposer Address, type str)
+	SigProposalSubmitted = "ProposalSubmitted(int,Address,str)"
+	// ProposalVoted(id int, voter Address, agree bool)
+	SigProposalVoted = "ProposalVoted(int,Address,bool)"
+	// ProposalStatusChanged(id int, status str)
+	SigProposalStatusChanged = "ProposalStatusChanged(int,str)"
+	// ParameterChangeScheduled(id int, type str, height int)
+	SigParameterChangeScheduled = "ParameterChangeScheduled(int,str,int)"
+	// ParameterChangeCanceled(id int)
+	SigParameterChangeCanceled = "ParameterChangeCanceled(int)"
+	// ParameterChangeApplied(id int, type str, success bool)
+	SigParameterChangeApplied = "ParameterChangeApplied(int,str,bool)"
+	// VestingScheduleAdded(name str, type str)
+	SigVestingScheduleAdded = "VestingScheduleAdded(str,str)"
+	// PlanetVestingChanged(id int, name str)
+	SigPlanetVestingChanged = "PlanetVestingChanged(int,str)"
+	// PlanetRewardsClaimed(owner Address, cursor int, nextCursor int, amount int)
+	SigPlanetRewardsClaimed = "PlanetRewardsClaimed(Address,int,int,int)"
+	// MultisigCreated(address Address, threshold int)
+	SigMultisigCreated = "MultisigCreated(Address,int)"
+	// MultisigSignersChanged(address Address, threshold int)
+	SigMultisigSignersChanged = "MultisigSignersChanged(Address,int)"
+	// MultisigOperationSubmitted(id int, multisig Address, to Address, method str)
+	SigMultisigOperationSubmitted = "MultisigOperationSubmitted(int,Address,Address,str)"
+	// MultisigOperationApproved(id int, signer Address)
+	SigMultisigOperationApproved = "MultisigOperationApproved(int,Address)"
+	// MultisigOperationStatusChanged(id int, status str)
+	SigMultisigOperationStatusChanged = "MultisigOperationStatusChanged(int,str)"
+	// PlanetNodeKeySet(id int, owner Address, nodeKey bytes)
+	SigPlanetNodeKeySet = "PlanetNodeKeySet(int,Address,bytes)"
 )
 
 func onRewardOfferedEvent(
@@ -239,3 +291,447 @@ func onActiveValidatorCountChanged(cc hvhmodule.CallContext, oc, nc int64) {
 		},
 	)
 }
+
+// onPlanetWorkRejectedEvent is called when a planet work report in a batch is skipped
+func onPlanetWorkRejectedEvent(cc hvhmodule.CallContext, termSeq, id int64, reason string) {
+	signature := SigPlanetWorkRejected
+	cc.FrameLogger().Debugf("%s event: height=%d termSeq=%d id=%d reason=%s",
+		signature, cc.BlockHeight(), termSeq, id, reason)
+	cc.OnEvent(
+		state.SystemAddress,
+		[][]byte{
+			[]byte(signature),
+		},
+		[][]byte{
+			intconv.Int64ToBytes(termSeq),
+			intconv.Int64ToBytes(id),
+			[]byte(reason),
+		},
+	)
+}
+
+// onClaimDelegateSetEvent is called when a planet owner designates a claim delegate
+func onClaimDelegateSetEvent(cc hvhmodule.CallContext, id int64, owner, delegate module.Address) {
+	signature := SigClaimDelegateSet
+	cc.FrameLogger().Debugf("%s event: height=%d id=%d owner=%s delegate=%s",
+		signature, cc.BlockHeight(), id, owner, delegate)
+	cc.OnEvent(
+		state.SystemAddress,
+		[][]byte{
+			[]byte(signature),
+			intconv.Int64ToBytes(id),
+		},
+		[][]byte{
+			owner.Bytes(),
+			delegate.Bytes(),
+		},
+	)
+}
+
+// onRewardBeneficiarySetEvent is called when a planet owner designates a reward beneficiary
+func onRewardBeneficiarySetEvent(cc hvhmodule.CallContext, id int64, owner, beneficiary module.Address) {
+	signature := SigRewardBeneficiarySet
+	cc.FrameLogger().Debugf("%s event: height=%d id=%d owner=%s beneficiary=%s",
+		signature, cc.BlockHeight(), id, owner, beneficiary)
+	cc.OnEvent(
+		state.SystemAddress,
+		[][]byte{
+			[]byte(signature),
+			intconv.Int64ToBytes(id),
+		},
+		[][]byte{
+			owner.Bytes(),
+			beneficiary.Bytes(),
+		},
+	)
+}
+
+// onPlanetTransferredEvent is called when the owner of a planet is changed.
+// from is ZeroAddress for a registered planet and to is ZeroAddress for an unregistered planet
+func onPlanetTransferredEvent(cc hvhmodule.CallContext, id int64, from, to module.Address) {
+	signature := SigPlanetTransferred
+	cc.FrameLogger().Debugf("%s event: height=%d id=%d from=%s to=%s",
+		signature, cc.BlockHeight(), id, from, to)
+	cc.OnEvent(
+		state.SystemAddress,
+		[][]byte{
+			[]byte(signature),
+			intconv.Int64ToBytes(id),
+			from.Bytes(),
+			to.Bytes(),
+		},
+		nil,
+	)
+}
+
+func onUSDTPriceReportedEvent(cc hvhmodule.CallContext, reporter module.Address, price *big.Int) {
+	signature := SigUSDTPriceReported
+	cc.FrameLogger().Debugf("%s event: height=%d reporter=%s price=%d",
+		signature, cc.BlockHeight(), reporter, price)
+	cc.OnEvent(
+		state.SystemAddress,
+		[][]byte{
+			[]byte(signature),
+			reporter.Bytes(),
+		},
+		[][]byte{
+			intconv.BigIntToBytes(price),
+		},
+	)
+}
+
+// onUSDTPriceUpdatedEvent is called when the median of price reports is applied at the start of a term
+func onUSDTPriceUpdatedEvent(cc hvhmodule.CallContext, termSeq int64, price *big.Int, count int) {
+	signature := SigUSDTPriceUpdated
+	cc.FrameLogger().Debugf("%s event: height=%d termSeq=%d price=%d count=%d",
+		signature, cc.BlockHeight(), termSeq, price, count)
+	cc.OnEvent(
+		state.SystemAddress,
+		[][]byte{[]byte(signature)},
+		[][]byte{
+			intconv.Int64ToBytes(termSeq),
+			intconv.BigIntToBytes(price),
+			intconv.Int64ToBytes(int64(count)),
+		},
+	)
+}
+
+// onValidatorRewardDistributedEvent is called when the validator reward pool of a term is distributed
+func onValidatorRewardDistributedEvent(cc hvhmodule.CallContext, pool *big.Int, count int, remain *big.Int) {
+	signature := SigValidatorRewardDistributed
+	cc.FrameLogger().Debugf("%s event: height=%d pool=%d count=%d remain=%d",
+		signature, cc.BlockHeight(), pool, count, remain)
+	cc.OnEvent(
+		state.SystemAddress,
+		[][]byte{[]byte(signature)},
+		[][]byte{
+			intconv.BigIntToBytes(pool),
+			intconv.Int64ToBytes(int64(count)),
+			intconv.BigIntToBytes(remain),
+		},
+	)
+}
+
+func onValidatorRewardClaimedEvent(cc hvhmodule.CallContext, owner module.Address, amount *big.Int) {
+	signature := SigValidatorRewardClaimed
+	cc.FrameLogger().Debugf("%s event: height=%d owner=%s amount=%d",
+		signature, cc.BlockHeight(), owner, amount)
+	cc.OnEvent(
+		state.SystemAddress,
+		[][]byte{
+			[]byte(signature),
+			owner.Bytes(),
+		},
+		[][]byte{
+			intconv.BigIntToBytes(amount),
+		},
+	)
+}
+
+// onValidatorSlashedEvent is called when a validator was slashed by a double sign report
+func onValidatorSlashedEvent(
+	cc hvhmodule.CallContext, owner, node module.Address, dsType string, dsHeight int64) {
+	signature := SigValidatorSlashed
+	cc.FrameLogger().Debugf("%s event: height=%d owner=%s node=%s dsType=%s dsHeight=%d",
+		signature, cc.BlockHeight(), owner, node, dsType, dsHeight)
+	cc.OnEvent(
+		state.SystemAddress,
+		[][]byte{
+			[]byte(signature),
+			owner.Bytes(),
+		},
+		[][]byte{
+			node.Bytes(),
+			[]byte(dsType),
+			intconv.Int64ToBytes(dsHeight),
+		},
+	)
+}
+
+// onValidatorJailedEvent is called when a penalized validator was put into jail
+func onValidatorJailedEvent(cc hvhmodule.CallContext, owner, node module.Address, until int64) {
+	signature := SigValidatorJailed
+	cc.FrameLogger().Debugf("%s event: height=%d owner=%s node=%s until=%d",
+		signature, cc.BlockHeight(), owner, node, until)
+	cc.OnEvent(
+		state.SystemAddress,
+		[][]byte{
+			[]byte(signature),
+			owner.Bytes(),
+		},
+		[][]byte{
+			node.Bytes(),
+			intconv.Int64ToBytes(until),
+		},
+	)
+}
+
+// onValidatorUnjailedEvent is called when the jail term of a validator expired
+func onValidatorUnjailedEvent(cc hvhmodule.CallContext, owner module.Address) {
+	signature := SigValidatorUnjailed
+	cc.FrameLogger().Debugf("%s event: height=%d owner=%s", signature, cc.BlockHeight(), owner)
+	cc.OnEvent(
+		state.SystemAddress,
+		[][]byte{
+			[]byte(signature),
+			owner.Bytes(),
+		},
+		nil,
+	)
+}
+
+func onProposalSubmittedEvent(cc hvhmodule.CallContext, id int64, proposer module.Address, pType string) {
+	signature := SigProposalSubmitted
+	cc.FrameLogger().Debugf("%s event: height=%d id=%d proposer=%s type=%s",
+		signature, cc.BlockHeight(), id, proposer, pType)
+	cc.OnEvent(
+		state.SystemAddress,
+		[][]byte{
+			[]byte(signature),
+			intconv.Int64ToBytes(id),
+		},
+		[][]byte{
+			proposer.Bytes(),
+			[]byte(pType),
+		},
+	)
+}
+
+func onProposalVotedEvent(cc hvhmodule.CallContext, id int64, voter module.Address, agree bool) {
+	signature := SigProposalVoted
+	cc.FrameLogger().Debugf("%s event: height=%d id=%d voter=%s agree=%t",
+		signature, cc.BlockHeight(), id, voter, agree)
+	vote := int64(0)
+	if agree {
+		vote = 1
+	}
+	cc.OnEvent(
+		state.SystemAddress,
+		[][]byte{
+			[]byte(signature),
+			intconv.Int64ToBytes(id),
+		},
+		[][]byte{
+			voter.Bytes(),
+			intconv.Int64ToBytes(vote),
+		},
+	)
+}
+
+func onProposalStatusChangedEvent(cc hvhmodule.CallContext, id int64, status string) {
+	signature := SigProposalStatusChanged
+	cc.FrameLogger().Debugf("%s event: height=%d id=%d status=%s",
+		signature, cc.BlockHeight(), id, status)
+	cc.OnEvent(
+		state.SystemAddress,
+		[][]byte{
+			[]byte(signature),
+			intconv.Int64ToBytes(id),
+		},
+		[][]byte{
+			[]byte(status),
+		},
+	)
+}
+
+func onParameterChangeScheduledEvent(cc hvhmodule.CallContext, id int64, pType string, height int64) {
+	signature := SigParameterChangeScheduled
+	cc.FrameLogger().Debugf("%s event: height=%d id=%d type=%s target=%d",
+		signature, cc.BlockHeight(), id, pType, height)
+	cc.OnEvent(
+		state.SystemAddress,
+		[][]byte{
+			[]byte(signature),
+			intconv.Int64ToBytes(id),
+		},
+		[][]byte{
+			[]byte(pType),
+			intconv.Int64ToBytes(height),
+		},
+	)
+}
+
+func onParameterChangeCanceledEvent(cc hvhmodule.CallContext, id int64) {
+	signature := SigParameterChangeCanceled
+	cc.FrameLogger().Debugf("%s event: height=%d id=%d", signature, cc.BlockHeight(), id)
+	cc.OnEvent(
+		state.SystemAddress,
+		[][]byte{
+			[]byte(signature),
+			intconv.Int64ToBytes(id),
+		},
+		nil,
+	)
+}
+
+func onParameterChangeAppliedEvent(cc hvhmodule.CallContext, id int64, pType string, success bool) {
+	signature := SigParameterChangeApplied
+	cc.FrameLogger().Debugf("%s event: height=%d id=%d type=%s success=%t",
+		signature, cc.BlockHeight(), id, pType, success)
+	result := int64(0)
+	if success {
+		result = 1
+	}
+	cc.OnEvent(
+		state.SystemAddress,
+		[][]byte{
+			[]byte(signature),
+			intconv.Int64ToBytes(id),
+		},
+		[][]byte{
+			[]byte(pType),
+			intconv.Int64ToBytes(result),
+		},
+	)
+}
+
+func onVestingScheduleAddedEvent(cc hvhmodule.CallContext, name, vType string) {
+	signature := SigVestingScheduleAdded
+	cc.FrameLogger().Debugf("%s event: height=%d name=%s type=%s",
+		signature, cc.BlockHeight(), name, vType)
+	cc.OnEvent(
+		state.SystemAddress,
+		[][]byte{
+			[]byte(signature),
+			[]byte(name),
+		},
+		[][]byte{
+			[]byte(vType),
+		},
+	)
+}
+
+func onPlanetVestingChangedEvent(cc hvhmodule.CallContext, id int64, name string) {
+	signature := SigPlanetVestingChanged
+	cc.FrameLogger().Debugf("%s event: height=%d id=%d name=%s",
+		signature, cc.BlockHeight(), id, name)
+	cc.OnEvent(
+		state.SystemAddress,
+		[][]byte{
+			[]byte(signature),
+			intconv.Int64ToBytes(id),
+		},
+		[][]byte{
+			[]byte(name),
+		},
+	)
+}
+
+func onPlanetRewardsClaimedEvent(cc hvhmodule.CallContext, owner module.Address, cursor, next int64, amount *big.Int) {
+	signature := SigPlanetRewardsClaimed
+	cc.FrameLogger().Debugf("%s event: height=%d owner=%s cursor=%d next=%d amount=%d",
+		signature, cc.BlockHeight(), owner, cursor, next, amount)
+	cc.OnEvent(
+		state.SystemAddress,
+		[][]byte{
+			[]byte(signature),
+			owner.Bytes(),
+		},
+		[][]byte{
+			intconv.Int64ToBytes(cursor),
+			intconv.Int64ToBytes(next),
+			intconv.BigIntToBytes(amount),
+		},
+	)
+}
+
+func onMultisigCreatedEvent(cc hvhmodule.CallContext, address module.Address, threshold int64) {
+	signature := SigMultisigCreated
+	cc.FrameLogger().Debugf("%s event: height=%d address=%s threshold=%d",
+		signature, cc.BlockHeight(), address, threshold)
+	cc.OnEvent(
+		state.SystemAddress,
+		[][]byte{
+			[]byte(signature),
+			address.Bytes(),
+		},
+		[][]byte{
+			intconv.Int64ToBytes(threshold),
+		},
+	)
+}
+
+func onMultisigSignersChangedEvent(cc hvhmodule.CallContext, address module.Address, threshold int64) {
+	signature := SigMultisigSignersChanged
+	cc.FrameLogger().Debugf("%s event: height=%d address=%s threshold=%d",
+		signature, cc.BlockHeight(), address, threshold)
+	cc.OnEvent(
+		state.SystemAddress,
+		[][]byte{
+			[]byte(signature),
+			address.Bytes(),
+		},
+		[][]byte{
+			intconv.Int64ToBytes(threshold),
+		},
+	)
+}
+
+func onMultisigOperationSubmittedEvent(
+	cc hvhmodule.CallContext, id int64, multisig, to module.Address, method string) {
+	signature := SigMultisigOperationSubmitted
+	cc.FrameLogger().Debugf("%s event: height=%d id=%d multisig=%s to=%s method=%s",
+		signature, cc.BlockHeight(), id, multisig, to, method)
+	cc.OnEvent(
+		state.SystemAddress,
+		[][]byte{
+			[]byte(signature),
+			intconv.Int64ToBytes(id),
+			multisig.Bytes(),
+		},
+		[][]byte{
+			to.Bytes(),
+			[]byte(method),
+		},
+	)
+}
+
+func onMultisigOperationApprovedEvent(cc hvhmodule.CallContext, id int64, signer module.Address) {
+	signature := SigMultisigOperationApproved
+	cc.FrameLogger().Debugf("%s event: height=%d id=%d signer=%s",
+		signature, cc.BlockHeight(), id, signer)
+	cc.OnEvent(
+		state.SystemAddress,
+		[][]byte{
+			[]byte(signature),
+			intconv.Int64ToBytes(id),
+		},
+		[][]byte{
+			signer.Bytes(),
+		},
+	)
+}
+
+func onMultisigOperationStatusChangedEvent(cc hvhmodule.CallContext, id int64, status string) {
+	signature := SigMultisigOperationStatusChanged
+	cc.FrameLogger().Debugf("%s event: height=%d id=%d status=%s",
+		signature, cc.BlockHeight(), id, status)
+	cc.OnEvent(
+		state.SystemAddress,
+		[][]byte{
+			[]byte(signature),
+			intconv.Int64ToBytes(id),
+		},
+		[][]byte{
+			[]byte(status),
+		},
+	)
+}
+
+// onPlanetNodeKeySetEvent is called when a planet owner registers or removes the node key of a planet
+func onPlanetNodeKeySetEvent(cc hvhmodule.CallContext, id int64, owner module.Address, nodeKey []byte) {
+	signature := SigPlanetNodeKeySet
+	cc.FrameLogger().Debugf("%s event: height=%d id=%d owner=%s nodeKey=%#x",
+		signature, cc.BlockHeight(), id, owner, nodeKey)
+	cc.OnEvent(
+		state.SystemAddress,
+		[][]byte{
+			[]byte(signature),
+			intconv.Int64ToBytes(id),
+		},
+		[][]byte{
+			owner.Bytes(),
+			nodeKey,
+		},
+	)
+}
@@ -96,6 +96,12 @@ func (ess *ExtensionSnapshotImpl) NewState(readonly bool) state.ExtensionState {
 	}
 }
 
+// ExportState returns the readable form of the extension state
+func (ess *ExtensionSnapshotImpl) ExportState(maxPlanetID int64) (*hvhstate.StateDump, error) {
+	s := hvhstate.NewStateFromSnapshot(ess.state, true, hvhutils.NewLogger(nil))
+	return s.ExportState(maxPlanetID)
+}
+
 func NewExtensionSnapshot(dbase db.Database, hash []byte) state.ExtensionSnapshot {
 	if hash == nil {
 		return &ExtensionSnapshotImpl{
@@ -167,6 +173,11 @@ func (es *ExtensionStateImpl) InitPlatformConfig(cfg *PlatformConfig) error {
 	return nil
 }
 
+// ImportState loads the state exported from another network at genesis
+func (es *ExtensionStateImpl) ImportState(rev int, dump *hvhstate.StateDump) error {
+	return es.state.ImportState(rev, dump)
+}
+
 func (es *ExtensionStateImpl) GetIssueStart() int64 {
 	return es.state.GetIssueStart()
 }
@@ -215,6 +226,82 @@ func (es *ExtensionStateImpl) SetUSDTPrice(price *big.Int) error {
 	return es.state.SetUSDTPrice(price)
 }
 
+func (es *ExtensionStateImpl) AddPriceReporter(address module.Address) error {
+	return es.state.AddPriceReporter(address)
+}
+
+func (es *ExtensionStateImpl) RemovePriceReporter(address module.Address) error {
+	return es.state.RemovePriceReporter(address)
+}
+
+func (es *ExtensionStateImpl) ReportUSDTPrice(cc hvhmodule.CallContext, price *big.Int) error {
+	height := cc.BlockHeight()
+	from := cc.From()
+	es.Logger().Debugf("ReportUSDTPrice() start: height=%d from=%s price=%d", height, from, price)
+
+	if err := es.state.ReportUSDTPrice(from, price, height); err != nil {
+		return err
+	}
+	onUSDTPriceReportedEvent(cc, from, price)
+
+	es.Logger().Debugf("ReportUSDTPrice() end: height=%d", height)
+	return nil
+}
+
+func (es *ExtensionStateImpl) SetPriceReportParameters(cc hvhmodule.CallContext, ttl, minReports int64) error {
+	height := cc.BlockHeight()
+	es.Logger().Debugf("SetPriceReportParameters() start: height=%d ttl=%d minReports=%d", height, ttl, minReports)
+	err := es.state.SetPriceReportParameters(ttl, minReports)
+	es.Logger().Debugf("SetPriceReportParameters() end: height=%d", height)
+	return err
+}
+
+func (es *ExtensionStateImpl) GetPriceReports(cc hvhmodule.CallContext) (map[string]interface{}, error) {
+	height := cc.BlockHeight()
+	ttl := es.state.GetPriceReportTTL()
+	reporters := es.state.GetPriceReporters()
+	reports := make([]interface{}, len(reporters))
+	for i, reporter := range reporters {
+		pr, err := es.state.GetPriceReport(reporter)
+		if err != nil {
+			return nil, err
+		}
+		jso := map[string]interface{}{
+			"reporter": reporter,
+		}
+		if pr != nil {
+			jso["price"] = pr.Price()
+			jso["height"] = pr.Height()
+			jso["fresh"] = pr.IsFresh(height, ttl)
+		}
+		reports[i] = jso
+	}
+	return map[string]interface{}{
+		"height":          height,
+		"priceReportTTL":  ttl,
+		"minPriceReports": es.state.GetMinPriceReports(),
+		"reports":         reports,
+	}, nil
+}
+
+// updateUSDTPriceByReports replaces USDTPrice with the median of fresh price reports.
+// USDTPrice set by governance remains if there are not enough reports
+func (es *ExtensionStateImpl) updateUSDTPriceByReports(cc hvhmodule.CallContext, termSeq int64) error {
+	price, count, err := es.state.GetMedianUSDTPrice(cc.BlockHeight())
+	if err != nil {
+		return err
+	}
+	es.Logger().Debugf("updateUSDTPriceByReports(): termSeq=%d price=%v count=%d", termSeq, price, count)
+	if price == nil {
+		return nil
+	}
+	if err = es.state.SetUSDTPrice(price); err != nil {
+		return err
+	}
+	onUSDTPriceUpdatedEvent(cc, termSeq, price, count)
+	return nil
+}
+
 func (es *ExtensionStateImpl) GetIssueInfo(cc hvhmodule.CallContext) (map[string]interface{}, error) {
 	height := cc.BlockHeight()
 	issueStart := es.state.GetIssueStart() // in height
@@ -262,9 +349,15 @@ func (es *ExtensionStateImpl) RegisterPlanet(
 	usdt *big.Int, price *big.Int,
 ) error {
 	height := cc.BlockHeight()
-	return es.state.RegisterPlanet(
-		cc.Revision().Value(),
-		id, isPrivate, isCompany, owner, usdt, price, height)
+	rev := cc.Revision().Value()
+	if err := es.state.RegisterPlanet(
+		rev, id, isPrivate, isCompany, owner, usdt, price, height); err != nil {
+		return err
+	}
+	if rev >= hvhmodule.RevisionPlanetOwnerIndex {
+		onPlanetTransferredEvent(cc, id, state.ZeroAddress, owner)
+	}
+	return nil
 }
 
 func (es *ExtensionStateImpl) UnregisterPlanet(cc hvhmodule.CallContext, id int64) error {
@@ -272,7 +365,17 @@ func (es *ExtensionStateImpl) UnregisterPlanet(cc hvhmodule.CallContext, id int6
 	rev := cc.Revision().Value()
 	es.Logger().Debugf("UnregisterPlanet() start: id=%d height=%d rev=%d", id, height, rev)
 
+	var owner module.Address
+	if rev >= hvhmodule.RevisionPlanetOwnerIndex {
+		if p, err := es.state.GetPlanet(id); err == nil {
+			owner = p.Owner()
+		}
+	}
+
 	lostDelta, err := es.state.UnregisterPlanet(rev, id)
+	if err == nil && owner != nil {
+		onPlanetTransferredEvent(cc, id, owner, state.ZeroAddress)
+	}
 	if rev >= hvhmodule.RevisionLostCoin {
 		if err == nil && lostDelta != nil && lostDelta.Sign() > 0 {
 			lostTotal, _ := es.state.GetLost()
@@ -288,12 +391,107 @@ func (es *ExtensionStateImpl) UnregisterPlanet(cc hvhmodule.CallContext, id int6
 
 func (es *ExtensionStateImpl) SetPlanetOwner(cc hvhmodule.CallContext, id int64, owner module.Address) error {
 	height := cc.BlockHeight()
+	rev := cc.Revision().Value()
 	es.Logger().Debugf("SetPlanetOwner() start: height=%d id=%d owner=%s", height, id, owner)
-	err := es.state.SetPlanetOwner(id, owner)
+
+	var oldOwner module.Address
+	if rev >= hvhmodule.RevisionPlanetOwnerIndex {
+		if p, err := es.state.GetPlanet(id); err == nil {
+			oldOwner = p.Owner()
+		}
+	}
+
+	err := es.state.SetPlanetOwner(rev, id, owner)
+	if err == nil && oldOwner != nil && !oldOwner.Equal(owner) {
+		onPlanetTransferredEvent(cc, id, oldOwner, owner)
+	}
 	es.Logger().Debugf("SetPlanetOwner() end: err=%#v", err)
 	return err
 }
 
+// GetPlanetsOf returns up to limit planet ids from start in the owner-to-planets index
+// and the start for the next call, which is 0 if there are no more planets
+func (es *ExtensionStateImpl) GetPlanetsOf(
+	cc hvhmodule.CallContext, owner module.Address, start int64, limit int) (map[string]interface{}, error) {
+	if limit < 1 || limit > hvhmodule.MaxCountToQuery {
+		return nil, scoreresult.Errorf(
+			hvhmodule.StatusIllegalArgument,
+			"Invalid limit: %d not in [1, %d]", limit, hvhmodule.MaxCountToQuery)
+	}
+	ids, size, err := es.state.GetPlanetsOf(owner, start, limit)
+	if err != nil {
+		return nil, err
+	}
+	planets := make([]interface{}, len(ids))
+	for i, id := range ids {
+		planets[i] = id
+	}
+	next := start + int64(len(ids))
+	if next >= int64(size) {
+		next = 0
+	}
+	return map[string]interface{}{
+		"height":  cc.BlockHeight(),
+		"owner":   owner,
+		"planets": planets,
+		"total":   size,
+		"next":    next,
+	}, nil
+}
+
+// IndexPlanets adds the planets registered before RevisionPlanetOwnerIndex to the owner-to-planets index
+func (es *ExtensionStateImpl) IndexPlanets(cc hvhmodule.CallContext, ids []int64) error {
+	es.Logger().Debugf("IndexPlanets() start: height=%d ids=%v", cc.BlockHeight(), ids)
+	if len(ids) > hvhmodule.MaxCountToIndex {
+		return scoreresult.Errorf(
+			hvhmodule.StatusIllegalArgument,
+			"Too many ids to index: %d > max(%d)", len(ids), hvhmodule.MaxCountToIndex)
+	}
+	count, err := es.state.IndexPlanets(ids)
+	es.Logger().Debugf("IndexPlanets() end: count=%d err=%v", count, err)
+	return err
+}
+
+func (es *ExtensionStateImpl) SetPlanetClaimDelegate(
+	cc hvhmodule.CallContext, id int64, delegate module.Address) error {
+	owner := cc.From()
+	es.Logger().Debugf(
+		"SetPlanetClaimDelegate() start: height=%d id=%d owner=%s delegate=%s",
+		cc.BlockHeight(), id, owner, delegate)
+	if err := es.state.SetPlanetClaimDelegate(id, owner, delegate); err != nil {
+		return err
+	}
+	onClaimDelegateSetEvent(cc, id, owner, delegate)
+	es.Logger().Debugf("SetPlanetClaimDelegate() end")
+	return nil
+}
+
+func (es *ExtensionStateImpl) SetPlanetRewardBeneficiary(
+	cc hvhmodule.CallContext, id int64, beneficiary module.Address) error {
+	owner := cc.From()
+	es.Logger().Debugf(
+		"SetPlanetRewardBeneficiary() start: height=%d id=%d owner=%s beneficiary=%s",
+		cc.BlockHeight(), id, owner, beneficiary)
+	if err := es.state.SetPlanetRewardBeneficiary(id, owner, beneficiary); err != nil {
+		return err
+	}
+	onRewardBeneficiarySetEvent(cc, id, owner, beneficiary)
+	es.Logger().Debugf("SetPlanetRewardBeneficiary() end")
+	return nil
+}
+
+func (es *ExtensionStateImpl) GetPlanetClaimConfig(
+	cc hvhmodule.CallContext, id int64) (map[string]interface{}, error) {
+	pcc, err := es.state.GetPlanetClaimConfig(id)
+	if err != nil {
+		return nil, err
+	}
+	jso := pcc.ToJSON()
+	jso["height"] = cc.BlockHeight()
+	jso["id"] = id
+	return jso, nil
+}
+
 func (es *ExtensionStateImpl) GetPlanetInfo(_ hvhmodule.CallContext, id int64) (map[string]interface{}, error) {
 	p, err := es.state.GetPlanet(id)
 	if err != nil {
@@ -312,26 +510,75 @@ func (es *ExtensionStateImpl) ReportPlanetWork(cc hvhmodule.CallContext, id int6
 			"IssueDoesntStarted(height=%d,issueStart=%d)", height, issueStart)
 	}
 
-	// Check if a planet exists
-	p, err := es.state.GetPlanet(id)
-	if err != nil {
+	termPeriod := es.state.GetTermPeriod()
+	termSeq := (height - issueStart) / termPeriod
+	termStart := termSeq*termPeriod + issueStart
+
+	if err := es.reportPlanetWork(cc, id, termSeq, termStart, nil, false); err != nil {
 		return err
 	}
+	es.Logger().Debugf("ReportPlanetWork() end: height=%d id=%d", height, id)
+	return nil
+}
+
+// ReportPlanetWorks handles the work reports of multiple planets in one transaction.
+// A planet which fails to be validated is skipped with PlanetWorkRejected eventlog
+// while the others get their rewards in the same way as ReportPlanetWork does.
+func (es *ExtensionStateImpl) ReportPlanetWorks(cc hvhmodule.CallContext, ids []int64) error {
+	height := cc.BlockHeight()
+	es.Logger().Debugf("ReportPlanetWorks() start: height=%d ids=%v", height, ids)
+
+	if len(ids) > hvhmodule.MaxCountToReport {
+		return scoreresult.Errorf(
+			hvhmodule.StatusIllegalArgument,
+			"Too many ids to report: %d > max(%d)", len(ids), hvhmodule.MaxCountToReport)
+	}
+
+	issueStart := es.state.GetIssueStart()
+	if !hvhstate.IsIssueStarted(height, issueStart) {
+		return errors.InvalidStateError.Errorf(
+			"IssueDoesntStarted(height=%d,issueStart=%d)", height, issueStart)
+	}
 
 	termPeriod := es.state.GetTermPeriod()
 	termSeq := (height - issueStart) / termPeriod
 	termStart := termSeq*termPeriod + issueStart
-	termNumber := termSeq + 1
 
-	es.Logger().Debugf(
-		"planet=%#v height=%d istart=%d tp=%d tseq=%d tstart=%d",
-		p, height, issueStart, termPeriod, termSeq, termStart)
+	for _, id := range ids {
+		if err := es.reportPlanetWork(cc, id, termSeq, termStart, nil, true); err != nil {
+			return err
+		}
+	}
+
+	es.Logger().Debugf("ReportPlanetWorks() end: height=%d", height)
+	return nil
+}
+
+// reportPlanetWork offers the reward of a given term to a planet.
+// signature is the attestation of the planet node, which is required only for a planet with a node key.
+// If skipInvalid is true, a planet which is not eligible for the reward is skipped
+// with PlanetWorkRejected eventlog instead of returning an error
+func (es *ExtensionStateImpl) reportPlanetWork(
+	cc hvhmodule.CallContext, id, termSeq, termStart int64, signature []byte, skipInvalid bool) error {
+	// Check if a planet exists
+	p, err := es.state.GetPlanet(id)
+	if err != nil {
+		return es.rejectPlanetWork(cc, termSeq, id, err, skipInvalid)
+	}
+	if cc.Revision().Value() >= hvhmodule.RevisionPlanetNodeKey {
+		if err = es.verifyPlanetWork(cc, id, termSeq, signature); err != nil {
+			return es.rejectPlanetWork(cc, termSeq, id, err, skipInvalid)
+		}
+	}
+
+	es.Logger().Debugf("planet=%#v tseq=%d tstart=%d", p, termSeq, termStart)
 
 	if p.Height() >= termStart {
 		// If a planet is registered in this term, ignore its work report
-		return scoreresult.Errorf(
+		err = scoreresult.Errorf(
 			hvhmodule.StatusRewardError,
 			"ReportPlanetWork not allowed during this term: tseq=%d id=%d", termSeq, id)
+		return es.rejectPlanetWork(cc, termSeq, id, err, skipInvalid)
 	}
 
 	// All planets have their own planetReward info
@@ -339,13 +586,28 @@ func (es *ExtensionStateImpl) ReportPlanetWork(cc hvhmodule.CallContext, id int6
 	if err != nil {
 		return err
 	}
+	termNumber := termSeq + 1
 	if termNumber <= pr.LastTermNumber() {
-		return scoreresult.Errorf(
+		err = scoreresult.Errorf(
 			hvhmodule.StatusRewardError,
 			"Duplicate reportPlanetWork: tn=%d id=%d", termNumber, id)
+		return es.rejectPlanetWork(cc, termSeq, id, err, skipInvalid)
 	}
 
 	_, reward := es.state.GetActivePlanetCountAndReward()
+	weight := int64(hvhmodule.PlanetWeightDenom)
+	if cc.Revision().Value() >= hvhmodule.RevisionPlanetWeight && es.state.GetActivePlanetWeight().Sign() > 0 {
+		if weight, _, err = es.state.GetPlanetWeight(id); err != nil {
+			return err
+		}
+		reward = es.state.GetActivePlanetReward(weight)
+	}
+	if cc.Revision().Value() >= hvhmodule.RevisionPlanetWeight {
+		// A planet registered before RevisionPlanetWeight follows the weight of its class from the next term
+		if err = es.state.ReservePlanetWeighting(id); err != nil {
+			return err
+		}
+	}
 	rewardWithHoover := reward
 
 	if err = es.state.DecreaseRewardRemain(reward); err != nil {
@@ -361,6 +623,11 @@ func (es *ExtensionStateImpl) ReportPlanetWork(cc hvhmodule.CallContext, id int6
 	hooverRequest := hvhmodule.BigIntZero
 	if hooverLimit.Sign() > 0 {
 		hooverGuide := calcHooverGuide(p.USDT(), es.state.GetActiveUSDTPrice())
+		if weight != hvhmodule.PlanetWeightDenom {
+			// Hoover subsidy follows the weight of a planet as well
+			hooverGuide.Mul(hooverGuide, big.NewInt(weight))
+			hooverGuide.Div(hooverGuide, big.NewInt(hvhmodule.PlanetWeightDenom))
+		}
 		es.Logger().Debugf("hooverGuide=%d", hooverGuide)
 		if reward.Cmp(hooverGuide) < 0 {
 			hooverBalance := cc.GetBalance(hvhmodule.HooverFund)
@@ -375,7 +642,11 @@ func (es *ExtensionStateImpl) ReportPlanetWork(cc hvhmodule.CallContext, id int6
 		hvhmodule.HooverFund, hvhmodule.PublicTreasury, hooverRequest, module.Transfer); err != nil {
 		return err
 	}
+	if err = es.addFundFlow(cc, hvhstate.FundFlowHooverSubsidy, hooverRequest); err != nil {
+		return err
+	}
 
+	ownerReward := rewardWithHoover
 	if p.IsCompany() {
 		// Divide company rewards into ecoSystem and owner in a ratio of 6:4
 		proportion := hvhmodule.BigRatEcoSystemToCompanyReward
@@ -389,6 +660,7 @@ func (es *ExtensionStateImpl) ReportPlanetWork(cc hvhmodule.CallContext, id int6
 		if err = es.state.IncreaseEcoSystemReward(ecoReward); err != nil {
 			return err
 		}
+		ownerReward = planetReward
 	} else {
 		if err = es.state.OfferReward(
 			termNumber, id, pr, rewardWithHoover, rewardWithHoover); err != nil {
@@ -399,10 +671,26 @@ func (es *ExtensionStateImpl) ReportPlanetWork(cc hvhmodule.CallContext, id int6
 	if err = es.state.IncrementWorkingPlanet(); err != nil {
 		return err
 	}
+	if cc.Revision().Value() >= hvhmodule.RevisionPlanetRewardHistory {
+		if err = es.state.AddRewardHistory(
+			id, termSeq, rewardWithHoover, hooverRequest, ownerReward); err != nil {
+			return err
+		}
+	}
 	onRewardOfferedEvent(cc, termSeq, id, rewardWithHoover, hooverRequest)
 	es.Logger().Debugf(
-		"ReportPlanetWork() end: height=%d id=%d rewardWithHoover=%d hooverRequest=%d",
-		height, id, rewardWithHoover, hooverRequest)
+		"reportPlanetWork(): id=%d rewardWithHoover=%d hooverRequest=%d",
+		id, rewardWithHoover, hooverRequest)
+	return nil
+}
+
+func (es *ExtensionStateImpl) rejectPlanetWork(
+	cc hvhmodule.CallContext, termSeq, id int64, err error, skipInvalid bool) error {
+	if !skipInvalid {
+		return err
+	}
+	es.Logger().Warnf("Failed to report the work of %d: %v", id, err)
+	onPlanetWorkRejectedEvent(cc, termSeq, id, err.Error())
 	return nil
 }
 
@@ -439,43 +727,110 @@ func calcSubsidyFromHooverFund(
 	return hooverRequest
 }
 
-// ClaimPlanetReward is used by a planet owner
-// who wants to transfer a reward from system treasury to owner account
+// ClaimPlanetReward is used by a planet owner or its claim delegate
+// who wants to transfer a reward from system treasury to owner account.
+// If the planet has a reward beneficiary, the reward is transferred to it instead
 func (es *ExtensionStateImpl) ClaimPlanetReward(cc hvhmodule.CallContext, ids []int64) error {
-	height := cc.BlockHeight()
-	es.Logger().Debugf("ClaimPlanetReward() start: height=%d ids=%v", height, ids)
+	es.Logger().Debugf("ClaimPlanetReward() start: height=%d ids=%v", cc.BlockHeight(), ids)
 
 	if len(ids) > hvhmodule.MaxCountToClaim {
 		return scoreresult.Errorf(
 			hvhmodule.StatusIllegalArgument,
 			"Too many ids to claim: %d > max(%d)", len(ids), hvhmodule.MaxCountToClaim)
 	}
+	_, err := es.claimPlanetRewards(cc, ids)
+
+	es.Logger().Debugf("ClaimPlanetReward() end")
+	return err
+}
 
+// claimPlanetRewards returns the sum of rewards claimed for given planets
+func (es *ExtensionStateImpl) claimPlanetRewards(cc hvhmodule.CallContext, ids []int64) (*big.Int, error) {
+	height := cc.BlockHeight()
 	issueStart := es.state.GetIssueStart()
 	if !hvhstate.IsIssueStarted(height, issueStart) {
-		return errors.InvalidStateError.Errorf(
+		return nil, errors.InvalidStateError.Errorf(
 			"IssueDoesntStarted(height=%d,issueStart=%d)", height, issueStart)
 	}
 	termPeriod := es.state.GetTermPeriod()
 	termSeq := (height - issueStart) / termPeriod
 
-	owner := cc.From()
+	from := cc.From()
+	rev := cc.Revision().Value()
+	claimed := new(big.Int)
 	for _, id := range ids {
-		reward, err := es.state.ClaimPlanetReward(id, height, owner)
+		reward, err := es.state.ClaimPlanetReward(id, height, from)
 		if err != nil {
 			es.Logger().Warnf("Failed to claim a reward for %d", id)
 		}
 		if reward != nil && reward.Sign() > 0 {
-			if err = cc.Transfer(hvhmodule.PublicTreasury, owner, reward, module.Claim); err != nil {
-				return nil
+			to := from
+			if rev >= hvhmodule.RevisionPlanetClaimDelegate {
+				if to, err = es.state.GetRewardBeneficiary(id); err != nil {
+					return nil, err
+				}
 			}
-			onRewardClaimedEvent(cc, owner, termSeq, id, reward)
-			es.Logger().Debugf("owner=%s termSeq=%d id=%d reward=%d", owner, termSeq, id, reward)
+			if err = cc.Transfer(hvhmodule.PublicTreasury, to, reward, module.Claim); err != nil {
+				if rev >= hvhmodule.RevisionClaimAllPlanets {
+					// The reward has been debited from the planet, so the tx has to be reverted
+					return nil, err
+				}
+				return claimed, nil
+			}
+			onRewardClaimedEvent(cc, to, termSeq, id, reward)
+			es.Logger().Debugf("from=%s to=%s termSeq=%d id=%d reward=%d", from, to, termSeq, id, reward)
+			claimed.Add(claimed, reward)
 		}
 	}
+	return claimed, nil
+}
 
-	es.Logger().Debugf("ClaimPlanetReward() end")
-	return nil
+// ClaimAllPlanetRewards claims the rewards of up to MaxCountToClaim planets which the sender has,
+// starting from cursor in the owner-to-planets index.
+// It returns the cursor for the next call, which is 0 if there are no more planets to claim
+func (es *ExtensionStateImpl) ClaimAllPlanetRewards(cc hvhmodule.CallContext, cursor int64) (int64, error) {
+	from := cc.From()
+	es.Logger().Debugf(
+		"ClaimAllPlanetRewards() start: height=%d from=%s cursor=%d", cc.BlockHeight(), from, cursor)
+
+	if err := es.state.CheckPlanetIndexCompleted(); err != nil {
+		return 0, err
+	}
+	ids, size, err := es.state.GetPlanetsOf(from, cursor, hvhmodule.MaxCountToClaim)
+	if err != nil {
+		return 0, err
+	}
+	claimed, err := es.claimPlanetRewards(cc, ids)
+	if err != nil {
+		return 0, err
+	}
+
+	next := cursor + int64(len(ids))
+	if next >= int64(size) {
+		next = 0
+	}
+	onPlanetRewardsClaimedEvent(cc, from, cursor, next, claimed)
+
+	es.Logger().Debugf("ClaimAllPlanetRewards() end: count=%d next=%d claimed=%d", len(ids), next, claimed)
+	return next, nil
+}
+
+// GetClaimablePlanetRewards returns the sum of rewards which an owner can claim from up to MaxCountToClaim planets,
+// starting from cursor in the owner-to-planets index in the same way as ClaimAllPlanetRewards
+func (es *ExtensionStateImpl) GetClaimablePlanetRewards(
+	cc hvhmodule.CallContext, owner module.Address, cursor int64) (map[string]interface{}, error) {
+	height := cc.BlockHeight()
+	claimable, count, next, err := es.state.GetClaimablePlanetRewards(height, owner, cursor)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"height":    height,
+		"owner":     owner,
+		"planets":   count,
+		"claimable": claimable,
+		"next":      next,
+	}, nil
 }
 
 func (es *ExtensionStateImpl) GetRewardInfoOf(cc hvhmodule.CallContext, id int64) (map[string]interface{}, error) {
@@ -510,6 +865,35 @@ func (es *ExtensionStateImpl) GetRewardInfosOf(cc hvhmodule.CallContext, ids []i
 	return jso, nil
 }
 
+func (es *ExtensionStateImpl) GetRewardHistoryOf(
+	cc hvhmodule.CallContext, id, fromTerm, toTerm int64) (map[string]interface{}, error) {
+	height := cc.BlockHeight()
+	es.Logger().Debugf(
+		"GetRewardHistoryOf() start: height=%d id=%d fromTerm=%d toTerm=%d",
+		height, id, fromTerm, toTerm)
+
+	// Check if a planet exists
+	if _, err := es.state.GetPlanet(id); err != nil {
+		return nil, err
+	}
+	rhs, err := es.state.GetRewardHistory(id, fromTerm, toTerm)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]interface{}, len(rhs))
+	for i, rh := range rhs {
+		history[i] = rh.ToJSON()
+	}
+
+	es.Logger().Debugf("GetRewardHistoryOf() end: height=%d id=%d history=%d", height, id, len(rhs))
+	return map[string]interface{}{
+		"height":        height,
+		"id":            id,
+		"rewardHistory": history,
+	}, nil
+}
+
 func (es *ExtensionStateImpl) GetRewardInfo(cc hvhmodule.CallContext) (map[string]interface{}, error) {
 	es.Logger().Debugf("GetRewardInfo() start")
 
@@ -609,6 +993,90 @@ func (es *ExtensionStateImpl) GetBlockVoteCheckParameters(cc hvhmodule.CallConte
 	}, nil
 }
 
+func (es *ExtensionStateImpl) SetJailParameters(cc hvhmodule.CallContext, cooldown int64) error {
+	height := cc.BlockHeight()
+	es.logger.Debugf("SetJailParameters() start: height=%d cooldown=%d", height, cooldown)
+	err := es.state.SetJailCooldown(cooldown)
+	es.logger.Debugf("SetJailParameters() end: height=%d", height)
+	return err
+}
+
+func (es *ExtensionStateImpl) GetJailParameters(cc hvhmodule.CallContext) (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"height":       cc.BlockHeight(),
+		"jailCooldown": es.state.GetJailCooldown(),
+	}, nil
+}
+
+func (es *ExtensionStateImpl) GetJailedValidators(cc hvhmodule.CallContext) (map[string]interface{}, error) {
+	owners := es.state.GetJailedValidators()
+	validators := make([]interface{}, len(owners))
+	for i, owner := range owners {
+		vi, err := es.state.GetValidatorInfo(owner)
+		if err != nil {
+			return nil, err
+		}
+		validators[i] = map[string]interface{}{
+			"owner":       owner,
+			"node":        vi.Address(),
+			"jailedUntil": es.state.GetJailedUntil(owner),
+		}
+	}
+	return map[string]interface{}{
+		"height":     cc.BlockHeight(),
+		"validators": validators,
+	}, nil
+}
+
+func (es *ExtensionStateImpl) SetValidatorRewardRate(cc hvhmodule.CallContext, rate int64) error {
+	height := cc.BlockHeight()
+	es.Logger().Debugf("SetValidatorRewardRate() start: height=%d rate=%d", height, rate)
+	err := es.state.SetValidatorRewardRate(rate)
+	es.Logger().Debugf("SetValidatorRewardRate() end: height=%d", height)
+	return err
+}
+
+// ClaimValidatorReward transfers the rewards of a validator to its owner
+func (es *ExtensionStateImpl) ClaimValidatorReward(cc hvhmodule.CallContext) error {
+	height := cc.BlockHeight()
+	owner := cc.From()
+	es.Logger().Debugf("ClaimValidatorReward() start: height=%d owner=%s", height, owner)
+
+	reward, err := es.state.ClaimValidatorReward(owner)
+	if err != nil {
+		return err
+	}
+	if reward.Sign() > 0 {
+		if err = cc.Transfer(hvhmodule.PublicTreasury, owner, reward, module.Claim); err != nil {
+			return err
+		}
+		onValidatorRewardClaimedEvent(cc, owner, reward)
+	}
+
+	es.Logger().Debugf("ClaimValidatorReward() end: height=%d reward=%d", height, reward)
+	return nil
+}
+
+func (es *ExtensionStateImpl) GetValidatorRewardInfo(
+	cc hvhmodule.CallContext, owner module.Address) (map[string]interface{}, error) {
+	if owner == nil {
+		return nil, scoreresult.InvalidParameterError.New("Invalid owner")
+	}
+	vr, err := es.state.GetValidatorReward(owner)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"height":     cc.BlockHeight(),
+		"owner":      owner,
+		"total":      vr.Total(),
+		"claimable":  vr.Claimable(),
+		"votes":      es.state.GetValidatorVotes(owner),
+		"rewardRate": es.state.GetValidatorRewardRate(),
+		"rewardPool": es.state.GetValidatorRewardPool(),
+	}, nil
+}
+
 func (es *ExtensionStateImpl) RegisterValidator(
 	cc hvhmodule.CallContext,
 	owner module.Address, nodePublicKey []byte, gradeName, name string,
@@ -876,6 +1344,9 @@ func (es *ExtensionStateImpl) GetValidatorStatus(
 	if err == nil {
 		jso = vs.ToJSON()
 		jso["height"] = height
+		if vs.Jailed() {
+			jso["jailedUntil"] = es.state.GetJailedUntil(owner)
+		}
 	}
 
 	es.Logger().Debugf("GetValidatorStatus() end: owner=%s err=%v", owner, err)
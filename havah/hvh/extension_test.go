@@ -10,6 +10,7 @@ import (
 	"golang.org/x/crypto/sha3"
 
 	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/crypto"
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/common/intconv"
@@ -59,6 +60,30 @@ func (as *mockAccount) DeleteValue(k []byte) ([]byte, error) {
 	return old, nil
 }
 
+type mockAccountSnapshot struct {
+	state.AccountSnapshot
+	balance *big.Int
+	store   map[string][]byte
+}
+
+func (as *mockAccount) GetSnapshot() state.AccountSnapshot {
+	store := make(map[string][]byte, len(as.store))
+	for k, v := range as.store {
+		store[k] = v
+	}
+	return &mockAccountSnapshot{balance: as.balance, store: store}
+}
+
+func (as *mockAccount) Reset(snapshot state.AccountSnapshot) error {
+	ss := snapshot.(*mockAccountSnapshot)
+	as.balance = ss.balance
+	as.store = make(map[string][]byte, len(ss.store))
+	for k, v := range ss.store {
+		as.store[k] = v
+	}
+	return nil
+}
+
 var (
 	EcoSystemIDStr       = string(hvhmodule.EcoSystem.ID())
 	HooverFundIDStr      = string(hvhmodule.HooverFund.ID())
@@ -85,12 +110,22 @@ func newMockAccount(id []byte) *mockAccount {
 	}
 }
 
+type mockEvent struct {
+	indexed [][]byte
+	data    [][]byte
+}
+
 type mockCallContext struct {
 	contract.CallContext
 	height   int64
 	txId     []byte
 	accounts map[string]*mockAccount
 	revision module.Revision
+	events   []mockEvent
+}
+
+func (cc *mockCallContext) ChainID() int {
+	return 1
 }
 
 func (cc *mockCallContext) BlockHeight() int64 {
@@ -123,8 +158,17 @@ func (cc *mockCallContext) GetAccountState(id []byte) state.AccountState {
 }
 
 func (cc *mockCallContext) OnEvent(addr module.Address, indexed, data [][]byte) {
-	// TODO print something?
-	return
+	cc.events = append(cc.events, mockEvent{indexed, data})
+}
+
+func (cc *mockCallContext) eventsOf(signature string) []mockEvent {
+	var events []mockEvent
+	for _, e := range cc.events {
+		if string(e.indexed[0]) == signature {
+			events = append(events, e)
+		}
+	}
+	return events
 }
 
 func (cc *mockCallContext) Treasury() module.Address {
@@ -1553,3 +1597,493 @@ func TestExtensionSnapshotImpl_calcSubsidyFromHooverFund(t *testing.T) {
 		})
 	}
 }
+
+func TestExtensionStateImpl_GetRewardHistoryOf(t *testing.T) {
+	var err error
+	const (
+		publicId  = int64(1)
+		companyId = int64(2)
+	)
+	termPeriod := int64(10)
+	issueAmount := toHVH(100)
+	owner := common.MustNewAddressFromString("hx1234")
+
+	stateCfg := hvhstate.StateConfig{
+		TermPeriod:  &common.HexInt64{Value: termPeriod},
+		USDTPrice:   new(common.HexInt).SetValue(toHVH(1)),
+		IssueAmount: new(common.HexInt).SetValue(issueAmount),
+	}
+	mcc, es := newMockContextAndExtensionState(t, &PlatformConfig{StateConfig: stateCfg})
+	mcc.height = 1
+	mcc.SetRevision(hvhmodule.RevisionPlanetRewardHistory)
+	cc := NewCallContext(mcc, nil)
+
+	issueStartBH := int64(10)
+	err = es.StartRewardIssue(cc, issueStartBH)
+	assert.NoError(t, err)
+
+	priceInUSDT := toUSDT(1_000)
+	priceInHVH := toHVH(10_000)
+	err = es.RegisterPlanet(cc, publicId, false, false, owner, priceInUSDT, priceInHVH)
+	assert.NoError(t, err)
+	err = es.RegisterPlanet(cc, companyId, false, true, owner, priceInUSDT, priceInHVH)
+	assert.NoError(t, err)
+
+	// No history before reportPlanetWork
+	jso, err := es.GetRewardHistoryOf(cc, publicId, 0, 10)
+	assert.NoError(t, err)
+	assert.Zero(t, len(jso["rewardHistory"].([]interface{})))
+
+	// Report planet works for termSeq 0, 1 and 3
+	reportedTerms := []int64{0, 1, 3}
+	for termSeq := int64(0); termSeq < 4; termSeq++ {
+		err = goToNextTerm(t, es, mcc, nil, 1)
+		assert.NoError(t, err)
+		if termSeq == 2 {
+			continue
+		}
+		for _, id := range []int64{publicId, companyId} {
+			err = es.ReportPlanetWork(cc, id)
+			assert.NoError(t, err)
+		}
+	}
+
+	for _, id := range []int64{publicId, companyId} {
+		jso, err = es.GetRewardHistoryOf(cc, id, 0, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, mcc.BlockHeight(), jso["height"].(int64))
+		assert.Equal(t, id, jso["id"].(int64))
+
+		history := jso["rewardHistory"].([]interface{})
+		assert.Equal(t, len(reportedTerms), len(history))
+
+		total := new(big.Int)
+		for i, item := range history {
+			rh := item.(map[string]interface{})
+			assert.Equal(t, reportedTerms[i], rh["termSequence"].(int64))
+
+			rewardWithHoover := rh["rewardWithHoover"].(*big.Int)
+			ownerReward := rh["ownerReward"].(*big.Int)
+			if id == companyId {
+				assert.True(t, ownerReward.Cmp(rewardWithHoover) < 0)
+			} else {
+				assert.Zero(t, ownerReward.Cmp(rewardWithHoover))
+			}
+			total.Add(total, rewardWithHoover)
+		}
+
+		ri, err := es.GetRewardInfoOf(cc, id)
+		assert.NoError(t, err)
+		assert.Zero(t, total.Cmp(ri["total"].(*big.Int)))
+	}
+
+	// Query a part of the history
+	jso, err = es.GetRewardHistoryOf(cc, publicId, 1, 2)
+	assert.NoError(t, err)
+	history := jso["rewardHistory"].([]interface{})
+	assert.Equal(t, 1, len(history))
+	assert.Equal(t, int64(1), history[0].(map[string]interface{})["termSequence"].(int64))
+
+	// Invalid arguments
+	_, err = es.GetRewardHistoryOf(cc, publicId, 2, 1)
+	assert.Error(t, err)
+	_, err = es.GetRewardHistoryOf(cc, 100, 0, 10)
+	assert.Error(t, err)
+
+	// Reward history is removed together when unregistering a planet
+	err = es.UnregisterPlanet(cc, publicId)
+	assert.NoError(t, err)
+	_, err = es.GetRewardHistoryOf(cc, publicId, 0, 10)
+	assert.Error(t, err)
+}
+
+func TestExtensionStateImpl_ReportPlanetWorks(t *testing.T) {
+	var err error
+	termPeriod := int64(10)
+	issueAmount := toHVH(100)
+	owner := common.MustNewAddressFromString("hx1234")
+
+	stateCfg := hvhstate.StateConfig{
+		TermPeriod:  &common.HexInt64{Value: termPeriod},
+		USDTPrice:   new(common.HexInt).SetValue(toHVH(1)),
+		IssueAmount: new(common.HexInt).SetValue(issueAmount),
+	}
+	mcc, es := newMockContextAndExtensionState(t, &PlatformConfig{StateConfig: stateCfg})
+	mcc.height = 1
+	mcc.SetRevision(hvhmodule.RevisionBatchedPlanetWork)
+	cc := NewCallContext(mcc, nil)
+
+	// Not allowed before reward issue starts
+	err = es.ReportPlanetWorks(cc, []int64{1})
+	assert.Error(t, err)
+
+	issueStartBH := int64(10)
+	err = es.StartRewardIssue(cc, issueStartBH)
+	assert.NoError(t, err)
+
+	priceInUSDT := toUSDT(1_000)
+	priceInHVH := toHVH(10_000)
+	ids := []int64{1, 2, 3}
+	for i, id := range ids {
+		err = es.RegisterPlanet(cc, id, false, i == 1, owner, priceInUSDT, priceInHVH)
+		assert.NoError(t, err)
+	}
+
+	// Too many ids
+	err = es.ReportPlanetWorks(cc, make([]int64, hvhmodule.MaxCountToReport+1))
+	assert.Error(t, err)
+
+	err = goToNextTerm(t, es, mcc, nil, 1)
+	assert.NoError(t, err)
+
+	// Planet 4 is registered in this term, planet 5 doesn't exist and planet 1 is duplicated
+	err = es.RegisterPlanet(cc, 4, false, false, owner, priceInUSDT, priceInHVH)
+	assert.NoError(t, err)
+	mcc.events = nil
+	err = es.ReportPlanetWorks(cc, []int64{1, 2, 3, 4, 5, 1})
+	assert.NoError(t, err)
+	assert.Equal(t, len(ids), len(mcc.eventsOf(SigRewardOffered)))
+
+	rejected := mcc.eventsOf(SigPlanetWorkRejected)
+	assert.Equal(t, 3, len(rejected))
+	for i, id := range []int64{4, 5, 1} {
+		assert.Equal(t, int64(0), intconv.BytesToInt64(rejected[i].data[0]))
+		assert.Equal(t, id, intconv.BytesToInt64(rejected[i].data[1]))
+	}
+
+	for _, id := range ids {
+		ri, err := es.GetRewardInfoOf(cc, id)
+		assert.NoError(t, err)
+		assert.True(t, ri["total"].(*big.Int).Sign() > 0)
+	}
+	err = es.ReportPlanetWork(cc, 2)
+	assert.Error(t, err)
+
+	// Single reportPlanetWork and batched one give the same reward to a planet
+	err = goToNextTerm(t, es, mcc, nil, 1)
+	assert.NoError(t, err)
+	err = es.ReportPlanetWork(cc, 1)
+	assert.NoError(t, err)
+	err = es.ReportPlanetWorks(cc, []int64{3})
+	assert.NoError(t, err)
+	ri1, err := es.GetRewardInfoOf(cc, 1)
+	assert.NoError(t, err)
+	ri3, err := es.GetRewardInfoOf(cc, 3)
+	assert.NoError(t, err)
+	assert.Zero(t, ri1["total"].(*big.Int).Cmp(ri3["total"].(*big.Int)))
+}
+
+func TestExtensionStateImpl_ClaimPlanetRewardWithDelegate(t *testing.T) {
+	id := int64(1)
+	issueStart := int64(10)
+	termPeriod := int64(100)
+	owner := common.MustNewAddressFromString("hx1111")
+	pm := common.MustNewAddressFromString("hx2222")
+	delegate := common.MustNewAddressFromString("hx3333")
+	beneficiary := common.MustNewAddressFromString("hx4444")
+
+	mcc, es := newMockContextAndExtensionState(t, newSimplePlatformConfig(termPeriod, 1))
+	mcc.height = 1
+	mcc.SetRevision(hvhmodule.RevisionPlanetClaimDelegate)
+	cc := NewCallContext(mcc, owner)
+
+	err := es.StartRewardIssue(cc, issueStart)
+	assert.NoError(t, err)
+	assert.NoError(t, es.AddPlanetManager(pm))
+
+	err = es.RegisterPlanet(cc, id, false, false, owner, toUSDT(5_000), toHVH(50_000))
+	assert.NoError(t, err)
+
+	assert.NoError(t, es.SetPlanetClaimDelegate(cc, id, delegate))
+	assert.NoError(t, es.SetPlanetRewardBeneficiary(cc, id, beneficiary))
+	assert.Equal(t, 1, len(mcc.eventsOf(SigClaimDelegateSet)))
+	assert.Equal(t, 1, len(mcc.eventsOf(SigRewardBeneficiarySet)))
+
+	jso, err := es.GetPlanetClaimConfig(cc, id)
+	assert.NoError(t, err)
+	assert.True(t, jso["claimDelegate"].(module.Address).Equal(delegate))
+	assert.True(t, jso["rewardBeneficiary"].(module.Address).Equal(beneficiary))
+
+	// Neither the delegate nor the beneficiary can change the claim config
+	err = es.SetPlanetRewardBeneficiary(NewCallContext(mcc, delegate), id, delegate)
+	assert.Error(t, err)
+
+	goByHeight(t, issueStart, es, mcc, owner)
+	assert.NoError(t, es.ReportPlanetWork(NewCallContext(mcc, pm), id))
+	goByCount(t, 1, es, mcc, owner)
+
+	ri, err := es.GetRewardInfoOf(cc, id)
+	assert.NoError(t, err)
+	claimable := ri["claimable"].(*big.Int)
+	assert.True(t, claimable.Sign() > 0)
+
+	// The delegate claims the reward which is transferred to the beneficiary
+	err = es.ClaimPlanetReward(NewCallContext(mcc, delegate), []int64{id})
+	assert.NoError(t, err)
+	assert.Zero(t, mcc.GetBalance(beneficiary).Cmp(claimable))
+	assert.Zero(t, mcc.GetBalance(delegate).Sign())
+	assert.Zero(t, mcc.GetBalance(owner).Sign())
+}
+
+func TestExtensionStateImpl_ClaimAllPlanetRewards(t *testing.T) {
+	issueStart := int64(10)
+	termPeriod := int64(100)
+	count := int64(hvhmodule.MaxCountToClaim + 10)
+	owner := common.MustNewAddressFromString("hx1111")
+	pm := common.MustNewAddressFromString("hx2222")
+
+	mcc, es := newMockContextAndExtensionState(t, newSimplePlatformConfig(termPeriod, 1))
+	mcc.height = 1
+	mcc.SetRevision(hvhmodule.RevisionClaimAllPlanets)
+	cc := NewCallContext(mcc, owner)
+
+	err := es.StartRewardIssue(cc, issueStart)
+	assert.NoError(t, err)
+	assert.NoError(t, es.AddPlanetManager(pm))
+
+	ids := make([]int64, count)
+	for i := range ids {
+		ids[i] = int64(i + 1)
+		err = es.RegisterPlanet(cc, ids[i], false, false, owner, toUSDT(5_000), toHVH(50_000))
+		assert.NoError(t, err)
+	}
+
+	goByHeight(t, issueStart, es, mcc, owner)
+	for i := 0; i < len(ids); i += hvhmodule.MaxCountToReport {
+		end := i + hvhmodule.MaxCountToReport
+		if end > len(ids) {
+			end = len(ids)
+		}
+		assert.NoError(t, es.ReportPlanetWorks(NewCallContext(mcc, pm), ids[i:end]))
+	}
+	goByCount(t, 1, es, mcc, owner)
+
+	jso, err := es.GetClaimablePlanetRewards(cc, owner, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, hvhmodule.MaxCountToClaim, jso["planets"])
+	assert.Equal(t, int64(hvhmodule.MaxCountToClaim), jso["next"])
+	claimable := new(big.Int).Set(jso["claimable"].(*big.Int))
+	assert.True(t, claimable.Sign() > 0)
+	jso, err = es.GetClaimablePlanetRewards(cc, owner, jso["next"].(int64))
+	assert.NoError(t, err)
+	assert.Equal(t, int(count)-hvhmodule.MaxCountToClaim, jso["planets"])
+	assert.Zero(t, jso["next"])
+	claimable.Add(claimable, jso["claimable"].(*big.Int))
+
+	mcc.events = nil
+	next, err := es.ClaimAllPlanetRewards(cc, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(hvhmodule.MaxCountToClaim), next)
+	assert.Equal(t, hvhmodule.MaxCountToClaim, len(mcc.eventsOf(SigRewardClaimed)))
+
+	next, err = es.ClaimAllPlanetRewards(cc, next)
+	assert.NoError(t, err)
+	assert.Zero(t, next)
+	assert.Equal(t, int(count), len(mcc.eventsOf(SigRewardClaimed)))
+	assert.Equal(t, 2, len(mcc.eventsOf(SigPlanetRewardsClaimed)))
+	assert.Zero(t, mcc.GetBalance(owner).Cmp(claimable))
+
+	jso, err = es.GetClaimablePlanetRewards(cc, owner, 0)
+	assert.NoError(t, err)
+	assert.Zero(t, jso["claimable"].(*big.Int).Sign())
+
+	_, err = es.ClaimAllPlanetRewards(cc, count+1)
+	assert.Error(t, err)
+
+	// Claims over the owner-to-planets index are not allowed until all planets are indexed
+	mcc.SetRevision(hvhmodule.Revision7)
+	err = es.RegisterPlanet(cc, count+1, false, false, owner, toUSDT(5_000), toHVH(50_000))
+	assert.NoError(t, err)
+	mcc.SetRevision(hvhmodule.RevisionClaimAllPlanets)
+	_, err = es.ClaimAllPlanetRewards(cc, 0)
+	assert.Error(t, err)
+	_, err = es.GetClaimablePlanetRewards(cc, owner, 0)
+	assert.Error(t, err)
+
+	assert.NoError(t, es.IndexPlanets(cc, []int64{count + 1}))
+	_, err = es.ClaimAllPlanetRewards(cc, 0)
+	assert.NoError(t, err)
+}
+
+func TestExtensionStateImpl_ClaimPlanetRewardTransferFailure(t *testing.T) {
+	id := int64(1)
+	issueStart := int64(10)
+	owner := common.MustNewAddressFromString("hx1111")
+	pm := common.MustNewAddressFromString("hx2222")
+
+	mcc, es := newMockContextAndExtensionState(t, newSimplePlatformConfig(100, 1))
+	mcc.height = 1
+	mcc.SetRevision(hvhmodule.RevisionClaimAllPlanets)
+	cc := NewCallContext(mcc, owner)
+
+	assert.NoError(t, es.StartRewardIssue(cc, issueStart))
+	assert.NoError(t, es.AddPlanetManager(pm))
+	assert.NoError(t, es.RegisterPlanet(cc, id, false, false, owner, toUSDT(5_000), toHVH(50_000)))
+
+	goByHeight(t, issueStart, es, mcc, owner)
+	assert.NoError(t, es.ReportPlanetWork(NewCallContext(mcc, pm), id))
+	goByCount(t, 1, es, mcc, owner)
+
+	// A failed transfer makes the claim fail instead of losing the reward
+	mcc.SetBalance(hvhmodule.PublicTreasury, big.NewInt(0))
+	err := es.ClaimPlanetReward(cc, []int64{id})
+	assert.Error(t, err)
+	assert.Zero(t, len(mcc.eventsOf(SigRewardClaimed)))
+}
+
+func TestExtensionStateImpl_PlanetTransferred(t *testing.T) {
+	id := int64(1)
+	owner := common.MustNewAddressFromString("hx1111")
+	newOwner := common.MustNewAddressFromString("hx2222")
+
+	mcc, es := newMockContextAndExtensionState(t, newSimplePlatformConfig(100, 1))
+	mcc.height = 1
+	mcc.SetRevision(hvhmodule.RevisionPlanetOwnerIndex)
+	cc := NewCallContext(mcc, nil)
+
+	err := es.RegisterPlanet(cc, id, false, false, owner, toUSDT(5_000), toHVH(50_000))
+	assert.NoError(t, err)
+	err = es.SetPlanetOwner(cc, id, newOwner)
+	assert.NoError(t, err)
+
+	jso, err := es.GetPlanetsOf(cc, owner, 0, hvhmodule.MaxCountToQuery)
+	assert.NoError(t, err)
+	assert.Zero(t, len(jso["planets"].([]interface{})))
+	jso, err = es.GetPlanetsOf(cc, newOwner, 0, hvhmodule.MaxCountToQuery)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{id}, jso["planets"])
+	assert.Equal(t, 1, jso["total"])
+	assert.Zero(t, jso["next"])
+
+	_, err = es.GetPlanetsOf(cc, newOwner, 0, 0)
+	assert.Error(t, err)
+	_, err = es.GetPlanetsOf(cc, newOwner, 0, hvhmodule.MaxCountToQuery+1)
+	assert.Error(t, err)
+
+	err = es.UnregisterPlanet(cc, id)
+	assert.NoError(t, err)
+
+	events := mcc.eventsOf(SigPlanetTransferred)
+	assert.Equal(t, 3, len(events))
+	transfers := [][2]module.Address{
+		{state.ZeroAddress, owner},
+		{owner, newOwner},
+		{newOwner, state.ZeroAddress},
+	}
+	for i, e := range events {
+		assert.Equal(t, id, intconv.BytesToInt64(e.indexed[1]))
+		assert.Equal(t, transfers[i][0].Bytes(), e.indexed[2])
+		assert.Equal(t, transfers[i][1].Bytes(), e.indexed[3])
+	}
+}
+
+func TestExtensionStateImpl_ReportUSDTPrice(t *testing.T) {
+	var err error
+	termPeriod := int64(10)
+	issueStart := int64(5)
+	reporters := []module.Address{
+		common.MustNewAddressFromString("hx1111"),
+		common.MustNewAddressFromString("hx2222"),
+		common.MustNewAddressFromString("hx3333"),
+	}
+
+	mcc, es := newMockContextAndExtensionState(t, newSimplePlatformConfig(termPeriod, 1))
+	mcc.SetRevision(hvhmodule.RevisionPriceOracle)
+	cc := NewCallContext(mcc, nil)
+	err = es.StartRewardIssue(cc, issueStart)
+	assert.NoError(t, err)
+	for _, reporter := range reporters {
+		err = es.AddPriceReporter(reporter)
+		assert.NoError(t, err)
+	}
+
+	prices := []*big.Int{toHVH(3), toHVH(1), toHVH(2)}
+	for i, reporter := range reporters {
+		err = es.ReportUSDTPrice(NewCallContext(mcc, reporter), prices[i])
+		assert.NoError(t, err)
+	}
+	err = es.ReportUSDTPrice(NewCallContext(mcc, common.MustNewAddressFromString("hx4444")), toHVH(1))
+	assert.Error(t, err)
+	assert.Equal(t, len(reporters), len(mcc.eventsOf(SigUSDTPriceReported)))
+
+	jso, err := es.GetPriceReports(cc)
+	assert.NoError(t, err)
+	assert.Equal(t, len(reporters), len(jso["reports"].([]interface{})))
+
+	// The median of reports becomes the active USDT price at the start of a term
+	err = goToNextTerm(t, es, mcc, nil, 0)
+	assert.NoError(t, err)
+	assert.Zero(t, toHVH(2).Cmp(es.state.GetUSDTPrice()))
+	assert.Zero(t, toHVH(2).Cmp(es.state.GetActiveUSDTPrice()))
+	events := mcc.eventsOf(SigUSDTPriceUpdated)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, int64(0), intconv.BytesToInt64(events[0].data[0]))
+	assert.Zero(t, toHVH(2).Cmp(intconv.BigIntSetBytes(new(big.Int), events[0].data[1])))
+
+	// Stale reports are ignored and the last price remains
+	err = es.SetPriceReportParameters(cc, termPeriod/2, 1)
+	assert.NoError(t, err)
+	err = goToNextTerm(t, es, mcc, nil, 0)
+	assert.NoError(t, err)
+	assert.Zero(t, toHVH(2).Cmp(es.state.GetActiveUSDTPrice()))
+	assert.Equal(t, 1, len(mcc.eventsOf(SigUSDTPriceUpdated)))
+
+	// Reports are not aggregated before RevisionPriceOracle
+	mcc.SetRevision(hvhmodule.RevisionPriceOracle - 1)
+	err = es.ReportUSDTPrice(NewCallContext(mcc, reporters[0]), toHVH(5))
+	assert.NoError(t, err)
+	err = goToNextTerm(t, es, mcc, nil, 0)
+	assert.NoError(t, err)
+	assert.Zero(t, toHVH(2).Cmp(es.state.GetActiveUSDTPrice()))
+}
+
+func newTestConsensusInfo(
+	t *testing.T, proposer module.Address, nodes []module.Address, voted []bool) module.ConsensusInfo {
+	validators := make([]module.Validator, len(nodes))
+	for i, node := range nodes {
+		v, err := state.ValidatorFromAddress(node)
+		assert.NoError(t, err)
+		validators[i] = v
+	}
+	voters, err := state.ValidatorSnapshotFromSlice(db.NewMapDB(), validators)
+	assert.NoError(t, err)
+	return common.NewConsensusInfo(proposer, voters, voted)
+}
+
+func TestExtensionStateImpl_recordBlockVotes(t *testing.T) {
+	_, es := newMockContextAndExtensionState(t, newSimplePlatformConfig(100, 1))
+
+	owners := make([]module.Address, 3)
+	nodes := make([]module.Address, len(owners)+1)
+	for i := range owners {
+		owners[i] = common.MustNewAddressFromString(fmt.Sprintf("hx%d", i+1))
+		_, pubKey := crypto.GenerateKeyPair()
+		err := es.state.RegisterValidator(
+			owners[i], pubKey.SerializeCompressed(), hvhstate.GradeMain, fmt.Sprintf("main-%d", i), nil)
+		assert.NoError(t, err)
+		nodes[i] = common.NewAccountAddressFromPublicKey(pubKey)
+	}
+	// Unregistered voters are ignored
+	nodes[len(owners)] = common.MustNewAddressFromString("hx1234")
+
+	ci := newTestConsensusInfo(t, nodes[0], nodes, []bool{true, true, false, true})
+	rev := hvhmodule.RevisionValidatorUptime
+	for height := int64(10); height < 13; height++ {
+		assert.NoError(t, es.recordBlockVotes(rev, height, ci))
+	}
+	assert.Equal(t, int64(3), es.state.GetValidatorVotes(owners[0]))
+	assert.Equal(t, int64(3), es.state.GetValidatorVotes(owners[1]))
+	assert.Zero(t, es.state.GetValidatorVotes(owners[2]))
+
+	// Uptime counters are accumulated at every block
+	vu, err := es.state.GetValidatorUptime(owners[0])
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), vu.Votes())
+	assert.Equal(t, int64(3), vu.Proposals())
+	assert.Equal(t, int64(12), vu.LastSeen())
+	vu, err = es.state.GetValidatorUptime(owners[2])
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), vu.Misses())
+	assert.Zero(t, vu.Proposals())
+	assert.Zero(t, vu.LastSeen())
+}
@@ -0,0 +1,51 @@
+package hvh
+
+import (
+	"github.com/icon-project/goloop/havah/hvh/hvhstate"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+)
+
+// GetFundLedger returns the ledgers of a fund between fromTerm and toTerm inclusive
+// with the flows of the current term, whose balance is the one at the moment
+func (es *ExtensionStateImpl) GetFundLedger(
+	cc hvhmodule.CallContext, name string, fromTerm, toTerm int64) (map[string]interface{}, error) {
+	height := cc.BlockHeight()
+	es.Logger().Debugf(
+		"GetFundLedger() start: height=%d fund=%s fromTerm=%d toTerm=%d", height, name, fromTerm, toTerm)
+
+	fund, err := hvhstate.ParseFund(name)
+	if err != nil {
+		return nil, err
+	}
+	curTermSeq := int64(-1)
+	if issueStart := es.state.GetIssueStart(); hvhstate.IsIssueStarted(height, issueStart) {
+		curTermSeq, _ = hvhstate.GetTermSequenceAndBlockIndex(height, issueStart, es.state.GetTermPeriod())
+	}
+	fls, err := es.state.GetFundLedgers(fund, fromTerm, toTerm, curTermSeq)
+	if err != nil {
+		return nil, err
+	}
+	ledger := make([]interface{}, len(fls))
+	for i, fl := range fls {
+		ledger[i] = fl.ToJSON(fund)
+	}
+
+	cfl, err := es.state.GetCurrentFundLedger(fund)
+	if err != nil {
+		return nil, err
+	}
+	current := cfl.ToJSON(fund)
+	delete(current, "termSequence")
+	if curTermSeq >= 0 {
+		current["termSequence"] = curTermSeq
+	}
+	current["balance"] = cc.GetBalance(fund.Address())
+
+	es.Logger().Debugf("GetFundLedger() end: height=%d fund=%s ledger=%d", height, fund, len(fls))
+	return map[string]interface{}{
+		"height":  height,
+		"fund":    fund.String(),
+		"ledger":  ledger,
+		"current": current,
+	}, nil
+}
@@ -0,0 +1,120 @@
+package hvh
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/havah/hvh/hvhstate"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+)
+
+func TestExtensionStateImpl_GetFundLedger(t *testing.T) {
+	var err error
+	stateCfg := hvhstate.StateConfig{
+		TermPeriod:  &common.HexInt64{Value: 10},
+		USDTPrice:   new(common.HexInt).SetValue(toHVH(1)),
+		IssueAmount: new(common.HexInt).SetValue(toHVH(1)),
+	}
+	mcc, es := newMockContextAndExtensionState(t, &PlatformConfig{StateConfig: stateCfg})
+	mcc.height = 1
+	mcc.SetRevision(hvhmodule.RevisionFundLedger)
+	cc := NewCallContext(mcc, nil)
+
+	err = es.StartRewardIssue(cc, 10)
+	assert.NoError(t, err)
+	err = es.RegisterPlanet(
+		cc, 1, false, false, common.MustNewAddressFromString("hx1234"), toUSDT(1_000_000), toHVH(1_000_000))
+	assert.NoError(t, err)
+	mcc.SetBalance(hvhmodule.HooverFund, toHVH(100))
+
+	// Term 0 starts
+	err = goToNextTerm(t, es, mcc, nil, 1)
+	assert.NoError(t, err)
+	err = es.ReportPlanetWorks(cc, []int64{1})
+	assert.NoError(t, err)
+	txFee := toHVH(10)
+	mcc.SetBalance(cc.Treasury(), txFee)
+
+	jso, err := es.GetFundLedger(cc, "hoover", 0, 0)
+	assert.NoError(t, err)
+	assert.Zero(t, len(jso["ledger"].([]interface{})))
+	current := jso["current"].(map[string]interface{})
+	subsidy := current["outflow"].(*big.Int)
+	assert.True(t, subsidy.Sign() > 0)
+	assert.Zero(t, new(big.Int).Sub(toHVH(100), subsidy).Cmp(current["balance"].(*big.Int)))
+	assert.Equal(t, int64(0), current["termSequence"])
+
+	// Term 0 ends
+	err = goToNextTerm(t, es, mcc, nil, 0)
+	assert.NoError(t, err)
+
+	jso, err = es.GetFundLedger(cc, "hoover", 0, 10)
+	assert.NoError(t, err)
+	ledger := jso["ledger"].([]interface{})
+	assert.Equal(t, 1, len(ledger))
+	fl := ledger[0].(map[string]interface{})
+	assert.Equal(t, int64(0), fl["termSequence"])
+	assert.Zero(t, subsidy.Cmp(fl["outflow"].(*big.Int)))
+	assert.Zero(t, cc.GetBalance(hvhmodule.HooverFund).Cmp(fl["balance"].(*big.Int)))
+	current = jso["current"].(map[string]interface{})
+	assert.Equal(t, int64(1), current["termSequence"])
+	assert.Zero(t, current["outflow"].(*big.Int).Sign())
+
+	jso, err = es.GetFundLedger(cc, "sustainable", 0, 0)
+	assert.NoError(t, err)
+	fl = jso["ledger"].([]interface{})[0].(map[string]interface{})
+	inflows := fl["inflows"].(map[string]interface{})
+	assert.True(t, inflows["txFee"].(*big.Int).Sign() > 0)
+	assert.True(t, inflows["txFee"].(*big.Int).Cmp(txFee) < 0)
+	assert.Zero(t, cc.GetBalance(hvhmodule.SustainableFund).Cmp(fl["balance"].(*big.Int)))
+
+	_, err = es.GetFundLedger(cc, "unknown", 0, 0)
+	assert.Error(t, err)
+	_, err = es.GetFundLedger(cc, "hoover", 1, 0)
+	assert.Error(t, err)
+	_, err = es.GetFundLedger(cc, "hoover", 2, 2)
+	assert.Error(t, err)
+	jso, err = es.GetFundLedger(cc, "hoover", 0, math.MaxInt64)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(jso["ledger"].([]interface{})))
+}
+
+func TestExtensionStateImpl_FundLedgerAfterIssueLimit(t *testing.T) {
+	stateCfg := hvhstate.StateConfig{
+		TermPeriod:  &common.HexInt64{Value: 10},
+		IssueLimit:  &common.HexInt64{Value: 1},
+		USDTPrice:   new(common.HexInt).SetValue(toHVH(1)),
+		IssueAmount: new(common.HexInt).SetValue(toHVH(1)),
+	}
+	mcc, es := newMockContextAndExtensionState(t, &PlatformConfig{StateConfig: stateCfg})
+	mcc.height = 1
+	mcc.SetRevision(hvhmodule.RevisionFundLedger)
+	cc := NewCallContext(mcc, nil)
+
+	err := es.StartRewardIssue(cc, 10)
+	assert.NoError(t, err)
+	mcc.SetBalance(hvhmodule.HooverFund, toHVH(100))
+
+	// Term 0 is the only term with issuance, but ledgers are closed at the end of every term
+	err = goToNextTerm(t, es, mcc, nil, 1)
+	assert.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		err = goToNextTerm(t, es, mcc, nil, 0)
+		assert.NoError(t, err)
+	}
+
+	for _, fund := range []string{"hoover", "sustainable"} {
+		jso, err := es.GetFundLedger(cc, fund, 0, 10)
+		assert.NoError(t, err)
+		ledger := jso["ledger"].([]interface{})
+		assert.Equal(t, 3, len(ledger), "fund=%s", fund)
+		for i, v := range ledger {
+			assert.Equal(t, int64(i), v.(map[string]interface{})["termSequence"])
+		}
+		assert.Equal(t, int64(3), jso["current"].(map[string]interface{})["termSequence"])
+	}
+}
@@ -0,0 +1,122 @@
+package hvhstate
+
+import (
+	"fmt"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+// DoubleSignEvidence is the double sign report which made a validator slashed
+type DoubleSignEvidence struct {
+	dsType       string
+	height       int64 // height where double signing occurred
+	node         *common.Address
+	reportHeight int64 // height where the report was handled
+}
+
+func newDoubleSignEvidenceFromBytes(b []byte) (*DoubleSignEvidence, error) {
+	dse := &DoubleSignEvidence{}
+	if _, err := codec.BC.UnmarshalFromBytes(b, dse); err != nil {
+		return nil, scoreresult.UnknownFailureError.Wrap(
+			err, "Failed to create a DoubleSignEvidence from bytes")
+	}
+	return dse, nil
+}
+
+func (dse *DoubleSignEvidence) Type() string {
+	return dse.dsType
+}
+
+func (dse *DoubleSignEvidence) Height() int64 {
+	return dse.height
+}
+
+func (dse *DoubleSignEvidence) Node() module.Address {
+	return dse.node
+}
+
+func (dse *DoubleSignEvidence) ReportHeight() int64 {
+	return dse.reportHeight
+}
+
+func (dse *DoubleSignEvidence) RLPDecodeSelf(d codec.Decoder) error {
+	return d.DecodeListOf(&dse.dsType, &dse.height, &dse.node, &dse.reportHeight)
+}
+
+func (dse *DoubleSignEvidence) RLPEncodeSelf(e codec.Encoder) error {
+	return e.EncodeListOf(dse.dsType, dse.height, dse.node, dse.reportHeight)
+}
+
+func (dse *DoubleSignEvidence) Bytes() []byte {
+	return codec.BC.MustMarshalToBytes(dse)
+}
+
+func (dse *DoubleSignEvidence) ToJSON() map[string]interface{} {
+	return map[string]interface{}{
+		"type":         dse.dsType,
+		"height":       dse.height,
+		"node":         dse.node,
+		"reportHeight": dse.reportHeight,
+	}
+}
+
+func (dse *DoubleSignEvidence) String() string {
+	return fmt.Sprintf(
+		"DoubleSignEvidence(type=%s,height=%d,node=%s,reportHeight=%d)",
+		dse.dsType, dse.height, dse.node, dse.reportHeight)
+}
+
+// OnDoubleSignReport marks the validator which owns a given signer node as slashed
+// and keeps the report as its evidence.
+// It returns the owner of the validator and whether the validator has been slashed by this report.
+// The reports for the validator which has been already slashed are ignored
+func (s *State) OnDoubleSignReport(
+	dsType string, height int64, signer module.Address, reportHeight int64) (module.Address, bool, error) {
+	s.logger.Debugf(
+		"OnDoubleSignReport() start: type=%s height=%d signer=%s reportHeight=%d",
+		dsType, height, signer, reportHeight)
+
+	owner, err := s.GetOwnerByNode(signer)
+	if err != nil {
+		return nil, false, err
+	}
+	vsDB := s.getDictDB(hvhmodule.DictValidatorStatus, 1)
+	vs, err := s.getValidatorStatus(vsDB, owner)
+	if err != nil {
+		return nil, false, err
+	}
+	if vs.Slashed() || vs.Disqualified() {
+		s.logger.Debugf("OnDoubleSignReport() end: owner=%s slashed=false", owner)
+		return owner, false, nil
+	}
+
+	vs.SetSlashed()
+	if err = vsDB.Set(ToKey(owner), vs.Bytes()); err != nil {
+		return nil, false, err
+	}
+	dse := &DoubleSignEvidence{
+		dsType:       dsType,
+		height:       height,
+		node:         common.AddressToPtr(signer),
+		reportHeight: reportHeight,
+	}
+	if err = s.getDictDB(hvhmodule.DictDoubleSignEvidence, 1).Set(ToKey(owner), dse.Bytes()); err != nil {
+		return nil, false, err
+	}
+
+	s.logger.Debugf("OnDoubleSignReport() end: owner=%s slashed=true %s", owner, dse)
+	return owner, true, nil
+}
+
+// GetDoubleSignEvidence returns nil if a given validator has never been slashed
+func (s *State) GetDoubleSignEvidence(owner module.Address) (*DoubleSignEvidence, error) {
+	v := s.getDictDB(hvhmodule.DictDoubleSignEvidence, 1).Get(ToKey(owner))
+	if v == nil {
+		return nil, nil
+	}
+	return newDoubleSignEvidenceFromBytes(v.Bytes())
+}
@@ -0,0 +1,55 @@
+package hvhstate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/module"
+)
+
+func TestState_OnDoubleSignReport(t *testing.T) {
+	s := newDummyState()
+	owner := newDummyAddress(1, false)
+	_, pubKey := crypto.GenerateKeyPair()
+	err := s.RegisterValidator(owner, pubKey.SerializeCompressed(), GradeMain, "name-01", nil)
+	assert.NoError(t, err)
+	node := common.NewAccountAddressFromPublicKey(pubKey)
+
+	dse, err := s.GetDoubleSignEvidence(owner)
+	assert.NoError(t, err)
+	assert.Nil(t, dse)
+
+	// Unknown signer
+	_, _, err = s.OnDoubleSignReport(module.DSTVote, 10, newDummyAddress(2, false), 20)
+	assert.Error(t, err)
+
+	ret, slashed, err := s.OnDoubleSignReport(module.DSTVote, 10, node, 20)
+	assert.NoError(t, err)
+	assert.True(t, slashed)
+	assert.True(t, owner.Equal(ret))
+
+	vs, err := s.GetValidatorStatus(owner)
+	assert.NoError(t, err)
+	assert.True(t, vs.Slashed())
+
+	dse, err = s.GetDoubleSignEvidence(owner)
+	assert.NoError(t, err)
+	assert.Equal(t, module.DSTVote, dse.Type())
+	assert.Equal(t, int64(10), dse.Height())
+	assert.True(t, node.Equal(dse.Node()))
+	assert.Equal(t, int64(20), dse.ReportHeight())
+
+	// The first evidence is kept for the validator which has been already slashed
+	ret, slashed, err = s.OnDoubleSignReport(module.DSTProposal, 15, node, 30)
+	assert.NoError(t, err)
+	assert.False(t, slashed)
+	assert.True(t, owner.Equal(ret))
+
+	dse, err = s.GetDoubleSignEvidence(owner)
+	assert.NoError(t, err)
+	assert.Equal(t, module.DSTVote, dse.Type())
+	assert.Equal(t, int64(20), dse.ReportHeight())
+}
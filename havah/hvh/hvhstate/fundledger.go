@@ -0,0 +1,334 @@
+package hvhstate
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+// Fund is a system account whose inflows and outflows are recorded term by term
+type Fund int
+
+const (
+	FundHoover Fund = iota
+	FundSustainable
+)
+
+func (f Fund) String() string {
+	switch f {
+	case FundHoover:
+		return "hoover"
+	case FundSustainable:
+		return "sustainable"
+	default:
+		return "unknown"
+	}
+}
+
+// Address returns the account of a fund
+func (f Fund) Address() module.Address {
+	switch f {
+	case FundHoover:
+		return hvhmodule.HooverFund
+	case FundSustainable:
+		return hvhmodule.SustainableFund
+	default:
+		return nil
+	}
+}
+
+func ParseFund(name string) (Fund, error) {
+	switch name {
+	case FundHoover.String():
+		return FundHoover, nil
+	case FundSustainable.String():
+		return FundSustainable, nil
+	default:
+		return 0, scoreresult.InvalidParameterError.Errorf("InvalidArgument(fund=%s)", name)
+	}
+}
+
+// FundFlow is a kind of transfers from or to funds made by the platform
+type FundFlow int
+
+const (
+	// FundFlowTxFee is the share of tx fees which goes to SustainableFund
+	FundFlowTxFee FundFlow = iota
+	// FundFlowServiceFee is the share of service fees which goes to SustainableFund
+	FundFlowServiceFee
+	// FundFlowMissedReward is the planet and validator rewards which nobody got in a term
+	FundFlowMissedReward
+	// FundFlowHooverRefill is the amount which SustainableFund refills HooverFund with
+	FundFlowHooverRefill
+	// FundFlowHooverSubsidy is the amount which HooverFund adds to planet rewards
+	FundFlowHooverSubsidy
+	fundFlowCount
+)
+
+func (ff FundFlow) String() string {
+	switch ff {
+	case FundFlowTxFee:
+		return "txFee"
+	case FundFlowServiceFee:
+		return "serviceFee"
+	case FundFlowMissedReward:
+		return "missedReward"
+	case FundFlowHooverRefill:
+		return "hooverRefill"
+	case FundFlowHooverSubsidy:
+		return "hooverSubsidy"
+	default:
+		return "unknown"
+	}
+}
+
+type fundFlows struct {
+	inflows  []FundFlow
+	outflows []FundFlow
+}
+
+var flowsOfFund = map[Fund]fundFlows{
+	FundHoover: {
+		inflows:  []FundFlow{FundFlowHooverRefill},
+		outflows: []FundFlow{FundFlowHooverSubsidy},
+	},
+	FundSustainable: {
+		inflows:  []FundFlow{FundFlowTxFee, FundFlowServiceFee, FundFlowMissedReward},
+		outflows: []FundFlow{FundFlowHooverRefill},
+	},
+}
+
+func (f Fund) flows() fundFlows {
+	return flowsOfFund[f]
+}
+
+func (f Fund) hasFlow(flow FundFlow) bool {
+	ff := f.flows()
+	for _, flows := range [][]FundFlow{ff.inflows, ff.outflows} {
+		for _, v := range flows {
+			if v == flow {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// FundLedger is what flowed into and out of a fund in a term.
+// balance is the balance of the fund at the end of the term and nil while the term is in progress
+type FundLedger struct {
+	termSeq int64
+	amounts []*big.Int // indexed by FundFlow
+	balance *big.Int
+}
+
+func newFundLedger() *FundLedger {
+	fl := &FundLedger{
+		amounts: make([]*big.Int, fundFlowCount),
+	}
+	for i := range fl.amounts {
+		fl.amounts[i] = new(big.Int)
+	}
+	return fl
+}
+
+func newFundLedgerFromBytes(b []byte) (*FundLedger, error) {
+	fl := newFundLedger()
+	if len(b) > 0 {
+		if _, err := codec.BC.UnmarshalFromBytes(b, fl); err != nil {
+			return nil, scoreresult.UnknownFailureError.Wrap(err, "Failed to create a FundLedger from bytes")
+		}
+		// Flows added in later versions are zero
+		for len(fl.amounts) < int(fundFlowCount) {
+			fl.amounts = append(fl.amounts, new(big.Int))
+		}
+	}
+	return fl, nil
+}
+
+func (fl *FundLedger) TermSequence() int64 {
+	return fl.termSeq
+}
+
+func (fl *FundLedger) Amount(flow FundFlow) *big.Int {
+	return fl.amounts[flow]
+}
+
+func (fl *FundLedger) Balance() *big.Int {
+	return fl.balance
+}
+
+func (fl *FundLedger) add(flow FundFlow, amount *big.Int) {
+	fl.amounts[flow] = new(big.Int).Add(fl.amounts[flow], amount)
+}
+
+func (fl *FundLedger) sum(flows []FundFlow) *big.Int {
+	sum := new(big.Int)
+	for _, flow := range flows {
+		sum.Add(sum, fl.amounts[flow])
+	}
+	return sum
+}
+
+func (fl *FundLedger) Inflow(fund Fund) *big.Int {
+	return fl.sum(fund.flows().inflows)
+}
+
+func (fl *FundLedger) Outflow(fund Fund) *big.Int {
+	return fl.sum(fund.flows().outflows)
+}
+
+func (fl *FundLedger) RLPDecodeSelf(d codec.Decoder) error {
+	return d.DecodeListOf(&fl.termSeq, &fl.amounts, &fl.balance)
+}
+
+func (fl *FundLedger) RLPEncodeSelf(e codec.Encoder) error {
+	return e.EncodeListOf(fl.termSeq, fl.amounts, fl.balance)
+}
+
+func (fl *FundLedger) Bytes() []byte {
+	return codec.BC.MustMarshalToBytes(fl)
+}
+
+// ToJSON returns the ledger with the flows which belong to a given fund
+func (fl *FundLedger) ToJSON(fund Fund) map[string]interface{} {
+	ff := fund.flows()
+	inflows := make(map[string]interface{})
+	for _, flow := range ff.inflows {
+		inflows[flow.String()] = fl.amounts[flow]
+	}
+	outflows := make(map[string]interface{})
+	for _, flow := range ff.outflows {
+		outflows[flow.String()] = fl.amounts[flow]
+	}
+	jso := map[string]interface{}{
+		"termSequence": fl.termSeq,
+		"inflow":       fl.Inflow(fund),
+		"outflow":      fl.Outflow(fund),
+		"inflows":      inflows,
+		"outflows":     outflows,
+	}
+	if fl.balance != nil {
+		jso["balance"] = fl.balance
+	}
+	return jso
+}
+
+func (fl *FundLedger) String() string {
+	return fmt.Sprintf("FundLedger(termSeq=%d,amounts=%v,balance=%v)", fl.termSeq, fl.amounts, fl.balance)
+}
+
+// AddFundFlow records a flow into the current ledgers of the funds which it belongs to.
+// Flows made at the end of a term belong to the term which is ending
+func (s *State) AddFundFlow(flow FundFlow, amount *big.Int) error {
+	if flow < 0 || flow >= fundFlowCount {
+		return scoreresult.InvalidParameterError.Errorf("InvalidArgument(flow=%d)", flow)
+	}
+	if amount == nil || amount.Sign() < 0 {
+		return scoreresult.InvalidParameterError.Errorf("InvalidArgument(amount=%v)", amount)
+	}
+	if amount.Sign() == 0 {
+		return nil
+	}
+	s.logger.Debugf("AddFundFlow(): flow=%s amount=%d", flow, amount)
+
+	currentDB := s.getDictDB(hvhmodule.DictFundLedgerCurrent, 1)
+	for _, fund := range []Fund{FundHoover, FundSustainable} {
+		if !fund.hasFlow(flow) {
+			continue
+		}
+		fl, err := s.GetCurrentFundLedger(fund)
+		if err != nil {
+			return err
+		}
+		fl.add(flow, amount)
+		if err = currentDB.Set(int64(fund), fl.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CloseFundLedger moves the ledger of the current term of a fund into its history with its balance
+// and prunes the old history which is out of FundLedgerPeriod
+func (s *State) CloseFundLedger(fund Fund, termSeq int64, balance *big.Int) error {
+	s.logger.Debugf("CloseFundLedger() start: fund=%s termSeq=%d balance=%d", fund, termSeq, balance)
+
+	if termSeq < 0 {
+		return scoreresult.Errorf(hvhmodule.StatusIllegalArgument, "Invalid termSeq: %d", termSeq)
+	}
+	fl, err := s.GetCurrentFundLedger(fund)
+	if err != nil {
+		return err
+	}
+	fl.termSeq = termSeq
+	fl.balance = balance
+
+	historyDB := s.getDictDB(hvhmodule.DictFundLedger, 2)
+	if err = historyDB.Set(int64(fund), termSeq, fl.Bytes()); err != nil {
+		return err
+	}
+	if old := termSeq - hvhmodule.FundLedgerPeriod; old >= 0 {
+		if err = historyDB.Delete(int64(fund), old); err != nil {
+			return err
+		}
+	}
+	if err = s.getDictDB(hvhmodule.DictFundLedgerCurrent, 1).Delete(int64(fund)); err != nil {
+		return err
+	}
+
+	s.logger.Debugf("CloseFundLedger() end: %s", fl)
+	return nil
+}
+
+// GetFundLedgers returns the ledgers of a fund between from and to term sequences inclusive.
+// to is clamped to curTermSeq and the range is limited to the last FundLedgerPeriod terms.
+// Terms without a ledger are skipped
+func (s *State) GetFundLedgers(fund Fund, from, to, curTermSeq int64) ([]*FundLedger, error) {
+	if from < 0 || from > to || from > curTermSeq {
+		return nil, scoreresult.Errorf(
+			hvhmodule.StatusIllegalArgument,
+			"Invalid range: from=%d to=%d curTermSeq=%d", from, to, curTermSeq)
+	}
+	if to > curTermSeq {
+		to = curTermSeq
+	}
+	if to-from >= hvhmodule.FundLedgerPeriod {
+		from = to - hvhmodule.FundLedgerPeriod + 1
+	}
+
+	historyDB := s.getDictDB(hvhmodule.DictFundLedger, 2)
+	count := to - from + 1
+	fls := make([]*FundLedger, 0, count)
+	for i := int64(0); i < count; i++ {
+		ts := from + i
+		value := historyDB.Get(int64(fund), ts)
+		if value == nil {
+			continue
+		}
+		fl, err := newFundLedgerFromBytes(value.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		if fl.termSeq != ts {
+			return nil, errors.InvalidStateError.Errorf(
+				"InvalidFundLedger(fund=%s,termSeq=%d,real=%d)", fund, ts, fl.termSeq)
+		}
+		fls = append(fls, fl)
+	}
+	return fls, nil
+}
+
+// GetCurrentFundLedger returns the flows of a fund since the last term end, which have neither termSeq nor balance yet
+func (s *State) GetCurrentFundLedger(fund Fund) (*FundLedger, error) {
+	var b []byte
+	if value := s.getDictDB(hvhmodule.DictFundLedgerCurrent, 1).Get(int64(fund)); value != nil {
+		b = value.Bytes()
+	}
+	return newFundLedgerFromBytes(b)
+}
@@ -0,0 +1,94 @@
+package hvhstate
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/havah/hvhmodule"
+)
+
+func TestFundLedger_RLPEncodeSelf(t *testing.T) {
+	fl := newFundLedger()
+	fl.termSeq = 10
+	fl.add(FundFlowTxFee, big.NewInt(100))
+	fl.add(FundFlowHooverRefill, big.NewInt(30))
+	fl.balance = big.NewInt(1000)
+
+	fl2, err := newFundLedgerFromBytes(fl.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, fl.Bytes(), fl2.Bytes())
+	assert.Equal(t, int64(10), fl2.TermSequence())
+	assert.Zero(t, fl2.Inflow(FundSustainable).Cmp(big.NewInt(100)))
+	assert.Zero(t, fl2.Outflow(FundSustainable).Cmp(big.NewInt(30)))
+	assert.Zero(t, fl2.Inflow(FundHoover).Cmp(big.NewInt(30)))
+	assert.Zero(t, fl2.Outflow(FundHoover).Sign())
+	assert.Zero(t, fl2.Balance().Cmp(big.NewInt(1000)))
+}
+
+func TestState_FundLedger(t *testing.T) {
+	s := newDummyState()
+	period := int64(hvhmodule.FundLedgerPeriod)
+
+	err := s.AddFundFlow(fundFlowCount, big.NewInt(1))
+	assert.Error(t, err)
+	err = s.AddFundFlow(FundFlowTxFee, big.NewInt(-1))
+	assert.Error(t, err)
+
+	for ts := int64(0); ts < period+2; ts++ {
+		err = s.AddFundFlow(FundFlowTxFee, big.NewInt(ts+1))
+		assert.NoError(t, err)
+		err = s.AddFundFlow(FundFlowHooverSubsidy, big.NewInt(1))
+		assert.NoError(t, err)
+
+		fl, err := s.GetCurrentFundLedger(FundSustainable)
+		assert.NoError(t, err)
+		assert.Zero(t, fl.Inflow(FundSustainable).Cmp(big.NewInt(ts+1)))
+		assert.Nil(t, fl.Balance())
+
+		for _, fund := range []Fund{FundHoover, FundSustainable} {
+			err = s.CloseFundLedger(fund, ts, big.NewInt(ts*10))
+			assert.NoError(t, err)
+		}
+	}
+
+	// Ledgers out of FundLedgerPeriod were pruned
+	curTermSeq := period + 2
+	fls, err := s.GetFundLedgers(FundSustainable, 0, period+1, curTermSeq)
+	assert.NoError(t, err)
+	assert.Equal(t, int(period), len(fls))
+	assert.Equal(t, int64(2), fls[0].TermSequence())
+	for _, fl := range fls {
+		ts := fl.TermSequence()
+		assert.Zero(t, fl.Inflow(FundSustainable).Cmp(big.NewInt(ts+1)))
+		assert.Zero(t, fl.Outflow(FundSustainable).Sign())
+		assert.Zero(t, fl.Balance().Cmp(big.NewInt(ts*10)))
+	}
+
+	fls, err = s.GetFundLedgers(FundHoover, period, period+10, curTermSeq)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(fls))
+
+	// to is clamped to the current term
+	fls, err = s.GetFundLedgers(FundHoover, period, math.MaxInt64, curTermSeq)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(fls))
+	fls, err = s.GetFundLedgers(FundHoover, 0, math.MaxInt64, curTermSeq)
+	assert.NoError(t, err)
+	assert.Equal(t, int(period)-1, len(fls))
+	assert.Equal(t, int64(3), fls[0].TermSequence())
+	assert.Zero(t, fls[1].Outflow(FundHoover).Cmp(big.NewInt(1)))
+
+	fl, err := s.GetCurrentFundLedger(FundHoover)
+	assert.NoError(t, err)
+	assert.Zero(t, fl.Outflow(FundHoover).Sign())
+
+	_, err = s.GetFundLedgers(FundHoover, 3, 2, curTermSeq)
+	assert.Error(t, err)
+	_, err = s.GetFundLedgers(FundHoover, curTermSeq+1, math.MaxInt64, curTermSeq)
+	assert.Error(t, err)
+	_, err = ParseFund("public")
+	assert.Error(t, err)
+}
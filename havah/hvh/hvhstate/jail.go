@@ -0,0 +1,119 @@
+package hvhstate
+
+import (
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+func (s *State) SetJailCooldown(cooldown int64) error {
+	if cooldown < 1 {
+		return scoreresult.InvalidParameterError.Errorf("InvalidArgument(cooldown=%d)", cooldown)
+	}
+	return s.setInt64(hvhmodule.VarJailCooldown, cooldown)
+}
+
+func (s *State) GetJailCooldown() int64 {
+	return s.getInt64OrDefault(hvhmodule.VarJailCooldown, hvhmodule.JailCooldown)
+}
+
+// JailValidator puts a penalized validator into jail until a given height
+func (s *State) JailValidator(owner module.Address, until int64) error {
+	s.logger.Debugf("JailValidator() start: owner=%s until=%d", owner, until)
+
+	vsDB := s.getDictDB(hvhmodule.DictValidatorStatus, 1)
+	vs, err := s.getValidatorStatus(vsDB, owner)
+	if err != nil {
+		return err
+	}
+	if vs.Disqualified() || vs.Slashed() {
+		return scoreresult.AccessDeniedError.Errorf("NotJailable(owner=%s)", owner)
+	}
+
+	key := ToKey(owner)
+	if !vs.Jailed() {
+		if err = s.getArrayDB(hvhmodule.ArrayJailedValidators).Put(owner); err != nil {
+			return err
+		}
+	}
+	vs.SetJailed()
+	if err = vsDB.Set(key, vs.Bytes()); err != nil {
+		return err
+	}
+	err = s.getDictDB(hvhmodule.DictJailedUntil, 1).Set(key, until)
+
+	s.logger.Debugf("JailValidator() end: owner=%s", owner)
+	return err
+}
+
+// GetJailedUntil returns 0 if a given validator is not jailed
+func (s *State) GetJailedUntil(owner module.Address) int64 {
+	if v := s.getDictDB(hvhmodule.DictJailedUntil, 1).Get(ToKey(owner)); v != nil {
+		return v.Int64()
+	}
+	return 0
+}
+
+func (s *State) GetJailedValidators() []module.Address {
+	arrayDB := s.getArrayDB(hvhmodule.ArrayJailedValidators)
+	size := arrayDB.Size()
+	owners := make([]module.Address, size)
+	for i := 0; i < size; i++ {
+		owners[i] = arrayDB.Get(i).Address()
+	}
+	return owners
+}
+
+// ReleaseJailedValidators releases the validators whose jail terms have expired at a given height
+// and returns their owners
+func (s *State) ReleaseJailedValidators(height int64) ([]module.Address, error) {
+	arrayDB := s.getArrayDB(hvhmodule.ArrayJailedValidators)
+	size := arrayDB.Size()
+	if size == 0 {
+		return nil, nil
+	}
+	s.logger.Debugf("ReleaseJailedValidators() start: height=%d jailed=%d", height, size)
+
+	vsDB := s.getDictDB(hvhmodule.DictValidatorStatus, 1)
+	var released []module.Address
+	for i := size - 1; i >= 0; i-- {
+		owner := arrayDB.Get(i).Address()
+		if s.GetJailedUntil(owner) > height {
+			continue
+		}
+
+		vs, err := s.getValidatorStatus(vsDB, owner)
+		if err != nil {
+			return nil, err
+		}
+		vs.Release()
+		if err = vsDB.Set(ToKey(owner), vs.Bytes()); err != nil {
+			return nil, err
+		}
+		if err = s.removeJailedValidator(owner); err != nil {
+			return nil, err
+		}
+		released = append(released, owner)
+	}
+
+	s.logger.Debugf("ReleaseJailedValidators() end: released=%v", released)
+	return released, nil
+}
+
+// removeJailedValidator removes the jail record of a validator without changing its status
+func (s *State) removeJailedValidator(owner module.Address) error {
+	arrayDB := s.getArrayDB(hvhmodule.ArrayJailedValidators)
+	size := arrayDB.Size()
+	for i := 0; i < size; i++ {
+		if owner.Equal(arrayDB.Get(i).Address()) {
+			last := arrayDB.Pop().Address()
+			if i < size-1 {
+				if err := arrayDB.Set(i, last); err != nil {
+					return err
+				}
+			}
+			return s.getDictDB(hvhmodule.DictJailedUntil, 1).Delete(ToKey(owner))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package hvhstate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/havah/hvhmodule"
+)
+
+func TestState_SetJailCooldown(t *testing.T) {
+	s := newDummyState()
+	assert.Equal(t, hvhmodule.JailCooldown, s.GetJailCooldown())
+
+	for _, cooldown := range []int64{-1, 0} {
+		err := s.SetJailCooldown(cooldown)
+		assert.Error(t, err)
+	}
+	err := s.SetJailCooldown(100)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), s.GetJailCooldown())
+}
+
+func TestState_ReleaseJailedValidators(t *testing.T) {
+	var err error
+	s := newDummyState()
+	owners := registerDummyValidators(t, s, 3)
+
+	untils := []int64{100, 200, 300}
+	for i, owner := range owners {
+		err = s.JailValidator(owner, untils[i])
+		assert.NoError(t, err)
+		assert.Equal(t, untils[i], s.GetJailedUntil(owner))
+	}
+	assert.Equal(t, owners, s.GetJailedValidators())
+
+	released, err := s.ReleaseJailedValidators(99)
+	assert.NoError(t, err)
+	assert.Zero(t, len(released))
+
+	released, err = s.ReleaseJailedValidators(200)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(released))
+	for _, owner := range owners[:2] {
+		vs, err := s.GetValidatorStatus(owner)
+		assert.NoError(t, err)
+		assert.True(t, vs.Enabled())
+		assert.Zero(t, s.GetJailedUntil(owner))
+	}
+	assert.Equal(t, owners[2:], s.GetJailedValidators())
+
+	// Governance can release a jailed validator at any time
+	err = s.EnableValidator(owners[2], false)
+	assert.Error(t, err)
+	err = s.EnableValidator(owners[2], true)
+	assert.NoError(t, err)
+	assert.Zero(t, len(s.GetJailedValidators()))
+	assert.Zero(t, s.GetJailedUntil(owners[2]))
+
+	// Unregistered validators are removed from the jailed validator list
+	err = s.JailValidator(owners[0], 400)
+	assert.NoError(t, err)
+	_, err = s.UnregisterValidator(owners[0])
+	assert.NoError(t, err)
+	assert.Zero(t, len(s.GetJailedValidators()))
+
+	err = s.JailValidator(owners[0], 500)
+	assert.Error(t, err)
+}
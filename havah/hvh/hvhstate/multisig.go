@@ -0,0 +1,537 @@
+package hvhstate
+
+import (
+	"fmt"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+// Multisig is an account which acts only when threshold of its signers approve an operation
+type Multisig struct {
+	signers   []*common.Address
+	threshold int64
+}
+
+func NewMultisig(signers []module.Address, threshold int64) (*Multisig, error) {
+	size := len(signers)
+	if size < 1 || size > hvhmodule.MaxMultisigSigners {
+		return nil, scoreresult.InvalidParameterError.Errorf("InvalidArgument(signers=%d)", size)
+	}
+	if threshold < 1 || threshold > int64(size) {
+		return nil, scoreresult.InvalidParameterError.Errorf("InvalidArgument(threshold=%d)", threshold)
+	}
+	ms := &Multisig{
+		signers:   make([]*common.Address, size),
+		threshold: threshold,
+	}
+	for i, signer := range signers {
+		if signer == nil || signer.IsContract() {
+			return nil, scoreresult.InvalidParameterError.Errorf("InvalidArgument(signer=%s)", signer)
+		}
+		if ms.IsSigner(signer) {
+			return nil, scoreresult.Errorf(hvhmodule.StatusDuplicate, "DuplicateSigner(%s)", signer)
+		}
+		ms.signers[i] = common.AddressToPtr(signer)
+	}
+	return ms, nil
+}
+
+func newMultisigFromBytes(b []byte) (*Multisig, error) {
+	ms := &Multisig{}
+	if _, err := codec.BC.UnmarshalFromBytes(b, ms); err != nil {
+		return nil, scoreresult.UnknownFailureError.Wrap(
+			err, "Failed to create a Multisig from bytes")
+	}
+	return ms, nil
+}
+
+func (ms *Multisig) Signers() []module.Address {
+	signers := make([]module.Address, len(ms.signers))
+	for i, signer := range ms.signers {
+		signers[i] = signer
+	}
+	return signers
+}
+
+func (ms *Multisig) Threshold() int64 {
+	return ms.threshold
+}
+
+func (ms *Multisig) IsSigner(address module.Address) bool {
+	for _, signer := range ms.signers {
+		if signer != nil && signer.Equal(address) {
+			return true
+		}
+	}
+	return false
+}
+
+// countApprovals returns how many of given approvers are current signers
+func (ms *Multisig) countApprovals(approvers []*common.Address) int64 {
+	count := int64(0)
+	for _, approver := range approvers {
+		if ms.IsSigner(approver) {
+			count++
+		}
+	}
+	return count
+}
+
+func (ms *Multisig) RLPDecodeSelf(d codec.Decoder) error {
+	return d.DecodeListOf(&ms.signers, &ms.threshold)
+}
+
+func (ms *Multisig) RLPEncodeSelf(e codec.Encoder) error {
+	return e.EncodeListOf(ms.signers, ms.threshold)
+}
+
+func (ms *Multisig) Bytes() []byte {
+	return codec.BC.MustMarshalToBytes(ms)
+}
+
+func (ms *Multisig) ToJSON() map[string]interface{} {
+	return map[string]interface{}{
+		"signers":   ms.signers,
+		"threshold": ms.threshold,
+	}
+}
+
+func (ms *Multisig) String() string {
+	return fmt.Sprintf("Multisig(signers=%v,threshold=%d)", ms.signers, ms.threshold)
+}
+
+type MultisigOperationStatus int
+
+const (
+	MultisigOperationPending MultisigOperationStatus = iota
+	MultisigOperationExecuted
+	MultisigOperationFailed
+	MultisigOperationCanceled
+	MultisigOperationExecuting
+)
+
+func (s MultisigOperationStatus) String() string {
+	switch s {
+	case MultisigOperationPending:
+		return "pending"
+	case MultisigOperationExecuted:
+		return "executed"
+	case MultisigOperationFailed:
+		return "failed"
+	case MultisigOperationCanceled:
+		return "canceled"
+	case MultisigOperationExecuting:
+		return "executing"
+	default:
+		return "unknown"
+	}
+}
+
+// MultisigOperation is a call which a multisig account makes after enough signers approve it
+type MultisigOperation struct {
+	id        int64
+	multisig  *common.Address
+	to        *common.Address
+	method    string
+	params    []byte // parameters in JSON
+	submitter *common.Address
+	approvals []*common.Address
+	status    MultisigOperationStatus
+	height    int64 // the height where the operation is submitted
+}
+
+func newMultisigOperationFromBytes(b []byte) (*MultisigOperation, error) {
+	op := &MultisigOperation{}
+	if _, err := codec.BC.UnmarshalFromBytes(b, op); err != nil {
+		return nil, scoreresult.UnknownFailureError.Wrap(
+			err, "Failed to create a MultisigOperation from bytes")
+	}
+	return op, nil
+}
+
+func (op *MultisigOperation) ID() int64 {
+	return op.id
+}
+
+func (op *MultisigOperation) Multisig() module.Address {
+	return op.multisig
+}
+
+func (op *MultisigOperation) To() module.Address {
+	return op.to
+}
+
+func (op *MultisigOperation) Method() string {
+	return op.method
+}
+
+func (op *MultisigOperation) Params() []byte {
+	return op.params
+}
+
+func (op *MultisigOperation) Status() MultisigOperationStatus {
+	return op.status
+}
+
+// ExpireHeight returns the last height where the operation can be approved and executed
+func (op *MultisigOperation) ExpireHeight() int64 {
+	return op.height + hvhmodule.MultisigOperationLifetime
+}
+
+func (op *MultisigOperation) IsExpired(height int64) bool {
+	return height > op.ExpireHeight()
+}
+
+func (op *MultisigOperation) isApprovedBy(address module.Address) bool {
+	for _, approver := range op.approvals {
+		if approver.Equal(address) {
+			return true
+		}
+	}
+	return false
+}
+
+func (op *MultisigOperation) RLPDecodeSelf(d codec.Decoder) error {
+	return d.DecodeListOf(
+		&op.id, &op.multisig, &op.to, &op.method, &op.params,
+		&op.submitter, &op.approvals, &op.status, &op.height)
+}
+
+func (op *MultisigOperation) RLPEncodeSelf(e codec.Encoder) error {
+	return e.EncodeListOf(
+		op.id, op.multisig, op.to, op.method, op.params,
+		op.submitter, op.approvals, op.status, op.height)
+}
+
+func (op *MultisigOperation) Bytes() []byte {
+	return codec.BC.MustMarshalToBytes(op)
+}
+
+func (op *MultisigOperation) ToJSON() map[string]interface{} {
+	jso := map[string]interface{}{
+		"id":           op.id,
+		"multisig":     op.multisig,
+		"to":           op.to,
+		"method":       op.method,
+		"submitter":    op.submitter,
+		"approvals":    op.approvals,
+		"status":       op.status.String(),
+		"startHeight":  op.height,
+		"expireHeight": op.ExpireHeight(),
+	}
+	if len(op.params) > 0 {
+		jso["params"] = string(op.params)
+	}
+	return jso
+}
+
+func (op *MultisigOperation) String() string {
+	return fmt.Sprintf(
+		"MultisigOperation(id=%d,multisig=%s,to=%s,method=%s,params=%s,submitter=%s,approvals=%d,status=%s,height=%d)",
+		op.id, op.multisig, op.to, op.method, op.params, op.submitter,
+		len(op.approvals), op.status, op.height)
+}
+
+// CreateMultisig registers a new multisig account and returns its address.
+// The address is derived from the sequence number of multisig accounts.
+// A creator can make up to MaxMultisigsPerCreator accounts
+func (s *State) CreateMultisig(
+	creator module.Address, signers []module.Address, threshold int64) (module.Address, error) {
+	s.logger.Debugf("CreateMultisig() start: creator=%s signers=%v threshold=%d", creator, signers, threshold)
+
+	ms, err := NewMultisig(signers, threshold)
+	if err != nil {
+		return nil, err
+	}
+	creatorDB := s.getDictDB(hvhmodule.DictMultisigCreatorCount, 1)
+	created := int64(0)
+	if v := creatorDB.Get(ToKey(creator)); v != nil {
+		created = v.Int64()
+	}
+	if created >= hvhmodule.MaxMultisigsPerCreator {
+		return nil, scoreresult.Errorf(
+			hvhmodule.StatusIllegalArgument,
+			"Too many multisig accounts: creator=%s max(%d)", creator, hvhmodule.MaxMultisigsPerCreator)
+	}
+	seq := s.getInt64(hvhmodule.VarMultisigCount) + 1
+	seed := codec.BC.MustMarshalToBytes([]interface{}{hvhmodule.DictMultisig, seq})
+	address := common.NewAccountAddress(crypto.SHA3Sum256(seed)[:common.AddressIDBytes])
+	if ok := s.IsMultisig(address); ok {
+		return nil, scoreresult.Errorf(hvhmodule.StatusDuplicate, "MultisigExists(%s)", address)
+	}
+	if err = s.setInt64(hvhmodule.VarMultisigCount, seq); err != nil {
+		return nil, err
+	}
+	if err = s.setMultisig(address, ms); err != nil {
+		return nil, err
+	}
+	if err = creatorDB.Set(ToKey(creator), created+1); err != nil {
+		return nil, err
+	}
+
+	s.logger.Debugf("CreateMultisig() end: address=%s %s", address, ms)
+	return address, nil
+}
+
+// SetMultisigSigners replaces the signers and the threshold of a multisig account.
+// Approvals of pending operations are counted again with new signers when they are approved next time
+func (s *State) SetMultisigSigners(address module.Address, signers []module.Address, threshold int64) error {
+	s.logger.Debugf(
+		"SetMultisigSigners() start: address=%s signers=%v threshold=%d", address, signers, threshold)
+
+	if _, err := s.GetMultisig(address); err != nil {
+		return err
+	}
+	ms, err := NewMultisig(signers, threshold)
+	if err != nil {
+		return err
+	}
+	if ms.IsSigner(address) {
+		return scoreresult.InvalidParameterError.Errorf("InvalidArgument(signer=%s)", address)
+	}
+	if err = s.setMultisig(address, ms); err != nil {
+		return err
+	}
+
+	s.logger.Debugf("SetMultisigSigners() end: %s", ms)
+	return nil
+}
+
+func (s *State) IsMultisig(address module.Address) bool {
+	if address == nil || address.IsContract() {
+		return false
+	}
+	return s.getDictDB(hvhmodule.DictMultisig, 1).Get(ToKey(address)) != nil
+}
+
+func (s *State) GetMultisig(address module.Address) (*Multisig, error) {
+	if address == nil {
+		return nil, scoreresult.InvalidParameterError.Errorf("InvalidArgument(%s)", address)
+	}
+	v := s.getDictDB(hvhmodule.DictMultisig, 1).Get(ToKey(address))
+	if v == nil {
+		return nil, scoreresult.Errorf(hvhmodule.StatusNotFound, "MultisigNotFound(%s)", address)
+	}
+	return newMultisigFromBytes(v.Bytes())
+}
+
+func (s *State) setMultisig(address module.Address, ms *Multisig) error {
+	return s.getDictDB(hvhmodule.DictMultisig, 1).Set(ToKey(address), ms.Bytes())
+}
+
+// SubmitMultisigOperation registers an operation of a multisig account, which its submitter approves at once.
+// It returns whether the operation has enough approvals to be executed
+func (s *State) SubmitMultisigOperation(
+	address, submitter, to module.Address, method string, params []byte, height int64,
+) (*MultisigOperation, bool, error) {
+	s.logger.Debugf(
+		"SubmitMultisigOperation() start: multisig=%s submitter=%s to=%s method=%s params=%s height=%d",
+		address, submitter, to, method, params, height)
+
+	ms, err := s.GetMultisig(address)
+	if err != nil {
+		return nil, false, err
+	}
+	if !ms.IsSigner(submitter) {
+		return nil, false, scoreresult.AccessDeniedError.Errorf("NoPermission: submitter=%s", submitter)
+	}
+	if to == nil {
+		return nil, false, scoreresult.InvalidParameterError.Errorf("InvalidArgument(to=%s)", to)
+	}
+	if len(method) == 0 || len(method) > hvhmodule.MaxMultisigMethodLen {
+		return nil, false, scoreresult.InvalidParameterError.Errorf("InvalidArgument(method=%s)", method)
+	}
+	if len(params) > hvhmodule.MaxMultisigParamsLen {
+		return nil, false, scoreresult.InvalidParameterError.Errorf("InvalidArgument(params=%d)", len(params))
+	}
+	pendingDB := s.getDictDB(hvhmodule.DictMultisigPendingCount, 1)
+	pending := int64(0)
+	if v := pendingDB.Get(ToKey(address)); v != nil {
+		pending = v.Int64()
+	}
+	if pending >= hvhmodule.MaxPendingMultisigOperations {
+		return nil, false, scoreresult.Errorf(
+			hvhmodule.StatusIllegalArgument,
+			"Too many pending operations: max(%d)", hvhmodule.MaxPendingMultisigOperations)
+	}
+
+	id := s.getInt64(hvhmodule.VarMultisigOperationID) + 1
+	op := &MultisigOperation{
+		id:        id,
+		multisig:  common.AddressToPtr(address),
+		to:        common.AddressToPtr(to),
+		method:    method,
+		params:    params,
+		submitter: common.AddressToPtr(submitter),
+		approvals: []*common.Address{common.AddressToPtr(submitter)},
+		status:    MultisigOperationPending,
+		height:    height,
+	}
+	if err = s.setInt64(hvhmodule.VarMultisigOperationID, id); err != nil {
+		return nil, false, err
+	}
+	if err = s.setMultisigOperation(op); err != nil {
+		return nil, false, err
+	}
+	if err = pendingDB.Set(ToKey(address), pending+1); err != nil {
+		return nil, false, err
+	}
+
+	s.logger.Debugf("SubmitMultisigOperation() end: %s", op)
+	return op, ms.countApprovals(op.approvals) >= ms.threshold, nil
+}
+
+// getLiveMultisigOperation returns a pending operation which is not expired at a given height
+// with its multisig account, which only its signers can act on
+func (s *State) getLiveMultisigOperation(
+	id int64, signer module.Address, height int64) (*MultisigOperation, *Multisig, error) {
+	op, err := s.GetMultisigOperation(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if op.status != MultisigOperationPending {
+		return nil, nil, scoreresult.Errorf(
+			hvhmodule.StatusIllegalArgument, "OperationNotPending(id=%d,status=%s)", id, op.status)
+	}
+	if op.IsExpired(height) {
+		return nil, nil, scoreresult.Errorf(
+			hvhmodule.StatusIllegalArgument, "OperationExpired(id=%d,expireHeight=%d)", id, op.ExpireHeight())
+	}
+	ms, err := s.GetMultisig(op.multisig)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ms.IsSigner(signer) {
+		return nil, nil, scoreresult.AccessDeniedError.Errorf("NoPermission: signer=%s", signer)
+	}
+	return op, ms, nil
+}
+
+// ApproveMultisigOperation records the approval of a signer.
+// It returns whether the operation has enough approvals to be executed
+func (s *State) ApproveMultisigOperation(
+	id int64, signer module.Address, height int64) (*MultisigOperation, bool, error) {
+	s.logger.Debugf("ApproveMultisigOperation() start: id=%d signer=%s height=%d", id, signer, height)
+
+	op, ms, err := s.getLiveMultisigOperation(id, signer, height)
+	if err != nil {
+		return nil, false, err
+	}
+	if op.isApprovedBy(signer) {
+		return nil, false, scoreresult.Errorf(
+			hvhmodule.StatusDuplicate, "AlreadyApproved(id=%d,signer=%s)", id, signer)
+	}
+	op.approvals = append(op.approvals, common.AddressToPtr(signer))
+	if err = s.setMultisigOperation(op); err != nil {
+		return nil, false, err
+	}
+
+	s.logger.Debugf("ApproveMultisigOperation() end: %s", op)
+	return op, ms.countApprovals(op.approvals) >= ms.threshold, nil
+}
+
+// GetExecutableMultisigOperation returns a pending operation which has enough approvals at a given height.
+// It lets a signer execute an operation which gets ready without a new approval,
+// e.g. when the threshold of its multisig account is lowered
+func (s *State) GetExecutableMultisigOperation(
+	id int64, signer module.Address, height int64) (*MultisigOperation, error) {
+	op, ms, err := s.getLiveMultisigOperation(id, signer, height)
+	if err != nil {
+		return nil, err
+	}
+	if approvals := ms.countApprovals(op.approvals); approvals < ms.threshold {
+		return nil, scoreresult.Errorf(
+			hvhmodule.StatusIllegalArgument,
+			"NotEnoughApprovals(id=%d,approvals=%d,threshold=%d)", id, approvals, ms.threshold)
+	}
+	return op, nil
+}
+
+// CancelMultisigOperation cancels a pending operation, which is allowed only to its submitter.
+// Once the operation expires, any signer of its multisig account can cancel it
+func (s *State) CancelMultisigOperation(id int64, from module.Address, height int64) error {
+	op, err := s.GetMultisigOperation(id)
+	if err != nil {
+		return err
+	}
+	if !op.submitter.Equal(from) {
+		if !op.IsExpired(height) {
+			return scoreresult.AccessDeniedError.Errorf("NoPermission: from=%s", from)
+		}
+		ms, err := s.GetMultisig(op.multisig)
+		if err != nil {
+			return err
+		}
+		if !ms.IsSigner(from) {
+			return scoreresult.AccessDeniedError.Errorf("NoPermission: from=%s", from)
+		}
+	}
+	return s.setMultisigOperationStatus(op, MultisigOperationCanceled)
+}
+
+// StartMultisigOperation marks a pending operation as executing,
+// so that it cannot be approved or canceled again while it is executed
+func (s *State) StartMultisigOperation(id int64) error {
+	op, err := s.GetMultisigOperation(id)
+	if err != nil {
+		return err
+	}
+	return s.setMultisigOperationStatus(op, MultisigOperationExecuting)
+}
+
+// CloseMultisigOperation sets the final status of an executing operation
+func (s *State) CloseMultisigOperation(id int64, status MultisigOperationStatus) error {
+	op, err := s.GetMultisigOperation(id)
+	if err != nil {
+		return err
+	}
+	if status != MultisigOperationExecuted && status != MultisigOperationFailed {
+		return scoreresult.InvalidParameterError.Errorf("InvalidArgument(status=%s)", status)
+	}
+	return s.setMultisigOperationStatus(op, status)
+}
+
+func (s *State) setMultisigOperationStatus(op *MultisigOperation, status MultisigOperationStatus) error {
+	var from MultisigOperationStatus
+	switch status {
+	case MultisigOperationCanceled, MultisigOperationExecuting:
+		from = MultisigOperationPending
+	default:
+		from = MultisigOperationExecuting
+	}
+	if op.status != from {
+		return scoreresult.Errorf(
+			hvhmodule.StatusIllegalArgument,
+			"InvalidStatusTransition(id=%d,from=%s,to=%s)", op.id, op.status, status)
+	}
+	op.status = status
+	if err := s.setMultisigOperation(op); err != nil {
+		return err
+	}
+	if from != MultisigOperationPending {
+		return nil
+	}
+	pendingDB := s.getDictDB(hvhmodule.DictMultisigPendingCount, 1)
+	key := ToKey(op.multisig)
+	if pending := pendingDB.Get(key).Int64() - 1; pending > 0 {
+		return pendingDB.Set(key, pending)
+	}
+	return pendingDB.Delete(key)
+}
+
+func (s *State) GetMultisigOperation(id int64) (*MultisigOperation, error) {
+	v := s.getDictDB(hvhmodule.DictMultisigOperation, 1).Get(id)
+	if v == nil {
+		return nil, scoreresult.Errorf(hvhmodule.StatusNotFound, "MultisigOperationNotFound(id=%d)", id)
+	}
+	return newMultisigOperationFromBytes(v.Bytes())
+}
+
+func (s *State) setMultisigOperation(op *MultisigOperation) error {
+	return s.getDictDB(hvhmodule.DictMultisigOperation, 1).Set(op.id, op.Bytes())
+}
@@ -0,0 +1,263 @@
+package hvhstate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/module"
+)
+
+func TestNewMultisig(t *testing.T) {
+	signers := newDummyAddresses(1, false, 3)
+
+	args := []struct {
+		signers   []module.Address
+		threshold int64
+		success   bool
+	}{
+		{signers, 1, true},
+		{signers, 3, true},
+		{signers, 0, false},
+		{signers, 4, false},
+		{nil, 1, false},
+		{newDummyAddresses(1, false, hvhmodule.MaxMultisigSigners+1), 1, false},
+		{newDummyAddresses(1, true, 2), 1, false},
+		{[]module.Address{signers[0], signers[1], signers[0]}, 2, false},
+	}
+	for i, arg := range args {
+		ms, err := NewMultisig(arg.signers, arg.threshold)
+		if arg.success {
+			assert.NoError(t, err, "i=%d", i)
+			assert.Equal(t, arg.threshold, ms.Threshold())
+			assert.Equal(t, len(arg.signers), len(ms.Signers()))
+
+			ms2, err := newMultisigFromBytes(ms.Bytes())
+			assert.NoError(t, err)
+			assert.Equal(t, ms.Bytes(), ms2.Bytes())
+		} else {
+			assert.Error(t, err, "i=%d", i)
+		}
+	}
+}
+
+func TestState_CreateMultisig(t *testing.T) {
+	s := newDummyState()
+	signers := newDummyAddresses(1, false, 3)
+
+	ms1, err := s.CreateMultisig(signers[0], signers, 2)
+	assert.NoError(t, err)
+	assert.False(t, ms1.IsContract())
+	assert.True(t, s.IsMultisig(ms1))
+	assert.False(t, s.IsMultisig(signers[0]))
+
+	// The same signers make a different account
+	ms2, err := s.CreateMultisig(signers[0], signers, 2)
+	assert.NoError(t, err)
+	assert.False(t, ms1.Equal(ms2))
+
+	_, err = s.CreateMultisig(signers[0], signers, 4)
+	assert.Error(t, err)
+
+	ms, err := s.GetMultisig(ms1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), ms.Threshold())
+
+	err = s.SetMultisigSigners(ms1, signers[:1], 1)
+	assert.NoError(t, err)
+	ms, err = s.GetMultisig(ms1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), ms.Threshold())
+	assert.False(t, ms.IsSigner(signers[1]))
+
+	err = s.SetMultisigSigners(signers[0], signers, 1)
+	assert.Error(t, err)
+	// A multisig account cannot be its own signer
+	err = s.SetMultisigSigners(ms1, []module.Address{ms1, signers[0]}, 1)
+	assert.Error(t, err)
+	_, err = s.GetMultisig(signers[0])
+	assert.Error(t, err)
+
+	// A creator can make a limited number of accounts
+	for i := 2; i < hvhmodule.MaxMultisigsPerCreator; i++ {
+		_, err = s.CreateMultisig(signers[0], signers, 2)
+		assert.NoError(t, err)
+	}
+	_, err = s.CreateMultisig(signers[0], signers, 2)
+	assert.Error(t, err)
+	_, err = s.CreateMultisig(signers[1], signers, 2)
+	assert.NoError(t, err)
+}
+
+func TestState_MultisigOperation(t *testing.T) {
+	s := newDummyState()
+	signers := newDummyAddresses(1, false, 3)
+	other := newDummyAddress(10, false)
+	to := newDummyAddress(100, true)
+
+	address, err := s.CreateMultisig(signers[0], signers, 2)
+	assert.NoError(t, err)
+
+	// Only signers can submit
+	_, _, err = s.SubmitMultisigOperation(address, other, to, "method", nil, 10)
+	assert.Error(t, err)
+	_, _, err = s.SubmitMultisigOperation(address, signers[0], to, "", nil, 10)
+	assert.Error(t, err)
+	_, _, err = s.SubmitMultisigOperation(signers[0], signers[0], to, "method", nil, 10)
+	assert.Error(t, err)
+
+	op, ready, err := s.SubmitMultisigOperation(address, signers[0], to, "method", []byte(`{"a":"0x1"}`), 10)
+	assert.NoError(t, err)
+	assert.False(t, ready)
+	assert.Equal(t, int64(1), op.ID())
+	assert.Equal(t, MultisigOperationPending, op.Status())
+
+	_, _, err = s.ApproveMultisigOperation(op.ID(), signers[0], 20)
+	assert.Error(t, err)
+	_, _, err = s.ApproveMultisigOperation(op.ID(), other, 20)
+	assert.Error(t, err)
+	_, _, err = s.ApproveMultisigOperation(op.ID()+1, signers[1], 20)
+	assert.Error(t, err)
+
+	op, ready, err = s.ApproveMultisigOperation(op.ID(), signers[1], 20)
+	assert.NoError(t, err)
+	assert.True(t, ready)
+
+	err = s.CloseMultisigOperation(op.ID(), MultisigOperationExecuted)
+	assert.Error(t, err)
+	err = s.StartMultisigOperation(op.ID())
+	assert.NoError(t, err)
+	_, _, err = s.ApproveMultisigOperation(op.ID(), signers[2], 20)
+	assert.Error(t, err)
+	err = s.CloseMultisigOperation(op.ID(), MultisigOperationCanceled)
+	assert.Error(t, err)
+	err = s.CloseMultisigOperation(op.ID(), MultisigOperationExecuted)
+	assert.NoError(t, err)
+	op, err = s.GetMultisigOperation(op.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, MultisigOperationExecuted, op.Status())
+	jso := op.ToJSON()
+	assert.Equal(t, "executed", jso["status"])
+	assert.Equal(t, `{"a":"0x1"}`, jso["params"])
+
+	// Closed operations cannot be approved or canceled
+	_, _, err = s.ApproveMultisigOperation(op.ID(), signers[2], 20)
+	assert.Error(t, err)
+	err = s.CancelMultisigOperation(op.ID(), signers[0], 20)
+	assert.Error(t, err)
+
+	// Only the submitter can cancel its operation
+	op, _, err = s.SubmitMultisigOperation(address, signers[1], to, "method", nil, 11)
+	assert.NoError(t, err)
+	err = s.CancelMultisigOperation(op.ID(), signers[0], 20)
+	assert.Error(t, err)
+	err = s.CancelMultisigOperation(op.ID(), signers[1], 20)
+	assert.NoError(t, err)
+
+	// Approvals of removed signers are not counted
+	op, _, err = s.SubmitMultisigOperation(address, signers[0], to, "method", nil, 12)
+	assert.NoError(t, err)
+	err = s.SetMultisigSigners(address, signers[1:], 2)
+	assert.NoError(t, err)
+	op, ready, err = s.ApproveMultisigOperation(op.ID(), signers[1], 20)
+	assert.NoError(t, err)
+	assert.False(t, ready)
+	op, ready, err = s.ApproveMultisigOperation(op.ID(), signers[2], 20)
+	assert.NoError(t, err)
+	assert.True(t, ready)
+	err = s.StartMultisigOperation(op.ID())
+	assert.NoError(t, err)
+	err = s.CloseMultisigOperation(op.ID(), MultisigOperationFailed)
+	assert.NoError(t, err)
+}
+
+func TestState_MaxPendingMultisigOperations(t *testing.T) {
+	s := newDummyState()
+	signers := newDummyAddresses(1, false, 2)
+	to := newDummyAddress(100, true)
+
+	address, err := s.CreateMultisig(signers[0], signers, 2)
+	assert.NoError(t, err)
+
+	var op *MultisigOperation
+	for i := 0; i < hvhmodule.MaxPendingMultisigOperations; i++ {
+		op, _, err = s.SubmitMultisigOperation(address, signers[0], to, "method", nil, 10)
+		assert.NoError(t, err)
+	}
+	_, _, err = s.SubmitMultisigOperation(address, signers[1], to, "method", nil, 10)
+	assert.Error(t, err)
+
+	// Another multisig account has its own limit
+	address2, err := s.CreateMultisig(signers[0], signers, 1)
+	assert.NoError(t, err)
+	_, _, err = s.SubmitMultisigOperation(address2, signers[1], to, "method", nil, 10)
+	assert.NoError(t, err)
+
+	err = s.CancelMultisigOperation(op.ID(), signers[0], 20)
+	assert.NoError(t, err)
+	_, _, err = s.SubmitMultisigOperation(address, signers[1], to, "method", nil, 10)
+	assert.NoError(t, err)
+}
+
+func TestState_ExpiredMultisigOperation(t *testing.T) {
+	s := newDummyState()
+	signers := newDummyAddresses(1, false, 3)
+	to := newDummyAddress(100, true)
+
+	address, err := s.CreateMultisig(signers[0], signers, 2)
+	assert.NoError(t, err)
+
+	height := int64(10)
+	op, _, err := s.SubmitMultisigOperation(address, signers[0], to, "method", nil, height)
+	assert.NoError(t, err)
+	expireHeight := height + hvhmodule.MultisigOperationLifetime
+	assert.Equal(t, expireHeight, op.ExpireHeight())
+	assert.Equal(t, expireHeight, op.ToJSON()["expireHeight"])
+
+	// An expired operation can be neither approved nor executed
+	_, _, err = s.ApproveMultisigOperation(op.ID(), signers[1], expireHeight+1)
+	assert.Error(t, err)
+	_, err = s.GetExecutableMultisigOperation(op.ID(), signers[0], expireHeight+1)
+	assert.Error(t, err)
+
+	// Only the submitter can cancel it before it expires
+	err = s.CancelMultisigOperation(op.ID(), signers[1], expireHeight)
+	assert.Error(t, err)
+	// Any signer can cancel it after it expires
+	err = s.CancelMultisigOperation(op.ID(), newDummyAddress(10, false), expireHeight+1)
+	assert.Error(t, err)
+	err = s.CancelMultisigOperation(op.ID(), signers[1], expireHeight+1)
+	assert.NoError(t, err)
+}
+
+func TestState_GetExecutableMultisigOperation(t *testing.T) {
+	s := newDummyState()
+	signers := newDummyAddresses(1, false, 3)
+	to := newDummyAddress(100, true)
+
+	address, err := s.CreateMultisig(signers[0], signers, 3)
+	assert.NoError(t, err)
+
+	op, _, err := s.SubmitMultisigOperation(address, signers[0], to, "method", nil, 10)
+	assert.NoError(t, err)
+	op, ready, err := s.ApproveMultisigOperation(op.ID(), signers[1], 11)
+	assert.NoError(t, err)
+	assert.False(t, ready)
+	_, err = s.GetExecutableMultisigOperation(op.ID(), signers[0], 12)
+	assert.Error(t, err)
+
+	// Lowering the threshold makes the operation ready without a new approval
+	err = s.SetMultisigSigners(address, signers, 2)
+	assert.NoError(t, err)
+	_, _, err = s.ApproveMultisigOperation(op.ID(), signers[1], 13)
+	assert.Error(t, err)
+	_, err = s.GetExecutableMultisigOperation(op.ID(), newDummyAddress(10, false), 13)
+	assert.Error(t, err)
+	op, err = s.GetExecutableMultisigOperation(op.ID(), signers[1], 13)
+	assert.NoError(t, err)
+	err = s.StartMultisigOperation(op.ID())
+	assert.NoError(t, err)
+	_, err = s.GetExecutableMultisigOperation(op.ID(), signers[1], 13)
+	assert.Error(t, err)
+}
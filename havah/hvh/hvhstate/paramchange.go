@@ -0,0 +1,185 @@
+package hvhstate
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+// ParameterChange is a parameter value which governance schedules for a given height
+type ParameterChange struct {
+	id          int64
+	pType       string
+	value       []byte // parameters in JSON
+	height      int64  // the change is applied at the first block whose height is equal to or greater than it
+	scheduledAt int64
+}
+
+func newParameterChangeFromBytes(b []byte) (*ParameterChange, error) {
+	pc := &ParameterChange{}
+	if _, err := codec.BC.UnmarshalFromBytes(b, pc); err != nil {
+		return nil, scoreresult.UnknownFailureError.Wrap(
+			err, "Failed to create a ParameterChange from bytes")
+	}
+	return pc, nil
+}
+
+func (pc *ParameterChange) ID() int64 {
+	return pc.id
+}
+
+func (pc *ParameterChange) Type() string {
+	return pc.pType
+}
+
+func (pc *ParameterChange) Value() []byte {
+	return pc.value
+}
+
+func (pc *ParameterChange) Height() int64 {
+	return pc.height
+}
+
+func (pc *ParameterChange) RLPDecodeSelf(d codec.Decoder) error {
+	return d.DecodeListOf(&pc.id, &pc.pType, &pc.value, &pc.height, &pc.scheduledAt)
+}
+
+func (pc *ParameterChange) RLPEncodeSelf(e codec.Encoder) error {
+	return e.EncodeListOf(pc.id, pc.pType, pc.value, pc.height, pc.scheduledAt)
+}
+
+func (pc *ParameterChange) Bytes() []byte {
+	return codec.BC.MustMarshalToBytes(pc)
+}
+
+func (pc *ParameterChange) ToJSON() map[string]interface{} {
+	return map[string]interface{}{
+		"id":          pc.id,
+		"type":        pc.pType,
+		"value":       string(pc.value),
+		"height":      pc.height,
+		"scheduledAt": pc.scheduledAt,
+	}
+}
+
+func (pc *ParameterChange) String() string {
+	return fmt.Sprintf(
+		"ParameterChange(id=%d,type=%s,value=%s,height=%d,scheduledAt=%d)",
+		pc.id, pc.pType, pc.value, pc.height, pc.scheduledAt)
+}
+
+// ScheduleParameterChange adds a parameter change to be applied at a given height and returns its id
+func (s *State) ScheduleParameterChange(pType string, value []byte, height, curHeight int64) (int64, error) {
+	s.logger.Debugf(
+		"ScheduleParameterChange() start: type=%s value=%s height=%d curHeight=%d",
+		pType, value, height, curHeight)
+
+	if height <= curHeight {
+		return 0, scoreresult.InvalidParameterError.Errorf(
+			"InvalidArgument(height=%d,curHeight=%d)", height, curHeight)
+	}
+	pendingDB := s.getArrayDB(hvhmodule.ArrayPendingChanges)
+	if pendingDB.Size() >= hvhmodule.MaxPendingChanges {
+		return 0, scoreresult.Errorf(
+			hvhmodule.StatusIllegalArgument,
+			"Too many pending changes: max(%d)", hvhmodule.MaxPendingChanges)
+	}
+
+	id := s.getInt64(hvhmodule.VarParameterChangeID) + 1
+	pc := &ParameterChange{
+		id:          id,
+		pType:       pType,
+		value:       value,
+		height:      height,
+		scheduledAt: curHeight,
+	}
+	if err := s.setInt64(hvhmodule.VarParameterChangeID, id); err != nil {
+		return 0, err
+	}
+	if err := s.getDictDB(hvhmodule.DictParameterChange, 1).Set(id, pc.Bytes()); err != nil {
+		return 0, err
+	}
+	if err := pendingDB.Put(id); err != nil {
+		return 0, err
+	}
+
+	s.logger.Debugf("ScheduleParameterChange() end: %s", pc)
+	return id, nil
+}
+
+// CancelParameterChange removes a pending parameter change
+func (s *State) CancelParameterChange(id int64) error {
+	if _, err := s.GetParameterChange(id); err != nil {
+		return err
+	}
+	return s.removeParameterChange(id)
+}
+
+func (s *State) GetParameterChange(id int64) (*ParameterChange, error) {
+	v := s.getDictDB(hvhmodule.DictParameterChange, 1).Get(id)
+	if v == nil {
+		return nil, scoreresult.Errorf(hvhmodule.StatusNotFound, "ParameterChangeNotFound(id=%d)", id)
+	}
+	return newParameterChangeFromBytes(v.Bytes())
+}
+
+// GetPendingChanges returns pending parameter changes in the order to be applied
+func (s *State) GetPendingChanges() ([]*ParameterChange, error) {
+	pendingDB := s.getArrayDB(hvhmodule.ArrayPendingChanges)
+	size := pendingDB.Size()
+	changes := make([]*ParameterChange, size)
+	for i := 0; i < size; i++ {
+		pc, err := s.GetParameterChange(pendingDB.Get(i).Int64())
+		if err != nil {
+			return nil, err
+		}
+		changes[i] = pc
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].height != changes[j].height {
+			return changes[i].height < changes[j].height
+		}
+		return changes[i].id < changes[j].id
+	})
+	return changes, nil
+}
+
+// PopDueChanges removes the parameter changes which are due at a given height
+// and returns them in the order to be applied
+func (s *State) PopDueChanges(height int64) ([]*ParameterChange, error) {
+	changes, err := s.GetPendingChanges()
+	if err != nil {
+		return nil, err
+	}
+	var due []*ParameterChange
+	for _, pc := range changes {
+		if pc.height > height {
+			break
+		}
+		if err = s.removeParameterChange(pc.id); err != nil {
+			return nil, err
+		}
+		due = append(due, pc)
+	}
+	return due, nil
+}
+
+func (s *State) removeParameterChange(id int64) error {
+	pendingDB := s.getArrayDB(hvhmodule.ArrayPendingChanges)
+	size := pendingDB.Size()
+	for i := 0; i < size; i++ {
+		if pendingDB.Get(i).Int64() == id {
+			last := pendingDB.Pop().Int64()
+			if i < size-1 {
+				if err := pendingDB.Set(i, last); err != nil {
+					return err
+				}
+			}
+			return s.getDictDB(hvhmodule.DictParameterChange, 1).Delete(id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package hvhstate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/havah/hvhmodule"
+)
+
+func TestState_ScheduleParameterChange(t *testing.T) {
+	s := newDummyState()
+	value := []byte(`{"count":"0x5"}`)
+
+	_, err := s.ScheduleParameterChange("activeValidatorCount", value, 10, 10)
+	assert.Error(t, err)
+
+	heights := []int64{300, 100, 200, 100}
+	for i, height := range heights {
+		id, err := s.ScheduleParameterChange("activeValidatorCount", value, height, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(i+1), id)
+	}
+
+	changes, err := s.GetPendingChanges()
+	assert.NoError(t, err)
+	ids := []int64{2, 4, 3, 1}
+	assert.Equal(t, len(ids), len(changes))
+	for i, pc := range changes {
+		assert.Equal(t, ids[i], pc.ID())
+	}
+
+	// Cancel
+	err = s.CancelParameterChange(3)
+	assert.NoError(t, err)
+	err = s.CancelParameterChange(3)
+	assert.Error(t, err)
+	_, err = s.GetParameterChange(3)
+	assert.Error(t, err)
+
+	// Nothing is due
+	due, err := s.PopDueChanges(99)
+	assert.NoError(t, err)
+	assert.Zero(t, len(due))
+
+	due, err = s.PopDueChanges(250)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(due))
+	assert.Equal(t, int64(2), due[0].ID())
+	assert.Equal(t, int64(4), due[1].ID())
+
+	changes, err = s.GetPendingChanges()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(changes))
+	assert.Equal(t, int64(1), changes[0].ID())
+	assert.Equal(t, int64(300), changes[0].Height())
+}
+
+func TestState_ScheduleParameterChange_Max(t *testing.T) {
+	s := newDummyState()
+	value := []byte(`{"count":"0x5"}`)
+
+	for i := 0; i < hvhmodule.MaxPendingChanges; i++ {
+		_, err := s.ScheduleParameterChange("activeValidatorCount", value, 100, 10)
+		assert.NoError(t, err)
+	}
+	_, err := s.ScheduleParameterChange("activeValidatorCount", value, 100, 10)
+	assert.Error(t, err)
+}
@@ -18,6 +18,44 @@ const (
 	Private
 )
 
+// Planet classes to which vesting schedules and reward weights can be assigned
+const (
+	PlanetClassPublic  = "public"
+	PlanetClassCompany = "company"
+	PlanetClassPrivate = "private"
+)
+
+// PlanetClassOf returns the class of a planet. A private planet belongs to private class even if it is a company one
+func PlanetClassOf(p *Planet) string {
+	if p.IsPrivate() {
+		return PlanetClassPrivate
+	}
+	if p.IsCompany() {
+		return PlanetClassCompany
+	}
+	return PlanetClassPublic
+}
+
+// PlanetClasses returns all planet classes
+func PlanetClasses() []string {
+	return []string{PlanetClassPublic, PlanetClassCompany, PlanetClassPrivate}
+}
+
+func IsValidPlanetClass(class string) bool {
+	switch class {
+	case PlanetClassPublic, PlanetClassCompany, PlanetClassPrivate:
+		return true
+	}
+	return false
+}
+
+func validatePlanetClass(class string) error {
+	if !IsValidPlanetClass(class) {
+		return scoreresult.InvalidParameterError.Errorf("InvalidPlanetClass(%s)", class)
+	}
+	return nil
+}
+
 type Planet struct {
 	dirty bool
 
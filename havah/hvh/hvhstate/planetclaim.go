@@ -0,0 +1,89 @@
+package hvhstate
+
+import (
+	"fmt"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+// PlanetClaimConfig contains the addresses which a planet owner designates for claiming rewards
+type PlanetClaimConfig struct {
+	// Address which is allowed to claim rewards on behalf of the planet owner
+	delegate *common.Address
+	// Address which receives claimed rewards instead of the planet owner
+	beneficiary *common.Address
+}
+
+func newPlanetClaimConfigFromBytes(b []byte) (*PlanetClaimConfig, error) {
+	pcc := &PlanetClaimConfig{}
+	if len(b) > 0 {
+		if _, err := codec.BC.UnmarshalFromBytes(b, pcc); err != nil {
+			return nil, scoreresult.UnknownFailureError.Wrap(
+				err, "Failed to create a PlanetClaimConfig from bytes")
+		}
+	}
+	return pcc, nil
+}
+
+// Delegate returns nil if no delegate is designated
+func (pcc *PlanetClaimConfig) Delegate() module.Address {
+	if pcc.delegate == nil {
+		return nil
+	}
+	return pcc.delegate
+}
+
+// Beneficiary returns nil if no beneficiary is designated
+func (pcc *PlanetClaimConfig) Beneficiary() module.Address {
+	if pcc.beneficiary == nil {
+		return nil
+	}
+	return pcc.beneficiary
+}
+
+func (pcc *PlanetClaimConfig) IsEmpty() bool {
+	return pcc.delegate == nil && pcc.beneficiary == nil
+}
+
+func (pcc *PlanetClaimConfig) setDelegate(delegate module.Address) {
+	pcc.delegate = common.AddressToPtr(delegate)
+}
+
+func (pcc *PlanetClaimConfig) setBeneficiary(beneficiary module.Address) {
+	pcc.beneficiary = common.AddressToPtr(beneficiary)
+}
+
+func (pcc *PlanetClaimConfig) equal(other *PlanetClaimConfig) bool {
+	return pcc.delegate.Equal(other.delegate) &&
+		pcc.beneficiary.Equal(other.beneficiary)
+}
+
+func (pcc *PlanetClaimConfig) RLPDecodeSelf(d codec.Decoder) error {
+	return d.DecodeListOf(&pcc.delegate, &pcc.beneficiary)
+}
+
+func (pcc *PlanetClaimConfig) RLPEncodeSelf(e codec.Encoder) error {
+	return e.EncodeListOf(pcc.delegate, pcc.beneficiary)
+}
+
+func (pcc *PlanetClaimConfig) Bytes() []byte {
+	return codec.BC.MustMarshalToBytes(pcc)
+}
+
+func (pcc *PlanetClaimConfig) ToJSON() map[string]interface{} {
+	jso := make(map[string]interface{})
+	if pcc.delegate != nil {
+		jso["claimDelegate"] = pcc.delegate
+	}
+	if pcc.beneficiary != nil {
+		jso["rewardBeneficiary"] = pcc.beneficiary
+	}
+	return jso
+}
+
+func (pcc *PlanetClaimConfig) String() string {
+	return fmt.Sprintf("PlanetClaimConfig(delegate=%s,beneficiary=%s)", pcc.delegate, pcc.beneficiary)
+}
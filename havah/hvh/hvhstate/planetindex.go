@@ -0,0 +1,143 @@
+package hvhstate
+
+import (
+	"github.com/icon-project/goloop/common/containerdb"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+// Owner-to-planets index
+//
+// ArrayPlanetsOf(owner): planet ids which an owner has
+// DictPlanetIndex(id): position of a planet id in ArrayPlanetsOf(owner)
+// VarIndexedPlanet: number of indexed planets
+
+func (s *State) getPlanetsOfDB(owner module.Address) *containerdb.ArrayDB {
+	keyBuilder := s.getKeyBuilder(hvhmodule.ArrayPlanetsOf).Append(owner)
+	return containerdb.NewArrayDB(s, keyBuilder)
+}
+
+func (s *State) isPlanetIndexed(id int64) bool {
+	return s.getDictDB(hvhmodule.DictPlanetIndex, 1).Get(id) != nil
+}
+
+func (s *State) addPlanetToIndex(id int64, owner module.Address) error {
+	s.logger.Debugf("addPlanetToIndex() start: id=%d owner=%s", id, owner)
+	indexDB := s.getDictDB(hvhmodule.DictPlanetIndex, 1)
+	if indexDB.Get(id) != nil {
+		return errors.InvalidStateError.Errorf("Planet already indexed: id=%d", id)
+	}
+
+	planetsDB := s.getPlanetsOfDB(owner)
+	if err := indexDB.Set(id, planetsDB.Size()); err != nil {
+		return err
+	}
+	if err := planetsDB.Put(id); err != nil {
+		return err
+	}
+	if err := s.setInt64(hvhmodule.VarIndexedPlanet, s.getInt64(hvhmodule.VarIndexedPlanet)+1); err != nil {
+		return err
+	}
+	s.logger.Debugf("addPlanetToIndex() end: id=%d size=%d", id, planetsDB.Size())
+	return nil
+}
+
+// removePlanetFromIndex moves the last planet of the owner into the position of a given planet
+func (s *State) removePlanetFromIndex(id int64, owner module.Address) error {
+	s.logger.Debugf("removePlanetFromIndex() start: id=%d owner=%s", id, owner)
+	indexDB := s.getDictDB(hvhmodule.DictPlanetIndex, 1)
+	value := indexDB.Get(id)
+	if value == nil {
+		// Planets registered before RevisionPlanetOwnerIndex might not be indexed yet
+		return nil
+	}
+
+	planetsDB := s.getPlanetsOfDB(owner)
+	i := int(value.Int64())
+	size := planetsDB.Size()
+	if i < 0 || i >= size || planetsDB.Get(i).Int64() != id {
+		return errors.InvalidStateError.Errorf(
+			"Planet index mismatch: id=%d owner=%s index=%d size=%d", id, owner, i, size)
+	}
+
+	last := planetsDB.Pop().Int64()
+	if last != id {
+		if err := planetsDB.Set(i, last); err != nil {
+			return err
+		}
+		if err := indexDB.Set(last, i); err != nil {
+			return err
+		}
+	}
+	if err := indexDB.Delete(id); err != nil {
+		return err
+	}
+	if err := s.setInt64(hvhmodule.VarIndexedPlanet, s.getInt64(hvhmodule.VarIndexedPlanet)-1); err != nil {
+		return err
+	}
+	s.logger.Debugf("removePlanetFromIndex() end: id=%d size=%d", id, planetsDB.Size())
+	return nil
+}
+
+// GetUnindexedPlanetCount returns the number of planets registered before RevisionPlanetOwnerIndex
+// which are not indexed yet
+func (s *State) GetUnindexedPlanetCount() int64 {
+	return s.getInt64(hvhmodule.VarAllPlanet) - s.getInt64(hvhmodule.VarIndexedPlanet)
+}
+
+// CheckPlanetIndexCompleted returns an error if any planet is not indexed yet.
+// The owner-to-planets index can't tell which owners the unindexed planets belong to,
+// so the operations covering all planets of an owner are not allowed until then
+func (s *State) CheckPlanetIndexCompleted() error {
+	if count := s.GetUnindexedPlanetCount(); count > 0 {
+		return scoreresult.Errorf(
+			hvhmodule.StatusInvalidState, "PlanetIndexIncomplete(unindexed=%d)", count)
+	}
+	return nil
+}
+
+// GetPlanetsOf returns up to limit planet ids from start in the planets of a given owner
+// and the number of planets which the owner has
+func (s *State) GetPlanetsOf(owner module.Address, start int64, limit int) ([]int64, int, error) {
+	if owner == nil {
+		return nil, 0, errors.IllegalArgumentError.New("Invalid owner")
+	}
+	planetsDB := s.getPlanetsOfDB(owner)
+	size := planetsDB.Size()
+	if start < 0 || start > int64(size) {
+		return nil, size, scoreresult.InvalidParameterError.Errorf("InvalidArgument(start=%d)", start)
+	}
+	end := int(start) + limit
+	if end > size {
+		end = size
+	}
+	ids := make([]int64, 0, end-int(start))
+	for i := int(start); i < end; i++ {
+		ids = append(ids, planetsDB.Get(i).Int64())
+	}
+	return ids, size, nil
+}
+
+// IndexPlanets adds the planets which were registered before RevisionPlanetOwnerIndex
+// to the owner-to-planets index. It returns the number of planets newly indexed
+func (s *State) IndexPlanets(ids []int64) (int, error) {
+	s.logger.Debugf("IndexPlanets() start: ids=%v", ids)
+	count := 0
+	for _, id := range ids {
+		p, err := s.GetPlanet(id)
+		if err != nil {
+			return count, err
+		}
+		if s.isPlanetIndexed(id) {
+			continue
+		}
+		if err = s.addPlanetToIndex(id, p.Owner()); err != nil {
+			return count, err
+		}
+		count++
+	}
+	s.logger.Debugf("IndexPlanets() end: count=%d", count)
+	return count, nil
+}
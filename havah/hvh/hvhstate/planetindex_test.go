@@ -0,0 +1,157 @@
+package hvhstate
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/module"
+)
+
+func checkPlanetsOf(t *testing.T, s *State, owner module.Address, expected []int64) {
+	ids, size, err := s.GetPlanetsOf(owner, 0, hvhmodule.MaxCountToQuery)
+	assert.NoError(t, err)
+	assert.Equal(t, len(expected), size)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	if len(expected) == 0 {
+		assert.Zero(t, len(ids))
+	} else {
+		assert.Equal(t, expected, ids)
+	}
+}
+
+func TestState_PlanetOwnerIndex(t *testing.T) {
+	var err error
+	owner := common.MustNewAddressFromString("hx1")
+	owner2 := common.MustNewAddressFromString("hx2")
+	rev := hvhmodule.RevisionPlanetOwnerIndex
+	s := newDummyState()
+
+	// Planets registered before RevisionPlanetOwnerIndex are not indexed
+	for id := int64(1); id <= 2; id++ {
+		err = s.RegisterPlanet(hvhmodule.Revision7, id, false, false, owner, toUSDT(10), toHVH(1), 5)
+		assert.NoError(t, err)
+	}
+	checkPlanetsOf(t, s, owner, nil)
+
+	for id := int64(3); id <= 5; id++ {
+		err = s.RegisterPlanet(rev, id, false, false, owner, toUSDT(10), toHVH(1), 5)
+		assert.NoError(t, err)
+	}
+	checkPlanetsOf(t, s, owner, []int64{3, 4, 5})
+	assert.Equal(t, int64(2), s.GetUnindexedPlanetCount())
+	assert.Error(t, s.CheckPlanetIndexCompleted())
+
+	count, err := s.IndexPlanets([]int64{1, 2, 3})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	checkPlanetsOf(t, s, owner, []int64{1, 2, 3, 4, 5})
+	assert.Zero(t, s.GetUnindexedPlanetCount())
+	assert.NoError(t, s.CheckPlanetIndexCompleted())
+
+	_, err = s.IndexPlanets([]int64{100})
+	assert.Error(t, err)
+
+	// Transfer a planet in the middle of the list
+	err = s.SetPlanetOwner(rev, 2, owner2)
+	assert.NoError(t, err)
+	checkPlanetsOf(t, s, owner, []int64{1, 3, 4, 5})
+	checkPlanetsOf(t, s, owner2, []int64{2})
+
+	// Nothing changes if the owner is the same
+	err = s.SetPlanetOwner(rev, 2, owner2)
+	assert.NoError(t, err)
+	checkPlanetsOf(t, s, owner2, []int64{2})
+
+	_, err = s.UnregisterPlanet(rev, 5)
+	assert.NoError(t, err)
+	_, err = s.UnregisterPlanet(rev, 2)
+	assert.NoError(t, err)
+	checkPlanetsOf(t, s, owner, []int64{1, 3, 4})
+	checkPlanetsOf(t, s, owner2, nil)
+	assert.Zero(t, s.GetUnindexedPlanetCount())
+
+	_, _, err = s.GetPlanetsOf(nil, 0, 1)
+	assert.Error(t, err)
+}
+
+func TestState_GetPlanetsOf(t *testing.T) {
+	owner := common.MustNewAddressFromString("hx1")
+	rev := hvhmodule.RevisionPlanetOwnerIndex
+	s := newDummyState()
+	for id := int64(1); id <= 5; id++ {
+		err := s.RegisterPlanet(rev, id, false, false, owner, toUSDT(1000), toHVH(1000), 10)
+		assert.NoError(t, err)
+	}
+
+	args := []struct {
+		start int64
+		limit int
+		ids   []int64
+		ok    bool
+	}{
+		{0, 2, []int64{1, 2}, true},
+		{2, 2, []int64{3, 4}, true},
+		{4, 2, []int64{5}, true},
+		{5, 2, []int64{}, true},
+		{6, 2, nil, false},
+		{-1, 2, nil, false},
+	}
+	for i, arg := range args {
+		ids, size, err := s.GetPlanetsOf(owner, arg.start, arg.limit)
+		assert.Equal(t, arg.ok, err == nil, "case %d", i)
+		assert.Equal(t, 5, size)
+		if arg.ok {
+			assert.Equal(t, arg.ids, ids, "case %d", i)
+		}
+	}
+
+	_, _, err := s.GetPlanetsOf(nil, 0, 2)
+	assert.Error(t, err)
+}
+
+func TestState_GetClaimablePlanetRewards(t *testing.T) {
+	owner := common.MustNewAddressFromString("hx1")
+	rev := hvhmodule.RevisionPlanetOwnerIndex
+	s := newDummyState()
+	height := int64(10)
+
+	// Rewards of a private planet are locked by default
+	for id := int64(1); id <= 3; id++ {
+		err := s.RegisterPlanet(rev, id, id == 3, false, owner, toUSDT(1000), toHVH(1000), height)
+		assert.NoError(t, err)
+		err = s.setPlanetReward(id, newPlanetReward(toHVH(10), toHVH(10), 0))
+		assert.NoError(t, err)
+	}
+
+	claimable, count, next, err := s.GetClaimablePlanetRewards(height+10, owner, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Zero(t, next)
+	assert.Zero(t, toHVH(20).Cmp(claimable))
+
+	// Planets are counted from cursor
+	claimable, count, next, err = s.GetClaimablePlanetRewards(height+10, owner, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Zero(t, next)
+	assert.Zero(t, toHVH(10).Cmp(claimable))
+
+	_, _, _, err = s.GetClaimablePlanetRewards(height+10, owner, 4)
+	assert.Error(t, err)
+
+	claimable, count, next, err = s.GetClaimablePlanetRewards(height+10, common.MustNewAddressFromString("hx2"), 0)
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+	assert.Zero(t, next)
+	assert.Zero(t, claimable.Sign())
+
+	// Planets which are not indexed yet might belong to the owner
+	err = s.RegisterPlanet(hvhmodule.Revision7, 4, false, false, owner, toUSDT(1000), toHVH(1000), height)
+	assert.NoError(t, err)
+	_, _, _, err = s.GetClaimablePlanetRewards(height+10, owner, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,61 @@
+package hvhstate
+
+import (
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+// SetPlanetNodeKey registers the public key of the node which runs a planet.
+// Work reports of a planet with a node key should be signed with the key.
+// An empty key removes the existing one
+func (s *State) SetPlanetNodeKey(id int64, owner module.Address, key []byte) ([]byte, error) {
+	s.logger.Debugf("SetPlanetNodeKey() start: id=%d owner=%s key=%#x", id, owner, key)
+
+	p, err := s.GetPlanet(id)
+	if err != nil {
+		return nil, err
+	}
+	if !owner.Equal(p.Owner()) {
+		return nil, scoreresult.AccessDeniedError.Errorf(
+			"NoPermission: id=%d owner=%s from=%s", id, p.Owner(), owner)
+	}
+
+	dictDB := s.getDictDB(hvhmodule.DictPlanetNodeKey, 1)
+	if len(key) == 0 {
+		return nil, dictDB.Delete(id)
+	}
+	pubKey, err := crypto.ParsePublicKey(key)
+	if err != nil {
+		return nil, scoreresult.InvalidParameterError.Wrapf(err, "InvalidArgument(key=%#x)", key)
+	}
+	// Keys are always kept in the compressed format
+	key = pubKey.SerializeCompressed()
+	if err = dictDB.Set(id, key); err != nil {
+		return nil, err
+	}
+
+	s.logger.Debugf("SetPlanetNodeKey() end: id=%d key=%#x", id, key)
+	return key, nil
+}
+
+// GetPlanetNodeKey returns the node key of a planet or nil if it has none
+func (s *State) GetPlanetNodeKey(id int64) (*crypto.PublicKey, error) {
+	if _, err := s.GetPlanet(id); err != nil {
+		return nil, err
+	}
+	value := s.getDictDB(hvhmodule.DictPlanetNodeKey, 1).Get(id)
+	if value == nil {
+		return nil, nil
+	}
+	pubKey, err := crypto.ParsePublicKey(value.Bytes())
+	if err != nil {
+		return nil, scoreresult.UnknownFailureError.Wrapf(err, "InvalidPlanetNodeKey(id=%d)", id)
+	}
+	return pubKey, nil
+}
+
+func (s *State) deletePlanetNodeKey(id int64) error {
+	return s.getDictDB(hvhmodule.DictPlanetNodeKey, 1).Delete(id)
+}
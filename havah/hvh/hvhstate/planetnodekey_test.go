@@ -0,0 +1,63 @@
+package hvhstate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+)
+
+func TestState_SetPlanetNodeKey(t *testing.T) {
+	s := newDummyState()
+	rev := hvhmodule.RevisionPlanetNodeKey
+	id := int64(1)
+	owner := newDummyAddress(1, false)
+	other := newDummyAddress(2, false)
+	_, pubKey := crypto.GenerateKeyPair()
+
+	err := s.RegisterPlanet(rev, id, false, false, owner, toUSDT(1000), toHVH(10000), 10)
+	assert.NoError(t, err)
+
+	key, err := s.GetPlanetNodeKey(id)
+	assert.NoError(t, err)
+	assert.Nil(t, key)
+
+	_, err = s.SetPlanetNodeKey(id, other, pubKey.SerializeCompressed())
+	assert.Error(t, err)
+	_, err = s.SetPlanetNodeKey(id, owner, []byte{0x02, 0x01})
+	assert.Error(t, err)
+	_, err = s.SetPlanetNodeKey(id+1, owner, pubKey.SerializeCompressed())
+	assert.Error(t, err)
+
+	// Uncompressed keys are stored in the compressed format
+	stored, err := s.SetPlanetNodeKey(id, owner, pubKey.SerializeUncompressed())
+	assert.NoError(t, err)
+	assert.Equal(t, pubKey.SerializeCompressed(), stored)
+	key, err = s.GetPlanetNodeKey(id)
+	assert.NoError(t, err)
+	assert.True(t, pubKey.Equal(key))
+
+	stored, err = s.SetPlanetNodeKey(id, owner, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, stored)
+	key, err = s.GetPlanetNodeKey(id)
+	assert.NoError(t, err)
+	assert.Nil(t, key)
+
+	// A new owner does not inherit the node key
+	_, err = s.SetPlanetNodeKey(id, owner, pubKey.SerializeCompressed())
+	assert.NoError(t, err)
+	err = s.SetPlanetOwner(rev, id, other)
+	assert.NoError(t, err)
+	key, err = s.GetPlanetNodeKey(id)
+	assert.NoError(t, err)
+	assert.Nil(t, key)
+
+	_, err = s.SetPlanetNodeKey(id, other, pubKey.SerializeCompressed())
+	assert.NoError(t, err)
+	_, err = s.UnregisterPlanet(rev, id)
+	assert.NoError(t, err)
+	assert.Nil(t, s.getDictDB(hvhmodule.DictPlanetNodeKey, 1).Get(id))
+}
@@ -0,0 +1,310 @@
+package hvhstate
+
+import (
+	"math/big"
+
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+// Planet reward weights
+//
+// DictPlanetClassWeight(class): weight of the planets in a class
+// DictPlanetClassCount(class): number of weighted planets in a class whose weights are not set individually
+// DictPlanetWeight(id): weight set to a weighted planet individually
+// VarPlanetWeightCount, VarPlanetWeightSum: number and sum of individual planet weights
+// DictPlanetWeighted(id): 1 if a planet is counted for the weights above, 0 if it is reserved to be counted
+// ArrayPendingWeightedPlanets: planets reserved to be counted at the start of the next term
+//
+// Planets registered since RevisionPlanetWeight are weighted on registration. The planets registered before
+// are regarded as having PlanetWeightDenom until they are weighted at the start of the term
+// following their first work report or individual weight
+//
+// Weight changes are kept in DictPendingClassWeight and DictPendingPlanetWeight
+// and applied at the start of the next term, because the rewards of a term are shared
+// based on the total weight of planets at its start
+
+func validatePlanetWeight(weight int64) error {
+	if weight < 0 || weight > hvhmodule.MaxPlanetWeight {
+		return scoreresult.InvalidParameterError.Errorf("InvalidArgument(weight=%d)", weight)
+	}
+	return nil
+}
+
+func (s *State) GetPlanetClassWeight(class string) int64 {
+	if v := s.getDictDB(hvhmodule.DictPlanetClassWeight, 1).Get(class); v != nil {
+		return v.Int64()
+	}
+	return hvhmodule.PlanetWeightDenom
+}
+
+// SetPlanetClassWeight reserves the weight of a class which is applied at the start of the next term
+func (s *State) SetPlanetClassWeight(class string, weight int64) error {
+	if err := validatePlanetClass(class); err != nil {
+		return err
+	}
+	if err := validatePlanetWeight(weight); err != nil {
+		return err
+	}
+	return s.getDictDB(hvhmodule.DictPendingClassWeight, 1).Set(class, weight)
+}
+
+// GetPendingPlanetClassWeight returns the weight of a class which will be applied at the start of the next term
+func (s *State) GetPendingPlanetClassWeight(class string) (int64, bool) {
+	if v := s.getDictDB(hvhmodule.DictPendingClassWeight, 1).Get(class); v != nil {
+		return v.Int64(), true
+	}
+	return 0, false
+}
+
+// SetPlanetWeight reserves the weight of a planet regardless of its class.
+// A negative weight makes the planet follow the weight of its class again
+func (s *State) SetPlanetWeight(id int64, weight int64) error {
+	if _, err := s.GetPlanet(id); err != nil {
+		return err
+	}
+	if weight >= 0 {
+		if err := validatePlanetWeight(weight); err != nil {
+			return err
+		}
+	} else {
+		weight = -1
+	}
+	if err := s.ReservePlanetWeighting(id); err != nil {
+		return err
+	}
+
+	pendingDB := s.getDictDB(hvhmodule.DictPendingPlanetWeight, 1)
+	if pendingDB.Get(id) == nil {
+		arrayDB := s.getArrayDB(hvhmodule.ArrayPendingPlanetWeights)
+		if arrayDB.Size() >= hvhmodule.MaxPendingPlanetWeights {
+			return scoreresult.Errorf(
+				hvhmodule.StatusIllegalArgument,
+				"Too many pending planet weights: max(%d)", hvhmodule.MaxPendingPlanetWeights)
+		}
+		if err := arrayDB.Put(id); err != nil {
+			return err
+		}
+	}
+	return pendingDB.Set(id, weight)
+}
+
+// GetPendingPlanetWeight returns the weight of a planet which will be applied at the start of the next term.
+// A negative weight means that the individual weight of the planet will be removed
+func (s *State) GetPendingPlanetWeight(id int64) (int64, bool) {
+	if v := s.getDictDB(hvhmodule.DictPendingPlanetWeight, 1).Get(id); v != nil {
+		return v.Int64(), true
+	}
+	return 0, false
+}
+
+func (s *State) isPlanetWeighted(id int64) bool {
+	v := s.getDictDB(hvhmodule.DictPlanetWeighted, 1).Get(id)
+	return v != nil && v.Int64() == 1
+}
+
+// ReservePlanetWeighting reserves a planet registered before RevisionPlanetWeight
+// to be weighted by its class at the start of the next term
+func (s *State) ReservePlanetWeighting(id int64) error {
+	weightedDB := s.getDictDB(hvhmodule.DictPlanetWeighted, 1)
+	if weightedDB.Get(id) != nil {
+		return nil
+	}
+	if err := weightedDB.Set(id, 0); err != nil {
+		return err
+	}
+	return s.getArrayDB(hvhmodule.ArrayPendingWeightedPlanets).Put(id)
+}
+
+// ApplyPendingPlanetWeights applies the weight changes reserved during the previous term
+func (s *State) ApplyPendingPlanetWeights() error {
+	s.logger.Debugf("ApplyPendingPlanetWeights() start")
+
+	weightedDB := s.getDictDB(hvhmodule.DictPlanetWeighted, 1)
+	weightedArrayDB := s.getArrayDB(hvhmodule.ArrayPendingWeightedPlanets)
+	for weightedArrayDB.Size() > 0 {
+		id := weightedArrayDB.Pop().Int64()
+		if v := weightedDB.Get(id); v == nil || v.Int64() != 0 {
+			// The planet has been unregistered or weighted since it was reserved
+			continue
+		}
+		p, err := s.GetPlanet(id)
+		if err != nil {
+			return err
+		}
+		if err = s.countPlanetWeight(id, PlanetClassOf(p)); err != nil {
+			return err
+		}
+	}
+
+	classDB := s.getDictDB(hvhmodule.DictPendingClassWeight, 1)
+	for _, class := range PlanetClasses() {
+		if v := classDB.Get(class); v != nil {
+			if err := s.getDictDB(hvhmodule.DictPlanetClassWeight, 1).Set(class, v.Int64()); err != nil {
+				return err
+			}
+			if err := classDB.Delete(class); err != nil {
+				return err
+			}
+		}
+	}
+
+	pendingDB := s.getDictDB(hvhmodule.DictPendingPlanetWeight, 1)
+	arrayDB := s.getArrayDB(hvhmodule.ArrayPendingPlanetWeights)
+	for arrayDB.Size() > 0 {
+		id := arrayDB.Pop().Int64()
+		v := pendingDB.Get(id)
+		if v == nil {
+			continue
+		}
+		if err := pendingDB.Delete(id); err != nil {
+			return err
+		}
+		p, err := s.GetPlanet(id)
+		if err != nil || !s.isPlanetWeighted(id) {
+			// The planet has been unregistered since its weight was reserved
+			continue
+		}
+		if weight := v.Int64(); weight >= 0 {
+			err = s.setPlanetWeight(id, p, weight)
+		} else {
+			err = s.removeIndividualPlanetWeight(id, PlanetClassOf(p))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	s.logger.Debugf("ApplyPendingPlanetWeights() end")
+	return nil
+}
+
+func (s *State) setPlanetWeight(id int64, p *Planet, weight int64) error {
+	weightDB := s.getDictDB(hvhmodule.DictPlanetWeight, 1)
+	sum := s.getInt64(hvhmodule.VarPlanetWeightSum)
+	if v := weightDB.Get(id); v != nil {
+		sum -= v.Int64()
+	} else {
+		if err := s.addPlanetClassCount(PlanetClassOf(p), -1); err != nil {
+			return err
+		}
+		if err := s.setInt64(
+			hvhmodule.VarPlanetWeightCount, s.getInt64(hvhmodule.VarPlanetWeightCount)+1); err != nil {
+			return err
+		}
+	}
+	if err := s.setInt64(hvhmodule.VarPlanetWeightSum, sum+weight); err != nil {
+		return err
+	}
+	return weightDB.Set(id, weight)
+}
+
+// removeIndividualPlanetWeight makes a planet follow the weight of its class again
+func (s *State) removeIndividualPlanetWeight(id int64, class string) error {
+	weightDB := s.getDictDB(hvhmodule.DictPlanetWeight, 1)
+	v := weightDB.Get(id)
+	if v == nil {
+		return nil
+	}
+	if err := s.setInt64(
+		hvhmodule.VarPlanetWeightCount, s.getInt64(hvhmodule.VarPlanetWeightCount)-1); err != nil {
+		return err
+	}
+	if err := s.setInt64(
+		hvhmodule.VarPlanetWeightSum, s.getInt64(hvhmodule.VarPlanetWeightSum)-v.Int64()); err != nil {
+		return err
+	}
+	if err := weightDB.Delete(id); err != nil {
+		return err
+	}
+	return s.addPlanetClassCount(class, 1)
+}
+
+// GetPlanetWeight returns the effective weight of a planet and whether it is set individually
+func (s *State) GetPlanetWeight(id int64) (int64, bool, error) {
+	p, err := s.GetPlanet(id)
+	if err != nil {
+		return 0, false, err
+	}
+	if !s.isPlanetWeighted(id) {
+		return hvhmodule.PlanetWeightDenom, false, nil
+	}
+	if v := s.getDictDB(hvhmodule.DictPlanetWeight, 1).Get(id); v != nil {
+		return v.Int64(), true, nil
+	}
+	return s.GetPlanetClassWeight(PlanetClassOf(p)), false, nil
+}
+
+func (s *State) getPlanetClassCount(class string) int64 {
+	if v := s.getDictDB(hvhmodule.DictPlanetClassCount, 1).Get(class); v != nil {
+		return v.Int64()
+	}
+	return 0
+}
+
+func (s *State) addPlanetClassCount(class string, delta int64) error {
+	return s.getDictDB(hvhmodule.DictPlanetClassCount, 1).Set(class, s.getPlanetClassCount(class)+delta)
+}
+
+// countPlanetWeight adds a planet to the total weight of planets by its class
+func (s *State) countPlanetWeight(id int64, class string) error {
+	if err := s.getDictDB(hvhmodule.DictPlanetWeighted, 1).Set(id, 1); err != nil {
+		return err
+	}
+	return s.addPlanetClassCount(class, 1)
+}
+
+// deletePlanetWeight removes an unregistered planet from the total weight of planets
+func (s *State) deletePlanetWeight(id int64, class string) error {
+	if s.isPlanetWeighted(id) {
+		if err := s.removeIndividualPlanetWeight(id, class); err != nil {
+			return err
+		}
+		if err := s.addPlanetClassCount(class, -1); err != nil {
+			return err
+		}
+	}
+	if err := s.getDictDB(hvhmodule.DictPlanetWeighted, 1).Delete(id); err != nil {
+		return err
+	}
+	if err := s.getDictDB(hvhmodule.DictPendingPlanetWeight, 1).Delete(id); err != nil {
+		return err
+	}
+	return s.getDictDB(hvhmodule.DictPlanetWeight, 1).Delete(id)
+}
+
+// GetAllPlanetWeight returns the total weight of all registered planets
+func (s *State) GetAllPlanetWeight() *big.Int {
+	allPlanet := s.getInt64(hvhmodule.VarAllPlanet)
+	count := s.getInt64(hvhmodule.VarPlanetWeightCount)
+	total := s.getInt64(hvhmodule.VarPlanetWeightSum)
+	for _, class := range PlanetClasses() {
+		classCount := s.getPlanetClassCount(class)
+		count += classCount
+		total += classCount * s.GetPlanetClassWeight(class)
+	}
+	// Planets which are not indexed yet
+	total += (allPlanet - count) * hvhmodule.PlanetWeightDenom
+	return big.NewInt(total)
+}
+
+// SetActivePlanetWeight keeps the total weight of planets at the start of a term
+// in the same way as activePlanet keeps the number of planets
+func (s *State) SetActivePlanetWeight() error {
+	return s.setBigInt(hvhmodule.VarActivePlanetWeight, s.GetAllPlanetWeight())
+}
+
+func (s *State) GetActivePlanetWeight() *big.Int {
+	return s.getBigInt(hvhmodule.VarActivePlanetWeight)
+}
+
+// GetActivePlanetReward returns the reward of the current term for a planet of a given weight
+func (s *State) GetActivePlanetReward(weight int64) *big.Int {
+	activePlanets := s.getBigInt(hvhmodule.VarActivePlanet)
+	activeWeight := s.GetActivePlanetWeight()
+	if activePlanets.Sign() <= 0 || activeWeight.Sign() <= 0 {
+		return hvhmodule.BigIntZero
+	}
+	reward := new(big.Int).Mul(s.getBigInt(hvhmodule.VarRewardTotal), big.NewInt(weight))
+	return reward.Div(reward, activeWeight)
+}
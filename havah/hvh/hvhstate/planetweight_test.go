@@ -0,0 +1,150 @@
+package hvhstate
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/havah/hvhmodule"
+)
+
+func TestState_PlanetWeight(t *testing.T) {
+	s := newDummyState()
+	rev := hvhmodule.RevisionPlanetWeight
+	owner := newDummyAddress(1, false)
+	denom := int64(hvhmodule.PlanetWeightDenom)
+
+	// id 1, 2: public, id 3: company, id 4: private
+	for id := int64(1); id <= 4; id++ {
+		err := s.RegisterPlanet(rev, id, id == 4, id == 3, owner, toUSDT(1000), toHVH(1000), 10)
+		assert.NoError(t, err)
+	}
+	assert.Zero(t, big.NewInt(4*denom).Cmp(s.GetAllPlanetWeight()))
+
+	assert.Error(t, s.SetPlanetClassWeight("unknown", denom))
+	assert.Error(t, s.SetPlanetClassWeight(PlanetClassCompany, -1))
+	assert.Error(t, s.SetPlanetClassWeight(PlanetClassCompany, hvhmodule.MaxPlanetWeight+1))
+	assert.NoError(t, s.SetPlanetClassWeight(PlanetClassCompany, 3*denom))
+	assert.NoError(t, s.SetPlanetWeight(4, 0))
+	assert.Error(t, s.SetPlanetWeight(5, denom))
+
+	// Reserved weights are not applied until the next term starts
+	assert.Equal(t, denom, s.GetPlanetClassWeight(PlanetClassCompany))
+	weight, ok := s.GetPendingPlanetClassWeight(PlanetClassCompany)
+	assert.True(t, ok)
+	assert.Equal(t, 3*denom, weight)
+	weight, individual, err := s.GetPlanetWeight(4)
+	assert.NoError(t, err)
+	assert.Equal(t, denom, weight)
+	assert.False(t, individual)
+
+	assert.NoError(t, s.ApplyPendingPlanetWeights())
+	_, ok = s.GetPendingPlanetClassWeight(PlanetClassCompany)
+	assert.False(t, ok)
+	_, ok = s.GetPendingPlanetWeight(4)
+	assert.False(t, ok)
+	weight, individual, err = s.GetPlanetWeight(3)
+	assert.NoError(t, err)
+	assert.Equal(t, 3*denom, weight)
+	assert.False(t, individual)
+	weight, individual, err = s.GetPlanetWeight(4)
+	assert.NoError(t, err)
+	assert.Zero(t, weight)
+	assert.True(t, individual)
+	assert.Zero(t, big.NewInt(5*denom).Cmp(s.GetAllPlanetWeight()))
+
+	// Weighted rewards
+	assert.NoError(t, s.OnTermStart(toHVH(500)))
+	assert.NoError(t, s.SetActivePlanetWeight())
+	assert.Zero(t, toHVH(100).Cmp(s.GetActivePlanetReward(denom)))
+	assert.Zero(t, toHVH(300).Cmp(s.GetActivePlanetReward(3*denom)))
+	_, reward := s.GetActivePlanetCountAndReward()
+	assert.Zero(t, toHVH(100).Cmp(reward))
+
+	// The individual weight is kept after the owner changes
+	assert.NoError(t, s.SetPlanetOwner(rev, 4, newDummyAddress(2, false)))
+	weight, _, err = s.GetPlanetWeight(4)
+	assert.NoError(t, err)
+	assert.Zero(t, weight)
+	assert.Zero(t, big.NewInt(5*denom).Cmp(s.GetAllPlanetWeight()))
+
+	// A negative weight removes the individual weight
+	assert.NoError(t, s.SetPlanetWeight(4, -1))
+	assert.NoError(t, s.ApplyPendingPlanetWeights())
+	weight, individual, err = s.GetPlanetWeight(4)
+	assert.NoError(t, err)
+	assert.Equal(t, denom, weight)
+	assert.False(t, individual)
+	assert.Zero(t, big.NewInt(6*denom).Cmp(s.GetAllPlanetWeight()))
+
+	// The weight reserved for an unregistered planet is dropped
+	assert.NoError(t, s.SetPlanetWeight(1, 2*denom))
+	_, err = s.UnregisterPlanet(rev, 1)
+	assert.NoError(t, err)
+	assert.NoError(t, s.ApplyPendingPlanetWeights())
+	assert.Zero(t, big.NewInt(5*denom).Cmp(s.GetAllPlanetWeight()))
+
+	_, err = s.UnregisterPlanet(rev, 3)
+	assert.NoError(t, err)
+	assert.Zero(t, big.NewInt(2*denom).Cmp(s.GetAllPlanetWeight()))
+}
+
+func TestState_PlanetWeightOfLegacyPlanets(t *testing.T) {
+	s := newDummyState()
+	owner := newDummyAddress(1, false)
+	denom := int64(hvhmodule.PlanetWeightDenom)
+
+	// Planets registered before RevisionPlanetWeight are not weighted by their classes
+	for id := int64(1); id <= 4; id++ {
+		err := s.RegisterPlanet(hvhmodule.Revision4, id, false, true, owner, toUSDT(1000), toHVH(1000), 10)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, s.SetPlanetClassWeight(PlanetClassCompany, 2*denom))
+	assert.NoError(t, s.ApplyPendingPlanetWeights())
+	assert.Zero(t, big.NewInt(4*denom).Cmp(s.GetAllPlanetWeight()))
+
+	// Indexing and transfer don't change the weights of planets
+	count, err := s.IndexPlanets([]int64{1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.NoError(t, s.SetPlanetOwner(hvhmodule.RevisionPlanetWeight, 3, newDummyAddress(2, false)))
+	assert.Zero(t, big.NewInt(4*denom).Cmp(s.GetAllPlanetWeight()))
+	for id := int64(1); id <= 4; id++ {
+		weight, _, err := s.GetPlanetWeight(id)
+		assert.NoError(t, err)
+		assert.Equal(t, denom, weight)
+	}
+
+	// Reserved planets are weighted at the start of the next term
+	assert.NoError(t, s.ReservePlanetWeighting(1))
+	assert.NoError(t, s.ReservePlanetWeighting(1))
+	assert.NoError(t, s.SetPlanetWeight(2, 3*denom))
+	assert.NoError(t, s.ReservePlanetWeighting(4))
+	_, err = s.UnregisterPlanet(hvhmodule.RevisionPlanetWeight, 4)
+	assert.NoError(t, err)
+	weight, _, err := s.GetPlanetWeight(1)
+	assert.NoError(t, err)
+	assert.Equal(t, denom, weight)
+	assert.Zero(t, big.NewInt(3*denom).Cmp(s.GetAllPlanetWeight()))
+
+	assert.NoError(t, s.ApplyPendingPlanetWeights())
+	weight, individual, err := s.GetPlanetWeight(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2*denom, weight)
+	assert.False(t, individual)
+	weight, individual, err = s.GetPlanetWeight(2)
+	assert.NoError(t, err)
+	assert.Equal(t, 3*denom, weight)
+	assert.True(t, individual)
+	weight, _, err = s.GetPlanetWeight(3)
+	assert.NoError(t, err)
+	assert.Equal(t, denom, weight)
+	assert.Zero(t, big.NewInt(6*denom).Cmp(s.GetAllPlanetWeight()))
+
+	for id := int64(1); id <= 3; id++ {
+		_, err = s.UnregisterPlanet(hvhmodule.RevisionPlanetWeight, id)
+		assert.NoError(t, err)
+	}
+	assert.Zero(t, s.GetAllPlanetWeight().Sign())
+}
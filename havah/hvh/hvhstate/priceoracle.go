@@ -0,0 +1,196 @@
+package hvhstate
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+// PriceReport is the latest USDT price submitted by a price reporter
+type PriceReport struct {
+	price  *big.Int // unit: hvh
+	height int64
+}
+
+func newPriceReportFromBytes(b []byte) (*PriceReport, error) {
+	pr := &PriceReport{}
+	if len(b) > 0 {
+		if _, err := codec.BC.UnmarshalFromBytes(b, pr); err != nil {
+			return nil, scoreresult.UnknownFailureError.Wrap(
+				err, "Failed to create a PriceReport from bytes")
+		}
+	}
+	return pr, nil
+}
+
+func (pr *PriceReport) Price() *big.Int {
+	return pr.price
+}
+
+func (pr *PriceReport) Height() int64 {
+	return pr.height
+}
+
+// IsFresh returns true if the report has been submitted within ttl blocks before a given height
+func (pr *PriceReport) IsFresh(height, ttl int64) bool {
+	return pr.price != nil && height-pr.height <= ttl
+}
+
+func (pr *PriceReport) RLPDecodeSelf(d codec.Decoder) error {
+	return d.DecodeListOf(&pr.price, &pr.height)
+}
+
+func (pr *PriceReport) RLPEncodeSelf(e codec.Encoder) error {
+	return e.EncodeListOf(pr.price, pr.height)
+}
+
+func (pr *PriceReport) Bytes() []byte {
+	return codec.BC.MustMarshalToBytes(pr)
+}
+
+func (pr *PriceReport) String() string {
+	return fmt.Sprintf("PriceReport(price=%d,height=%d)", pr.price, pr.height)
+}
+
+func (s *State) AddPriceReporter(address module.Address) error {
+	if ok, err := s.IsPriceReporter(address); err != nil {
+		return err
+	} else if ok {
+		return scoreresult.RevertedError.New("Duplicate address")
+	}
+	arrayDB := s.getArrayDB(hvhmodule.ArrayPriceReporter)
+	if arrayDB.Size() >= hvhmodule.MaxPriceReporterCount {
+		return scoreresult.Errorf(
+			hvhmodule.StatusIllegalArgument,
+			"Too many price reporters: max(%d)", hvhmodule.MaxPriceReporterCount)
+	}
+	return arrayDB.Put(address)
+}
+
+// RemovePriceReporter removes a price reporter with its last report
+func (s *State) RemovePriceReporter(address module.Address) error {
+	if address == nil {
+		return scoreresult.RevertedError.New("Invalid address")
+	}
+	arrayDB := s.getArrayDB(hvhmodule.ArrayPriceReporter)
+	size := arrayDB.Size()
+	for i := 0; i < size; i++ {
+		if address.Equal(arrayDB.Get(i).Address()) {
+			last := arrayDB.Pop().Address()
+			if i < size-1 {
+				if err := arrayDB.Set(i, last); err != nil {
+					return err
+				}
+			}
+			return s.getDictDB(hvhmodule.DictPriceReport, 1).Delete(address)
+		}
+	}
+	return scoreresult.RevertedError.New("Address not found")
+}
+
+func (s *State) IsPriceReporter(address module.Address) (bool, error) {
+	if address == nil {
+		return false, scoreresult.RevertedError.New("Invalid address")
+	}
+	arrayDB := s.getArrayDB(hvhmodule.ArrayPriceReporter)
+	size := arrayDB.Size()
+	for i := 0; i < size; i++ {
+		if address.Equal(arrayDB.Get(i).Address()) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *State) GetPriceReporters() []module.Address {
+	arrayDB := s.getArrayDB(hvhmodule.ArrayPriceReporter)
+	size := arrayDB.Size()
+	reporters := make([]module.Address, size)
+	for i := 0; i < size; i++ {
+		reporters[i] = arrayDB.Get(i).Address()
+	}
+	return reporters
+}
+
+func (s *State) GetPriceReport(reporter module.Address) (*PriceReport, error) {
+	value := s.getDictDB(hvhmodule.DictPriceReport, 1).Get(reporter)
+	if value == nil {
+		return nil, nil
+	}
+	return newPriceReportFromBytes(value.Bytes())
+}
+
+// ReportUSDTPrice overwrites the previous report of a given reporter
+func (s *State) ReportUSDTPrice(reporter module.Address, price *big.Int, height int64) error {
+	s.logger.Debugf("ReportUSDTPrice() start: reporter=%s price=%d height=%d", reporter, price, height)
+	if price == nil || price.Sign() <= 0 {
+		return scoreresult.Errorf(hvhmodule.StatusIllegalArgument, "Invalid price: %v", price)
+	}
+	if ok, err := s.IsPriceReporter(reporter); err != nil {
+		return err
+	} else if !ok {
+		return scoreresult.AccessDeniedError.Errorf("NoPermission: reporter=%s", reporter)
+	}
+
+	pr := &PriceReport{price: price, height: height}
+	err := s.getDictDB(hvhmodule.DictPriceReport, 1).Set(reporter, pr.Bytes())
+	s.logger.Debugf("ReportUSDTPrice() end: %s", pr)
+	return err
+}
+
+func (s *State) SetPriceReportParameters(ttl, minReports int64) error {
+	if ttl < 1 {
+		return scoreresult.InvalidParameterError.Errorf("InvalidArgument(ttl=%d)", ttl)
+	}
+	if minReports < 1 || minReports > hvhmodule.MaxPriceReporterCount {
+		return scoreresult.InvalidParameterError.Errorf("InvalidArgument(minReports=%d)", minReports)
+	}
+	if err := s.setInt64(hvhmodule.VarPriceReportTTL, ttl); err != nil {
+		return err
+	}
+	return s.setInt64(hvhmodule.VarMinPriceReports, minReports)
+}
+
+func (s *State) GetPriceReportTTL() int64 {
+	return s.getInt64OrDefault(hvhmodule.VarPriceReportTTL, hvhmodule.PriceReportTTL)
+}
+
+func (s *State) GetMinPriceReports() int64 {
+	return s.getInt64OrDefault(hvhmodule.VarMinPriceReports, hvhmodule.MinPriceReports)
+}
+
+// GetMedianUSDTPrice returns the median of the prices which are reported within priceReportTTL
+// and the number of them. The price is nil if fresh reports are fewer than minPriceReports.
+// If the number of reports is even, the median is the floor of the mean of the two middle prices
+func (s *State) GetMedianUSDTPrice(height int64) (*big.Int, int, error) {
+	ttl := s.GetPriceReportTTL()
+	var prices []*big.Int
+	for _, reporter := range s.GetPriceReporters() {
+		pr, err := s.GetPriceReport(reporter)
+		if err != nil {
+			return nil, 0, err
+		}
+		if pr != nil && pr.IsFresh(height, ttl) {
+			prices = append(prices, pr.Price())
+		}
+	}
+
+	count := len(prices)
+	if int64(count) < s.GetMinPriceReports() || count == 0 {
+		return nil, count, nil
+	}
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].Cmp(prices[j]) < 0
+	})
+	mid := count / 2
+	if count%2 == 1 {
+		return prices[mid], count, nil
+	}
+	median := new(big.Int).Add(prices[mid-1], prices[mid])
+	return median.Rsh(median, 1), count, nil
+}
@@ -0,0 +1,129 @@
+package hvhstate
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/module"
+)
+
+func TestState_PriceReporter(t *testing.T) {
+	var err error
+	s := newDummyState()
+	reporters := []module.Address{
+		common.MustNewAddressFromString("hx1"),
+		common.MustNewAddressFromString("hx2"),
+		common.MustNewAddressFromString("hx3"),
+	}
+	for _, reporter := range reporters {
+		err = s.AddPriceReporter(reporter)
+		assert.NoError(t, err)
+	}
+	err = s.AddPriceReporter(reporters[0])
+	assert.Error(t, err)
+	assert.Equal(t, reporters, s.GetPriceReporters())
+
+	// Only price reporters can report prices
+	err = s.ReportUSDTPrice(common.MustNewAddressFromString("hx4"), toHVH(1), 10)
+	assert.Error(t, err)
+	err = s.ReportUSDTPrice(reporters[0], big.NewInt(0), 10)
+	assert.Error(t, err)
+	err = s.ReportUSDTPrice(reporters[0], toHVH(1), 10)
+	assert.NoError(t, err)
+
+	pr, err := s.GetPriceReport(reporters[0])
+	assert.NoError(t, err)
+	assert.Zero(t, toHVH(1).Cmp(pr.Price()))
+	assert.Equal(t, int64(10), pr.Height())
+
+	// The report of a removed reporter is deleted
+	err = s.RemovePriceReporter(reporters[0])
+	assert.NoError(t, err)
+	err = s.RemovePriceReporter(reporters[0])
+	assert.Error(t, err)
+	assert.Equal(t, []module.Address{reporters[2], reporters[1]}, s.GetPriceReporters())
+	pr, err = s.GetPriceReport(reporters[0])
+	assert.NoError(t, err)
+	assert.Nil(t, pr)
+}
+
+func TestState_GetMedianUSDTPrice(t *testing.T) {
+	var err error
+	s := newDummyState()
+	ttl := int64(100)
+	err = s.SetPriceReportParameters(ttl, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, ttl, s.GetPriceReportTTL())
+	assert.Equal(t, int64(2), s.GetMinPriceReports())
+
+	err = s.SetPriceReportParameters(0, 1)
+	assert.Error(t, err)
+	err = s.SetPriceReportParameters(ttl, 0)
+	assert.Error(t, err)
+
+	reporters := make([]module.Address, 4)
+	for i := range reporters {
+		reporters[i] = common.MustNewAddressFromString(fmt.Sprintf("hx%d", i+1))
+		err = s.AddPriceReporter(reporters[i])
+		assert.NoError(t, err)
+	}
+
+	height := int64(1000)
+	price, count, err := s.GetMedianUSDTPrice(height)
+	assert.NoError(t, err)
+	assert.Nil(t, price)
+	assert.Zero(t, count)
+
+	// Not enough reports
+	err = s.ReportUSDTPrice(reporters[0], toHVH(10), height-ttl)
+	assert.NoError(t, err)
+	price, count, err = s.GetMedianUSDTPrice(height)
+	assert.NoError(t, err)
+	assert.Nil(t, price)
+	assert.Equal(t, 1, count)
+
+	// Odd number of reports
+	err = s.ReportUSDTPrice(reporters[1], toHVH(30), height-1)
+	assert.NoError(t, err)
+	err = s.ReportUSDTPrice(reporters[2], toHVH(20), height)
+	assert.NoError(t, err)
+	price, count, err = s.GetMedianUSDTPrice(height)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Zero(t, toHVH(20).Cmp(price))
+
+	// Even number of reports
+	err = s.ReportUSDTPrice(reporters[3], toHVH(25), height)
+	assert.NoError(t, err)
+	price, count, err = s.GetMedianUSDTPrice(height)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, count)
+	exp := new(big.Int).Add(toHVH(20), toHVH(25))
+	assert.Zero(t, exp.Rsh(exp, 1).Cmp(price))
+
+	// Stale reports are excluded
+	price, count, err = s.GetMedianUSDTPrice(height + 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Zero(t, toHVH(25).Cmp(price))
+
+	price, count, err = s.GetMedianUSDTPrice(height + ttl + 1)
+	assert.NoError(t, err)
+	assert.Nil(t, price)
+	assert.Zero(t, count)
+}
+
+func TestState_AddPriceReporter_Max(t *testing.T) {
+	s := newDummyState()
+	for i := 0; i < hvhmodule.MaxPriceReporterCount; i++ {
+		err := s.AddPriceReporter(common.MustNewAddressFromString(fmt.Sprintf("hx%x", i+1)))
+		assert.NoError(t, err)
+	}
+	err := s.AddPriceReporter(common.MustNewAddressFromString("hxffff"))
+	assert.Error(t, err)
+}
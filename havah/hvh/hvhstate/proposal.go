@@ -0,0 +1,399 @@
+package hvhstate
+
+import (
+	"fmt"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+type ProposalStatus int
+
+const (
+	ProposalPending ProposalStatus = iota
+	ProposalApproved
+	ProposalRejected
+	ProposalExpired
+	ProposalCanceled
+	ProposalApplied
+	ProposalFailed
+)
+
+func (ps ProposalStatus) String() string {
+	switch ps {
+	case ProposalPending:
+		return "pending"
+	case ProposalApproved:
+		return "approved"
+	case ProposalRejected:
+		return "rejected"
+	case ProposalExpired:
+		return "expired"
+	case ProposalCanceled:
+		return "canceled"
+	case ProposalApplied:
+		return "applied"
+	case ProposalFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// IsActive returns true if the proposal is waiting for votes or to be applied
+func (ps ProposalStatus) IsActive() bool {
+	return ps == ProposalPending || ps == ProposalApproved
+}
+
+// Proposal is a parameter change which main validators vote on
+type Proposal struct {
+	id          int64
+	proposer    *common.Address
+	pType       string
+	value       []byte // parameters in JSON
+	description string
+	startHeight int64
+	endHeight   int64 // the proposal expires if it is not approved until endHeight
+	status      ProposalStatus
+	voterCount  int64 // the number of eligible voters when the proposal is submitted
+	agree       int64
+	disagree    int64
+}
+
+func newProposalFromBytes(b []byte) (*Proposal, error) {
+	p := &Proposal{}
+	if _, err := codec.BC.UnmarshalFromBytes(b, p); err != nil {
+		return nil, scoreresult.UnknownFailureError.Wrap(
+			err, "Failed to create a Proposal from bytes")
+	}
+	return p, nil
+}
+
+func (p *Proposal) ID() int64 {
+	return p.id
+}
+
+func (p *Proposal) Proposer() module.Address {
+	return p.proposer
+}
+
+func (p *Proposal) Type() string {
+	return p.pType
+}
+
+func (p *Proposal) Value() []byte {
+	return p.value
+}
+
+func (p *Proposal) Status() ProposalStatus {
+	return p.status
+}
+
+func (p *Proposal) EndHeight() int64 {
+	return p.endHeight
+}
+
+// onVote updates the vote counts and the status of the proposal.
+// A proposal is approved when more than 2/3 of voters agree
+// and rejected when it cannot be approved any more
+func (p *Proposal) onVote(agree bool) {
+	if agree {
+		p.agree++
+	} else {
+		p.disagree++
+	}
+	if p.agree*3 > p.voterCount*2 {
+		p.status = ProposalApproved
+	} else if (p.voterCount-p.disagree)*3 <= p.voterCount*2 {
+		p.status = ProposalRejected
+	}
+}
+
+func (p *Proposal) RLPDecodeSelf(d codec.Decoder) error {
+	return d.DecodeListOf(
+		&p.id, &p.proposer, &p.pType, &p.value, &p.description,
+		&p.startHeight, &p.endHeight, &p.status, &p.voterCount, &p.agree, &p.disagree)
+}
+
+func (p *Proposal) RLPEncodeSelf(e codec.Encoder) error {
+	return e.EncodeListOf(
+		p.id, p.proposer, p.pType, p.value, p.description,
+		p.startHeight, p.endHeight, p.status, p.voterCount, p.agree, p.disagree)
+}
+
+func (p *Proposal) Bytes() []byte {
+	return codec.BC.MustMarshalToBytes(p)
+}
+
+func (p *Proposal) ToJSON() map[string]interface{} {
+	return map[string]interface{}{
+		"id":          p.id,
+		"proposer":    p.proposer,
+		"type":        p.pType,
+		"value":       string(p.value),
+		"description": p.description,
+		"startHeight": p.startHeight,
+		"endHeight":   p.endHeight,
+		"status":      p.status.String(),
+		"voterCount":  p.voterCount,
+		"agree":       p.agree,
+		"disagree":    p.disagree,
+	}
+}
+
+func (p *Proposal) String() string {
+	return fmt.Sprintf(
+		"Proposal(id=%d,proposer=%s,type=%s,value=%s,start=%d,end=%d,status=%s,voters=%d,agree=%d,disagree=%d)",
+		p.id, p.proposer, p.pType, p.value, p.startHeight, p.endHeight,
+		p.status, p.voterCount, p.agree, p.disagree)
+}
+
+// IsProposalVoter returns true if a given address is the owner of an eligible main validator
+func (s *State) IsProposalVoter(owner module.Address) (bool, error) {
+	if owner == nil {
+		return false, nil
+	}
+	vi, err := s.GetValidatorInfo(owner)
+	if err != nil {
+		if status, _ := scoreresult.StatusOf(err); status == hvhmodule.StatusNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	if vi.Grade() != GradeMain {
+		return false, nil
+	}
+	vs, err := s.GetValidatorStatus(owner)
+	if err != nil {
+		return false, err
+	}
+	return !(vs.Disqualified() || vs.Slashed()), nil
+}
+
+// setProposalVoters records the eligible voters of a proposal at its submission and returns the number of them.
+// Validators which become eligible after the submission can't vote on the proposal
+func (s *State) setProposalVoters(id int64) (int64, error) {
+	owners, err := s.GetValidatorsOf(GradeFilterMain)
+	if err != nil {
+		return 0, err
+	}
+	votersDB := s.getDictDB(hvhmodule.DictProposalVoters, 2)
+	count := int64(0)
+	for _, owner := range owners {
+		if ok, err := s.IsProposalVoter(owner); err != nil {
+			return 0, err
+		} else if ok {
+			if err = votersDB.Set(id, ToKey(owner), true); err != nil {
+				return 0, err
+			}
+			count++
+		}
+	}
+	return count, nil
+}
+
+// IsProposalVoterOf returns true if a given address was an eligible voter when a proposal was submitted
+// and it is still eligible
+func (s *State) IsProposalVoterOf(id int64, owner module.Address) (bool, error) {
+	if owner == nil || s.getDictDB(hvhmodule.DictProposalVoters, 2).Get(id, ToKey(owner)) == nil {
+		return false, nil
+	}
+	return s.IsProposalVoter(owner)
+}
+
+// SubmitProposal registers a new proposal and returns its id
+func (s *State) SubmitProposal(
+	proposer module.Address, pType string, value []byte, description string, height int64) (int64, error) {
+	s.logger.Debugf(
+		"SubmitProposal() start: proposer=%s type=%s value=%s height=%d", proposer, pType, value, height)
+
+	if ok, err := s.IsProposalVoter(proposer); err != nil {
+		return 0, err
+	} else if !ok {
+		return 0, scoreresult.AccessDeniedError.Errorf("NoPermission: proposer=%s", proposer)
+	}
+	if len(value) > hvhmodule.MaxProposalValueLen {
+		return 0, scoreresult.InvalidParameterError.Errorf("TooLongValue(%d)", len(value))
+	}
+	if len(description) > hvhmodule.MaxProposalDescriptionLen {
+		return 0, scoreresult.InvalidParameterError.Errorf("TooLongDescription(%d)", len(description))
+	}
+	activeDB := s.getArrayDB(hvhmodule.ArrayActiveProposals)
+	if activeDB.Size() >= hvhmodule.MaxActiveProposals {
+		return 0, scoreresult.Errorf(
+			hvhmodule.StatusIllegalArgument,
+			"Too many active proposals: max(%d)", hvhmodule.MaxActiveProposals)
+	}
+	id := s.getInt64(hvhmodule.VarProposalID) + 1
+	voterCount, err := s.setProposalVoters(id)
+	if err != nil {
+		return 0, err
+	}
+
+	p := &Proposal{
+		id:          id,
+		proposer:    common.AddressToPtr(proposer),
+		pType:       pType,
+		value:       value,
+		description: description,
+		startHeight: height,
+		endHeight:   height + s.GetProposalDuration(),
+		status:      ProposalPending,
+		voterCount:  voterCount,
+	}
+	if err = s.setInt64(hvhmodule.VarProposalID, id); err != nil {
+		return 0, err
+	}
+	if err = s.setProposal(p); err != nil {
+		return 0, err
+	}
+	if err = activeDB.Put(id); err != nil {
+		return 0, err
+	}
+
+	s.logger.Debugf("SubmitProposal() end: %s", p)
+	return id, nil
+}
+
+// VoteProposal records the vote of a main validator owner and returns the updated proposal
+func (s *State) VoteProposal(id int64, voter module.Address, agree bool, height int64) (*Proposal, error) {
+	s.logger.Debugf("VoteProposal() start: id=%d voter=%s agree=%t height=%d", id, voter, agree, height)
+
+	p, err := s.GetProposal(id)
+	if err != nil {
+		return nil, err
+	}
+	if p.status != ProposalPending || height > p.endHeight {
+		return nil, scoreresult.Errorf(
+			hvhmodule.StatusIllegalArgument, "ProposalNotVotable(id=%d,status=%s)", id, p.status)
+	}
+	if ok, err := s.IsProposalVoterOf(id, voter); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, scoreresult.AccessDeniedError.Errorf("NoPermission: voter=%s", voter)
+	}
+
+	votesDB := s.getDictDB(hvhmodule.DictProposalVotes, 2)
+	if votesDB.Get(id, ToKey(voter)) != nil {
+		return nil, scoreresult.Errorf(
+			hvhmodule.StatusDuplicate, "AlreadyVoted(id=%d,voter=%s)", id, voter)
+	}
+	if err = votesDB.Set(id, ToKey(voter), agree); err != nil {
+		return nil, err
+	}
+
+	p.onVote(agree)
+	if err = s.setProposal(p); err != nil {
+		return nil, err
+	}
+	if p.status == ProposalRejected {
+		if err = s.removeActiveProposal(id); err != nil {
+			return nil, err
+		}
+	}
+
+	s.logger.Debugf("VoteProposal() end: %s", p)
+	return p, nil
+}
+
+// CancelProposal cancels a pending proposal, which is allowed only to its proposer
+func (s *State) CancelProposal(id int64, from module.Address) error {
+	p, err := s.GetProposal(id)
+	if err != nil {
+		return err
+	}
+	if !p.proposer.Equal(from) {
+		return scoreresult.AccessDeniedError.Errorf("NoPermission: from=%s", from)
+	}
+	if p.status != ProposalPending {
+		return scoreresult.Errorf(
+			hvhmodule.StatusIllegalArgument, "ProposalNotCancelable(id=%d,status=%s)", id, p.status)
+	}
+	return s.closeProposal(p, ProposalCanceled)
+}
+
+// GetProposalVote returns whether a voter agreed and whether it voted for a given proposal
+func (s *State) GetProposalVote(id int64, voter module.Address) (bool, bool) {
+	if v := s.getDictDB(hvhmodule.DictProposalVotes, 2).Get(id, ToKey(voter)); v != nil {
+		return v.Bool(), true
+	}
+	return false, false
+}
+
+func (s *State) GetProposal(id int64) (*Proposal, error) {
+	v := s.getDictDB(hvhmodule.DictProposal, 1).Get(id)
+	if v == nil {
+		return nil, scoreresult.Errorf(hvhmodule.StatusNotFound, "ProposalNotFound(id=%d)", id)
+	}
+	return newProposalFromBytes(v.Bytes())
+}
+
+func (s *State) setProposal(p *Proposal) error {
+	return s.getDictDB(hvhmodule.DictProposal, 1).Set(p.id, p.Bytes())
+}
+
+// GetActiveProposals returns the proposals which are pending or approved but not applied yet
+func (s *State) GetActiveProposals() ([]*Proposal, error) {
+	activeDB := s.getArrayDB(hvhmodule.ArrayActiveProposals)
+	size := activeDB.Size()
+	proposals := make([]*Proposal, size)
+	for i := 0; i < size; i++ {
+		p, err := s.GetProposal(activeDB.Get(i).Int64())
+		if err != nil {
+			return nil, err
+		}
+		proposals[i] = p
+	}
+	return proposals, nil
+}
+
+// CloseProposal sets the final status of an active proposal and removes it from active proposals
+func (s *State) CloseProposal(id int64, status ProposalStatus) error {
+	p, err := s.GetProposal(id)
+	if err != nil {
+		return err
+	}
+	if !p.status.IsActive() || status.IsActive() {
+		return scoreresult.Errorf(
+			hvhmodule.StatusIllegalArgument,
+			"InvalidStatusTransition(id=%d,from=%s,to=%s)", id, p.status, status)
+	}
+	return s.closeProposal(p, status)
+}
+
+func (s *State) closeProposal(p *Proposal, status ProposalStatus) error {
+	p.status = status
+	if err := s.setProposal(p); err != nil {
+		return err
+	}
+	return s.removeActiveProposal(p.id)
+}
+
+func (s *State) removeActiveProposal(id int64) error {
+	activeDB := s.getArrayDB(hvhmodule.ArrayActiveProposals)
+	size := activeDB.Size()
+	for i := 0; i < size; i++ {
+		if activeDB.Get(i).Int64() == id {
+			last := activeDB.Pop().Int64()
+			if i < size-1 {
+				return activeDB.Set(i, last)
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+func (s *State) SetProposalDuration(duration int64) error {
+	if duration < 1 {
+		return scoreresult.InvalidParameterError.Errorf("InvalidArgument(duration=%d)", duration)
+	}
+	return s.setInt64(hvhmodule.VarProposalDuration, duration)
+}
+
+func (s *State) GetProposalDuration() int64 {
+	return s.getInt64OrDefault(hvhmodule.VarProposalDuration, hvhmodule.ProposalDuration)
+}
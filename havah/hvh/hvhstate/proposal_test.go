@@ -0,0 +1,157 @@
+package hvhstate
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/module"
+)
+
+func registerDummyMainValidators(t *testing.T, s *State, size int) []module.Address {
+	owners := make([]module.Address, size)
+	for i := 0; i < size; i++ {
+		owner := newDummyAddress(100+i, false)
+		_, pubKey := crypto.GenerateKeyPair()
+		err := s.RegisterValidator(owner, pubKey.SerializeCompressed(), GradeMain, fmt.Sprintf("main-%02d", i), nil)
+		assert.NoError(t, err)
+		owners[i] = owner
+	}
+	return owners
+}
+
+func TestState_SubmitProposal(t *testing.T) {
+	s := newDummyState()
+	subs := registerDummyValidators(t, s, 1)
+	mains := registerDummyMainValidators(t, s, 4)
+	value := []byte(`{"count":"0x5"}`)
+
+	// Only main validators can submit proposals
+	_, err := s.SubmitProposal(subs[0], "activeValidatorCount", value, "", 10)
+	assert.Error(t, err)
+
+	// Too long value and description are rejected
+	_, err = s.SubmitProposal(mains[0], "activeValidatorCount", make([]byte, hvhmodule.MaxProposalValueLen+1), "", 10)
+	assert.Error(t, err)
+	longDesc := string(make([]byte, hvhmodule.MaxProposalDescriptionLen+1))
+	_, err = s.SubmitProposal(mains[0], "activeValidatorCount", value, longDesc, 10)
+	assert.Error(t, err)
+
+	id, err := s.SubmitProposal(mains[0], "activeValidatorCount", value, "desc", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), id)
+
+	p, err := s.GetProposal(id)
+	assert.NoError(t, err)
+	assert.Equal(t, ProposalPending, p.Status())
+	assert.Equal(t, int64(10)+s.GetProposalDuration(), p.EndHeight())
+	assert.Equal(t, value, p.Value())
+
+	ps, err := s.GetActiveProposals()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(ps))
+
+	// Only the proposer can cancel its proposal
+	err = s.CancelProposal(id, mains[1])
+	assert.Error(t, err)
+	err = s.CancelProposal(id, mains[0])
+	assert.NoError(t, err)
+	p, err = s.GetProposal(id)
+	assert.NoError(t, err)
+	assert.Equal(t, ProposalCanceled, p.Status())
+	ps, err = s.GetActiveProposals()
+	assert.NoError(t, err)
+	assert.Zero(t, len(ps))
+
+	_, err = s.GetProposal(id + 1)
+	assert.Error(t, err)
+}
+
+func TestState_VoteProposal(t *testing.T) {
+	s := newDummyState()
+	subs := registerDummyValidators(t, s, 1)
+	mains := registerDummyMainValidators(t, s, 4)
+	value := []byte(`{"count":"0x5"}`)
+
+	id, err := s.SubmitProposal(mains[0], "activeValidatorCount", value, "", 10)
+	assert.NoError(t, err)
+
+	_, err = s.VoteProposal(id, subs[0], true, 11)
+	assert.Error(t, err)
+
+	// More than 2/3 of 4 voters are required to approve a proposal
+	for i := 0; i < 2; i++ {
+		p, err := s.VoteProposal(id, mains[i], true, 11)
+		assert.NoError(t, err)
+		assert.Equal(t, ProposalPending, p.Status())
+	}
+	_, err = s.VoteProposal(id, mains[0], true, 11)
+	assert.Error(t, err)
+
+	agree, ok := s.GetProposalVote(id, mains[1])
+	assert.True(t, ok)
+	assert.True(t, agree)
+	_, ok = s.GetProposalVote(id, mains[2])
+	assert.False(t, ok)
+
+	p, err := s.VoteProposal(id, mains[2], true, 11)
+	assert.NoError(t, err)
+	assert.Equal(t, ProposalApproved, p.Status())
+
+	// Approved proposals remain active until they are applied
+	_, err = s.VoteProposal(id, mains[3], true, 11)
+	assert.Error(t, err)
+	ps, err := s.GetActiveProposals()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(ps))
+	err = s.CloseProposal(id, ProposalApplied)
+	assert.NoError(t, err)
+	err = s.CloseProposal(id, ProposalFailed)
+	assert.Error(t, err)
+
+	// A proposal is rejected when it cannot be approved any more
+	id, err = s.SubmitProposal(mains[0], "activeValidatorCount", value, "", 20)
+	assert.NoError(t, err)
+	p, err = s.VoteProposal(id, mains[0], false, 21)
+	assert.NoError(t, err)
+	assert.Equal(t, ProposalPending, p.Status())
+	p, err = s.VoteProposal(id, mains[1], false, 21)
+	assert.NoError(t, err)
+	assert.Equal(t, ProposalRejected, p.Status())
+	ps, err = s.GetActiveProposals()
+	assert.NoError(t, err)
+	assert.Zero(t, len(ps))
+
+	// Expired proposals cannot be voted
+	id, err = s.SubmitProposal(mains[0], "activeValidatorCount", value, "", 30)
+	assert.NoError(t, err)
+	_, err = s.VoteProposal(id, mains[0], true, 31+s.GetProposalDuration())
+	assert.Error(t, err)
+
+	// Main validators registered after the submission can't vote on the proposal
+	id, err = s.SubmitProposal(mains[0], "activeValidatorCount", value, "", 40)
+	assert.NoError(t, err)
+	newMain := newDummyAddress(200, false)
+	_, pubKey := crypto.GenerateKeyPair()
+	err = s.RegisterValidator(newMain, pubKey.SerializeCompressed(), GradeMain, "main-new", nil)
+	assert.NoError(t, err)
+	ok, err = s.IsProposalVoter(newMain)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = s.IsProposalVoterOf(id, newMain)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	_, err = s.VoteProposal(id, newMain, true, 41)
+	assert.Error(t, err)
+	p, err = s.GetProposal(id)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), p.ToJSON()["voterCount"])
+
+	// Unknown addresses are not voters
+	ok, err = s.IsProposalVoter(newDummyAddress(300, false))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
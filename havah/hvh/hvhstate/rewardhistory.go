@@ -0,0 +1,150 @@
+package hvhstate
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+// RewardHistory is the reward which a planet was offered in a term
+type RewardHistory struct {
+	termSeq int64
+	// Reward including a subsidy from HooverFund
+	total *big.Int
+	// Subsidy from HooverFund
+	hoover *big.Int
+	// Reward given to the planet owner; less than total only if the planet is a company planet
+	amount *big.Int
+}
+
+func newRewardHistory(termSeq int64, total, hoover, amount *big.Int) *RewardHistory {
+	return &RewardHistory{
+		termSeq: termSeq,
+		total:   total,
+		hoover:  hoover,
+		amount:  amount,
+	}
+}
+
+func newRewardHistoryFromBytes(b []byte) (*RewardHistory, error) {
+	rh := &RewardHistory{}
+	if _, err := codec.BC.UnmarshalFromBytes(b, rh); err != nil {
+		return nil, scoreresult.UnknownFailureError.Wrap(err, "Failed to create a RewardHistory from bytes")
+	}
+	return rh, nil
+}
+
+func (rh *RewardHistory) TermSequence() int64 {
+	return rh.termSeq
+}
+
+func (rh *RewardHistory) Total() *big.Int {
+	return rh.total
+}
+
+func (rh *RewardHistory) Hoover() *big.Int {
+	return rh.hoover
+}
+
+func (rh *RewardHistory) Amount() *big.Int {
+	return rh.amount
+}
+
+func (rh *RewardHistory) equal(other *RewardHistory) bool {
+	return rh.termSeq == other.termSeq &&
+		rh.total.Cmp(other.total) == 0 &&
+		rh.hoover.Cmp(other.hoover) == 0 &&
+		rh.amount.Cmp(other.amount) == 0
+}
+
+func (rh *RewardHistory) RLPDecodeSelf(d codec.Decoder) error {
+	return d.DecodeListOf(&rh.termSeq, &rh.total, &rh.hoover, &rh.amount)
+}
+
+func (rh *RewardHistory) RLPEncodeSelf(e codec.Encoder) error {
+	return e.EncodeListOf(rh.termSeq, rh.total, rh.hoover, rh.amount)
+}
+
+func (rh *RewardHistory) Bytes() []byte {
+	return codec.BC.MustMarshalToBytes(rh)
+}
+
+func (rh *RewardHistory) ToJSON() map[string]interface{} {
+	return map[string]interface{}{
+		"termSequence":     rh.termSeq,
+		"rewardWithHoover": rh.total,
+		"hooverRequest":    rh.hoover,
+		"ownerReward":      rh.amount,
+	}
+}
+
+func (rh *RewardHistory) String() string {
+	return fmt.Sprintf("RewardHistory(termSeq=%d,total=%d,hoover=%d,amount=%d)",
+		rh.termSeq, rh.total, rh.hoover, rh.amount)
+}
+
+// rewardTerms contains the term sequences in ascending order
+// at which the reward history of a planet is recorded
+type rewardTerms struct {
+	terms []int64
+}
+
+func newRewardTermsFromBytes(b []byte) (*rewardTerms, error) {
+	rt := &rewardTerms{}
+	if len(b) > 0 {
+		if _, err := codec.BC.UnmarshalFromBytes(b, rt); err != nil {
+			return nil, err
+		}
+	}
+	return rt, nil
+}
+
+func (rt *rewardTerms) Len() int {
+	return len(rt.terms)
+}
+
+func (rt *rewardTerms) Get(i int) int64 {
+	return rt.terms[i]
+}
+
+func (rt *rewardTerms) add(termSeq int64) error {
+	if size := len(rt.terms); size > 0 && termSeq <= rt.terms[size-1] {
+		return scoreresult.Errorf(
+			hvhmodule.StatusIllegalArgument,
+			"Invalid termSeq: termSeq=%d last=%d", termSeq, rt.terms[size-1])
+	}
+	rt.terms = append(rt.terms, termSeq)
+	return nil
+}
+
+// prune removes the term sequences which are out of the history period
+// and returns the removed ones
+func (rt *rewardTerms) prune(termSeq, period int64) []int64 {
+	i := 0
+	for ; i < len(rt.terms); i++ {
+		if rt.terms[i] > termSeq-period {
+			break
+		}
+	}
+	if i == 0 {
+		return nil
+	}
+	pruned := rt.terms[:i]
+	rt.terms = append([]int64{}, rt.terms[i:]...)
+	return pruned
+}
+
+func (rt *rewardTerms) RLPDecodeSelf(d codec.Decoder) error {
+	return d.Decode(&rt.terms)
+}
+
+func (rt *rewardTerms) RLPEncodeSelf(e codec.Encoder) error {
+	return e.Encode(rt.terms)
+}
+
+func (rt *rewardTerms) Bytes() []byte {
+	return codec.BC.MustMarshalToBytes(rt)
+}
@@ -0,0 +1,56 @@
+package hvhstate
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewardHistory_RLPEncodeSelf(t *testing.T) {
+	rh := newRewardHistory(10, big.NewInt(1000), big.NewInt(100), big.NewInt(400))
+
+	b := rh.Bytes()
+	rh2, err := newRewardHistoryFromBytes(b)
+	assert.NoError(t, err)
+	assert.True(t, rh.equal(rh2))
+	assert.Equal(t, int64(10), rh2.TermSequence())
+	assert.Zero(t, rh2.Total().Cmp(big.NewInt(1000)))
+	assert.Zero(t, rh2.Hoover().Cmp(big.NewInt(100)))
+	assert.Zero(t, rh2.Amount().Cmp(big.NewInt(400)))
+
+	jso := rh.ToJSON()
+	assert.Equal(t, int64(10), jso["termSequence"])
+	assert.Zero(t, jso["rewardWithHoover"].(*big.Int).Cmp(rh.Total()))
+	assert.Zero(t, jso["hooverRequest"].(*big.Int).Cmp(rh.Hoover()))
+	assert.Zero(t, jso["ownerReward"].(*big.Int).Cmp(rh.Amount()))
+}
+
+func TestRewardTerms_AddAndPrune(t *testing.T) {
+	rt, err := newRewardTermsFromBytes(nil)
+	assert.NoError(t, err)
+	assert.Zero(t, rt.Len())
+
+	for _, ts := range []int64{1, 3, 4, 7} {
+		assert.NoError(t, rt.add(ts))
+	}
+	assert.Error(t, rt.add(7))
+	assert.Error(t, rt.add(5))
+	assert.Equal(t, 4, rt.Len())
+
+	rt2, err := newRewardTermsFromBytes(rt.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, rt.terms, rt2.terms)
+
+	// Nothing to prune
+	assert.Nil(t, rt.prune(7, 10))
+	assert.Equal(t, 4, rt.Len())
+
+	pruned := rt.prune(7, 4)
+	assert.Equal(t, []int64{1, 3}, pruned)
+	assert.Equal(t, []int64{4, 7}, rt.terms)
+
+	pruned = rt.prune(20, 4)
+	assert.Equal(t, []int64{4, 7}, pruned)
+	assert.Zero(t, rt.Len())
+}
@@ -278,6 +278,21 @@ func (s *State) RegisterPlanet(
 	if err := allPlanetVarDB.Set(planetCount + 1); err != nil {
 		return err
 	}
+	if rev >= hvhmodule.RevisionPlanetOwnerIndex {
+		if err := s.addPlanetToIndex(id, owner); err != nil {
+			return err
+		}
+	}
+	if rev >= hvhmodule.RevisionPlanetWeight {
+		if err := s.countPlanetWeight(id, PlanetClassOf(p)); err != nil {
+			return err
+		}
+	}
+	if rev >= hvhmodule.RevisionVestingSchedule {
+		if err := s.assignClassVesting(id, p); err != nil {
+			return err
+		}
+	}
 
 	s.logger.Debugf(
 		"RegisterPlanet() end: height=%d planetCount=%d", height, planetCount+1)
@@ -293,7 +308,8 @@ func (s *State) UnregisterPlanet(rev int, id int64) (*big.Int, error) {
 
 	// Check if id exists
 	planetDictDB := s.getDictDB(hvhmodule.DictPlanet, 1)
-	if planetDictDB.Get(id) == nil {
+	value := planetDictDB.Get(id)
+	if value == nil {
 		return nil, scoreresult.Errorf(
 			hvhmodule.StatusIllegalArgument, "Planet not found: id=%d", id)
 	}
@@ -327,20 +343,75 @@ func (s *State) UnregisterPlanet(rev int, id int64) (*big.Int, error) {
 			return nil, err
 		}
 	}
+	if rev >= hvhmodule.RevisionPlanetRewardHistory {
+		if err = s.deleteRewardHistory(id); err != nil {
+			return nil, err
+		}
+	}
+	if rev >= hvhmodule.RevisionPlanetClaimDelegate {
+		if err = s.deletePlanetClaimConfig(id); err != nil {
+			return nil, err
+		}
+	}
+	p, err := newPlanetFromBytes(value.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if rev >= hvhmodule.RevisionPlanetOwnerIndex {
+		if err = s.removePlanetFromIndex(id, p.Owner()); err != nil {
+			return nil, err
+		}
+	}
+	if rev >= hvhmodule.RevisionVestingSchedule {
+		if err = s.deletePlanetVesting(id); err != nil {
+			return nil, err
+		}
+	}
+	if rev >= hvhmodule.RevisionPlanetWeight {
+		if err = s.deletePlanetWeight(id, PlanetClassOf(p)); err != nil {
+			return nil, err
+		}
+	}
+	if rev >= hvhmodule.RevisionPlanetNodeKey {
+		if err = s.deletePlanetNodeKey(id); err != nil {
+			return nil, err
+		}
+	}
 
 	return amount, nil
 }
 
-func (s *State) SetPlanetOwner(id int64, owner module.Address) error {
+func (s *State) SetPlanetOwner(rev int, id int64, owner module.Address) error {
 	planetDictDB := s.getDictDB(hvhmodule.DictPlanet, 1)
 	p, err := s.getPlanet(planetDictDB, id)
 	if err != nil {
 		return err
 	}
+	oldOwner := p.Owner()
 	err = p.setOwner(owner)
 	if err != nil {
 		return err
 	}
+	if rev >= hvhmodule.RevisionPlanetClaimDelegate && p.isDirty() {
+		// Claim delegate and reward beneficiary are designated by the previous owner
+		if err = s.deletePlanetClaimConfig(id); err != nil {
+			return err
+		}
+	}
+	if rev >= hvhmodule.RevisionPlanetNodeKey && p.isDirty() {
+		// The new owner runs its own node
+		if err = s.deletePlanetNodeKey(id); err != nil {
+			return err
+		}
+	}
+	if rev >= hvhmodule.RevisionPlanetOwnerIndex && p.isDirty() {
+		if err = s.removePlanetFromIndex(id, oldOwner); err != nil {
+			return err
+		}
+		if err = s.addPlanetToIndex(id, owner); err != nil {
+			return err
+		}
+	}
 	return s.setPlanet(planetDictDB, id, p)
 }
 
@@ -508,17 +579,24 @@ func (s *State) ClaimEcoSystemReward() (*big.Int, error) {
 	return reward, nil
 }
 
-func (s *State) ClaimPlanetReward(id, height int64, owner module.Address) (*big.Int, error) {
-	s.logger.Debugf("ClaimPlanetReward() start: id=%d height=%d owner=%s", id, height, owner)
+// ClaimPlanetReward is called by a planet owner or its claim delegate
+func (s *State) ClaimPlanetReward(id, height int64, from module.Address) (*big.Int, error) {
+	s.logger.Debugf("ClaimPlanetReward() start: id=%d height=%d from=%s", id, height, from)
 
 	p, err := s.GetPlanet(id)
 	if err != nil {
 		return nil, err
 	}
 
-	if !owner.Equal(p.Owner()) {
-		return nil, scoreresult.AccessDeniedError.Errorf(
-			"NoPermission: id=%d owner=%s from=%s", id, p.Owner(), owner)
+	if !from.Equal(p.Owner()) {
+		pcc, err := s.getPlanetClaimConfig(s.getDictDB(hvhmodule.DictPlanetClaimConfig, 1), id)
+		if err != nil {
+			return nil, err
+		}
+		if !from.Equal(pcc.Delegate()) {
+			return nil, scoreresult.AccessDeniedError.Errorf(
+				"NoPermission: id=%d owner=%s from=%s", id, p.Owner(), from)
+		}
 	}
 
 	pr, err := s.GetPlanetReward(id)
@@ -526,7 +604,7 @@ func (s *State) ClaimPlanetReward(id, height int64, owner module.Address) (*big.
 		return nil, err
 	}
 
-	claimableReward, err := s.calcClaimableReward(height, p, pr)
+	claimableReward, err := s.calcClaimableReward(height, id, p, pr)
 	if err != nil {
 		return nil, err
 	}
@@ -544,23 +622,52 @@ func (s *State) ClaimPlanetReward(id, height int64, owner module.Address) (*big.
 	return claimableReward, nil
 }
 
-func (s *State) calcClaimableReward(height int64, p *Planet, pr *planetReward) (*big.Int, error) {
+// GetClaimablePlanetRewards returns the sum of claimable rewards of up to MaxCountToClaim planets
+// from cursor in the owner-to-planets index, the number of the planets counted
+// and the cursor for the next call, which is 0 if there are no more planets
+func (s *State) GetClaimablePlanetRewards(
+	height int64, owner module.Address, cursor int64) (*big.Int, int, int64, error) {
+	if err := s.CheckPlanetIndexCompleted(); err != nil {
+		return nil, 0, 0, err
+	}
+	ids, size, err := s.GetPlanetsOf(owner, cursor, hvhmodule.MaxCountToClaim)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	total := new(big.Int)
+	for _, id := range ids {
+		p, err := s.GetPlanet(id)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		pr, err := s.GetPlanetReward(id)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		claimable, err := s.calcClaimableReward(height, id, p, pr)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		total.Add(total, claimable)
+	}
+	next := cursor + int64(len(ids))
+	if next >= int64(size) {
+		next = 0
+	}
+	return total, len(ids), next, nil
+}
+
+func (s *State) calcClaimableReward(height, id int64, p *Planet, pr *planetReward) (*big.Int, error) {
 	claimableReward := pr.Current()
-	if !p.IsPrivate() || claimableReward.Sign() == 0 {
+	if claimableReward.Sign() == 0 {
 		return claimableReward, nil
 	}
 
-	num, denom := s.GetPrivateClaimableRate()
-	if !validatePrivateClaimableRate(num, denom) {
-		return nil, scoreresult.InvalidParameterError.Errorf(
-			"InvalidPrivateClaimableRate: num=%d denom=%d", num, denom)
+	lockedReward, err := s.calcLockedReward(height, id, p, pr)
+	if err != nil {
+		return nil, err
 	}
-
-	if num < denom {
-		lockedReward := big.NewInt(denom - num)
-		lockedReward.Mul(lockedReward, pr.Total())
-		lockedReward.Div(lockedReward, big.NewInt(denom))
-
+	if lockedReward.Sign() > 0 {
 		claimableReward = new(big.Int).Sub(claimableReward, lockedReward)
 		if claimableReward.Sign() < 0 {
 			claimableReward.SetInt64(0)
@@ -653,7 +760,7 @@ func (s *State) GetRewardInfoOf(height, id int64) (map[string]interface{}, error
 		return nil, err
 	}
 
-	claimable, err := s.calcClaimableReward(height, p, pr)
+	claimable, err := s.calcClaimableReward(height, id, p, pr)
 	if err != nil {
 		return nil, err
 	}
@@ -666,11 +773,122 @@ func (s *State) GetRewardInfoOf(height, id int64) (map[string]interface{}, error
 	}, nil
 }
 
+// AddRewardHistory records the reward offered to a planet at a given term
+// and prunes the old history which is out of RewardHistoryPeriod
+func (s *State) AddRewardHistory(id, termSeq int64, total, hoover, amount *big.Int) error {
+	s.logger.Debugf(
+		"AddRewardHistory() start: id=%d termSeq=%d total=%d hoover=%d amount=%d",
+		id, termSeq, total, hoover, amount)
+
+	if err := validatePlanetId(id); err != nil {
+		return err
+	}
+	if termSeq < 0 {
+		return scoreresult.Errorf(
+			hvhmodule.StatusIllegalArgument, "Invalid termSeq: %d", termSeq)
+	}
+
+	termsDB := s.getDictDB(hvhmodule.DictPlanetRewardTerms, 1)
+	rt, err := s.getRewardTerms(termsDB, id)
+	if err != nil {
+		return err
+	}
+	if err = rt.add(termSeq); err != nil {
+		return err
+	}
+
+	historyDB := s.getDictDB(hvhmodule.DictPlanetRewardHistory, 2)
+	for _, ts := range rt.prune(termSeq, hvhmodule.RewardHistoryPeriod) {
+		if err = historyDB.Delete(id, ts); err != nil {
+			return err
+		}
+	}
+
+	rh := newRewardHistory(termSeq, total, hoover, amount)
+	if err = historyDB.Set(id, termSeq, rh.Bytes()); err != nil {
+		return err
+	}
+	err = termsDB.Set(id, rt.Bytes())
+
+	s.logger.Debugf("AddRewardHistory() end: id=%d terms=%d", id, rt.Len())
+	return err
+}
+
+// GetRewardHistory returns the reward history of a planet between from and to term sequences inclusive
+func (s *State) GetRewardHistory(id, from, to int64) ([]*RewardHistory, error) {
+	if err := validatePlanetId(id); err != nil {
+		return nil, err
+	}
+	if from < 0 || from > to {
+		return nil, scoreresult.Errorf(
+			hvhmodule.StatusIllegalArgument, "Invalid range: from=%d to=%d", from, to)
+	}
+
+	termsDB := s.getDictDB(hvhmodule.DictPlanetRewardTerms, 1)
+	rt, err := s.getRewardTerms(termsDB, id)
+	if err != nil {
+		return nil, err
+	}
+
+	historyDB := s.getDictDB(hvhmodule.DictPlanetRewardHistory, 2)
+	rhs := make([]*RewardHistory, 0)
+	for i := 0; i < rt.Len(); i++ {
+		ts := rt.Get(i)
+		if ts < from {
+			continue
+		}
+		if ts > to {
+			break
+		}
+		value := historyDB.Get(id, ts)
+		if value == nil {
+			return nil, errors.InvalidStateError.Errorf(
+				"RewardHistoryNotFound(id=%d,termSeq=%d)", id, ts)
+		}
+		rh, err := newRewardHistoryFromBytes(value.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		rhs = append(rhs, rh)
+	}
+	return rhs, nil
+}
+
+func (s *State) deleteRewardHistory(id int64) error {
+	termsDB := s.getDictDB(hvhmodule.DictPlanetRewardTerms, 1)
+	rt, err := s.getRewardTerms(termsDB, id)
+	if err != nil {
+		return err
+	}
+	if rt.Len() == 0 {
+		return nil
+	}
+
+	historyDB := s.getDictDB(hvhmodule.DictPlanetRewardHistory, 2)
+	for i := 0; i < rt.Len(); i++ {
+		if err = historyDB.Delete(id, rt.Get(i)); err != nil {
+			return err
+		}
+	}
+	return termsDB.Delete(id)
+}
+
+func (s *State) getRewardTerms(dictDB *containerdb.DictDB, id int64) (*rewardTerms, error) {
+	var b []byte
+	if value := dictDB.Get(id); value != nil {
+		b = value.Bytes()
+	}
+	return newRewardTermsFromBytes(b)
+}
+
+// GetActivePlanetCountAndReward returns the number of active planets and the reward for a planet of default weight
 func (s *State) GetActivePlanetCountAndReward() (*big.Int, *big.Int) {
 	activePlanets := s.getBigInt(hvhmodule.VarActivePlanet)
 	rewardPerActivePlanet := hvhmodule.BigIntZero
 
-	if activePlanets.Sign() > 0 {
+	if s.GetActivePlanetWeight().Sign() > 0 {
+		rewardPerActivePlanet = s.GetActivePlanetReward(hvhmodule.PlanetWeightDenom)
+	} else if activePlanets.Sign() > 0 {
 		rewardPerActivePlanet = new(big.Int).Div(
 			s.getBigInt(hvhmodule.VarRewardTotal), activePlanets)
 	}
@@ -686,6 +904,8 @@ type StateConfig struct {
 	IssueAmount  *common.HexInt `json:"issueAmount,omitempty"`  // 5M in HVH
 	HooverBudget *common.HexInt `json:"hooverBudget,omitempty"` // unit: HVH
 	USDTPrice    *common.HexInt `json:"usdtPrice"`              // unit: HVH
+
+	ValidatorRewardRate *common.HexInt64 `json:"validatorRewardRate,omitempty"` // unit: 1 / 10000
 }
 
 func (s *State) InitState(cfg *StateConfig) error {
@@ -724,6 +944,11 @@ func (s *State) InitState(cfg *StateConfig) error {
 	} else {
 		return scoreresult.InvalidParameterError.New("USDTPrice not found")
 	}
+	if cfg.ValidatorRewardRate != nil {
+		if err = s.SetValidatorRewardRate(cfg.ValidatorRewardRate.Value); err != nil {
+			return err
+		}
+	}
 
 	s.printInitState()
 	return nil
@@ -747,7 +972,7 @@ func (s *State) printInitState() {
 }
 
 func (s *State) SetPrivateClaimableRate(num, denom int64) error {
-	if !validatePrivateClaimableRate(num, denom) {
+	if !ValidatePrivateClaimableRate(num, denom) {
 		return scoreresult.InvalidParameterError.Errorf(
 			"InvalidPrivateClaimableRate: num=%d denom=%d", num, denom)
 	}
@@ -998,6 +1223,11 @@ func (s *State) UnregisterValidator(owner module.Address) (module.Address, error
 	if err = vsDB.Set(key, vs.Bytes()); err != nil {
 		return nil, err
 	}
+	if vs.Jailed() {
+		if err = s.removeJailedValidator(owner); err != nil {
+			return nil, err
+		}
+	}
 
 	// Remove it from validatorList based on its grade
 	var vi *ValidatorInfo
@@ -1189,9 +1419,16 @@ func (s *State) EnableValidator(owner module.Address, calledByGov bool) error {
 	if err != nil {
 		return err
 	}
+	jailed := vs.Jailed()
 	if err = vs.Enable(calledByGov); err != nil {
 		return err
 	}
+	if jailed && !vs.Jailed() {
+		// Governance releases the jailed validator before its jail term expires
+		if err = s.removeJailedValidator(owner); err != nil {
+			return err
+		}
+	}
 	vs.ResetNonVotes()
 	return db.Set(ToKey(owner), vs.Bytes())
 }
@@ -1221,6 +1458,7 @@ func (s *State) GetMainValidators(cc hvhmodule.CallContext, count int) ([]module
 
 	mvDB := s.getArrayDB(hvhmodule.ArrayMainValidators)
 	viDB := s.getDictDB(hvhmodule.DictValidatorInfo, 1)
+	vsDB := s.getDictDB(hvhmodule.DictValidatorStatus, 1)
 
 	bs := cc.GetBTPState()
 	btx := cc.GetBTPContext()
@@ -1236,6 +1474,11 @@ func (s *State) GetMainValidators(cc hvhmodule.CallContext, count int) ([]module
 		}
 		node := vi.Address()
 
+		// Slashed main validators are excluded from active validator set
+		if vs, err := s.getValidatorStatus(vsDB, owner); err == nil && vs.Slashed() {
+			continue
+		}
+
 		// Check if validator has a public key for BTP
 		if err = bs.CheckPublicKey(btx, node); err != nil {
 			continue
@@ -1445,3 +1688,92 @@ func NewStateFromSnapshot(ss *Snapshot, readonly bool, logger log.Logger) *State
 		cachedContainerDBs: make(map[string]interface{}),
 	}
 }
+
+func (s *State) GetPlanetClaimConfig(id int64) (*PlanetClaimConfig, error) {
+	if _, err := s.GetPlanet(id); err != nil {
+		return nil, err
+	}
+	dictDB := s.getDictDB(hvhmodule.DictPlanetClaimConfig, 1)
+	return s.getPlanetClaimConfig(dictDB, id)
+}
+
+// SetPlanetClaimDelegate designates an address which can claim the rewards of a planet
+// on behalf of its owner. Designating the owner itself removes the existing delegate
+func (s *State) SetPlanetClaimDelegate(id int64, owner, delegate module.Address) error {
+	s.logger.Debugf("SetPlanetClaimDelegate() start: id=%d owner=%s delegate=%s", id, owner, delegate)
+	err := s.updatePlanetClaimConfig(id, owner, delegate, func(pcc *PlanetClaimConfig, addr module.Address) {
+		pcc.setDelegate(addr)
+	})
+	s.logger.Debugf("SetPlanetClaimDelegate() end: err=%v", err)
+	return err
+}
+
+// SetPlanetRewardBeneficiary designates an address which receives the claimed rewards of a planet.
+// Designating the owner itself removes the existing beneficiary
+func (s *State) SetPlanetRewardBeneficiary(id int64, owner, beneficiary module.Address) error {
+	s.logger.Debugf(
+		"SetPlanetRewardBeneficiary() start: id=%d owner=%s beneficiary=%s", id, owner, beneficiary)
+	err := s.updatePlanetClaimConfig(id, owner, beneficiary, func(pcc *PlanetClaimConfig, addr module.Address) {
+		pcc.setBeneficiary(addr)
+	})
+	s.logger.Debugf("SetPlanetRewardBeneficiary() end: err=%v", err)
+	return err
+}
+
+func (s *State) updatePlanetClaimConfig(
+	id int64, owner, addr module.Address, update func(*PlanetClaimConfig, module.Address)) error {
+	if addr == nil {
+		return scoreresult.InvalidParameterError.New("InvalidArgument(addr=nil)")
+	}
+	p, err := s.GetPlanet(id)
+	if err != nil {
+		return err
+	}
+	if !owner.Equal(p.Owner()) {
+		return scoreresult.AccessDeniedError.Errorf(
+			"NoPermission: id=%d owner=%s from=%s", id, p.Owner(), owner)
+	}
+
+	dictDB := s.getDictDB(hvhmodule.DictPlanetClaimConfig, 1)
+	pcc, err := s.getPlanetClaimConfig(dictDB, id)
+	if err != nil {
+		return err
+	}
+	if addr.Equal(owner) {
+		addr = nil
+	}
+	update(pcc, addr)
+	if pcc.IsEmpty() {
+		return dictDB.Delete(id)
+	}
+	return dictDB.Set(id, pcc.Bytes())
+}
+
+// GetRewardBeneficiary returns the address which receives the claimed rewards of a planet
+func (s *State) GetRewardBeneficiary(id int64) (module.Address, error) {
+	p, err := s.GetPlanet(id)
+	if err != nil {
+		return nil, err
+	}
+	pcc, err := s.getPlanetClaimConfig(s.getDictDB(hvhmodule.DictPlanetClaimConfig, 1), id)
+	if err != nil {
+		return nil, err
+	}
+	if beneficiary := pcc.Beneficiary(); beneficiary != nil {
+		return beneficiary, nil
+	}
+	return p.Owner(), nil
+}
+
+func (s *State) getPlanetClaimConfig(dictDB *containerdb.DictDB, id int64) (*PlanetClaimConfig, error) {
+	var b []byte
+	if value := dictDB.Get(id); value != nil {
+		b = value.Bytes()
+	}
+	return newPlanetClaimConfigFromBytes(b)
+}
+
+func (s *State) deletePlanetClaimConfig(id int64) error {
+	dictDB := s.getDictDB(hvhmodule.DictPlanetClaimConfig, 1)
+	return dictDB.Delete(id)
+}
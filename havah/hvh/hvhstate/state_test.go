@@ -341,20 +341,20 @@ func TestState_SetPlanetOwner(t *testing.T) {
 	assert.NoError(t, err)
 	assert.False(t, newOwner.Equal(planet.Owner()))
 
-	err = s.SetPlanetOwner(id, newOwner)
+	err = s.SetPlanetOwner(hvhmodule.Revision1, id, newOwner)
 	assert.NoError(t, err)
 	planet, err = s.GetPlanet(id)
 	assert.NoError(t, err)
 	assert.True(t, newOwner.Equal(planet.Owner()))
 
 	// Invalid planet id
-	err = s.SetPlanetOwner(int64(100), owner)
+	err = s.SetPlanetOwner(hvhmodule.Revision1, int64(100), owner)
 	assert.Error(t, err)
 	planet, _ = s.GetPlanet(id)
 	assert.True(t, newOwner.Equal(planet.Owner()))
 
 	// Invalid owner
-	err = s.SetPlanetOwner(id, nil)
+	err = s.SetPlanetOwner(hvhmodule.Revision1, id, nil)
 	assert.Error(t, err)
 	planet, _ = s.GetPlanet(id)
 	assert.True(t, newOwner.Equal(planet.Owner()))
@@ -569,7 +569,7 @@ func TestState_calcClaimableReward(t *testing.T) {
 	p2 := deepCopyPlanet(t, p)
 	pr2 := deepCopyPlanetReward(t, pr)
 
-	reward, err := s.calcClaimableReward(height, p, pr)
+	reward, err := s.calcClaimableReward(height, 1, p, pr)
 	assert.NoError(t, err)
 	assert.Zero(t, reward.Cmp(amount))
 	assert.True(t, p.equal(p2))
@@ -1724,3 +1724,165 @@ func TestState_SetNodePublicKey(t *testing.T) {
 	assert.True(t, vi.Address().Equal(common.NewAccountAddressFromPublicKey(publicKey)))
 	assert.False(t, vi.Address().Equal(oNode))
 }
+
+func TestState_AddRewardHistory(t *testing.T) {
+	var err error
+	id := int64(1)
+	period := int64(hvhmodule.RewardHistoryPeriod)
+	s := newDummyState()
+
+	rhs, err := s.GetRewardHistory(id, 0, period)
+	assert.NoError(t, err)
+	assert.Zero(t, len(rhs))
+
+	// Reward history is recorded only for the terms when the planet work is reported
+	termSeqs := []int64{0, 1, 3, period - 1, period, period + 3}
+	for i, ts := range termSeqs {
+		total := toHVH(int64(i + 10))
+		hoover := toHVH(int64(i))
+		err = s.AddRewardHistory(id, ts, total, hoover, total)
+		assert.NoError(t, err)
+	}
+
+	// Duplicate or old termSeq is not allowed
+	err = s.AddRewardHistory(id, period+3, toHVH(1), toHVH(0), toHVH(1))
+	assert.Error(t, err)
+	err = s.AddRewardHistory(id, period+2, toHVH(1), toHVH(0), toHVH(1))
+	assert.Error(t, err)
+
+	// Old history out of RewardHistoryPeriod was pruned
+	rhs, err = s.GetRewardHistory(id, 0, period+3)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(rhs))
+	for i, rh := range rhs {
+		j := i + 3
+		assert.Equal(t, termSeqs[j], rh.TermSequence())
+		assert.Zero(t, toHVH(int64(j+10)).Cmp(rh.Total()))
+		assert.Zero(t, toHVH(int64(j)).Cmp(rh.Hoover()))
+		assert.Zero(t, rh.Total().Cmp(rh.Amount()))
+	}
+	historyDB := s.getDictDB(hvhmodule.DictPlanetRewardHistory, 2)
+	for _, ts := range termSeqs[:3] {
+		assert.Nil(t, historyDB.Get(id, ts))
+	}
+
+	rhs, err = s.GetRewardHistory(id, period, period+2)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rhs))
+	assert.Equal(t, period, rhs[0].TermSequence())
+
+	// Invalid ranges
+	_, err = s.GetRewardHistory(id, -1, period)
+	assert.Error(t, err)
+	_, err = s.GetRewardHistory(id, period, period-1)
+	assert.Error(t, err)
+	_, err = s.GetRewardHistory(-1, 0, period)
+	assert.Error(t, err)
+}
+
+func TestState_UnregisterPlanetWithRewardHistory(t *testing.T) {
+	var err error
+	id := int64(1)
+	owner := common.MustNewAddressFromString("hx1")
+	s := newDummyState()
+
+	for _, rev := range []int{hvhmodule.Revision7, hvhmodule.RevisionPlanetRewardHistory} {
+		err = s.RegisterPlanet(rev, id, false, false, owner, toUSDT(10), toHVH(1), 5)
+		assert.NoError(t, err)
+
+		for ts := int64(0); ts < 3; ts++ {
+			err = s.AddRewardHistory(id, ts, toHVH(1), toHVH(0), toHVH(1))
+			assert.NoError(t, err)
+		}
+
+		_, err = s.UnregisterPlanet(rev, id)
+		assert.NoError(t, err)
+
+		rhs, err := s.GetRewardHistory(id, 0, 2)
+		assert.NoError(t, err)
+		if rev < hvhmodule.RevisionPlanetRewardHistory {
+			assert.Equal(t, 3, len(rhs))
+			assert.NoError(t, s.deleteRewardHistory(id))
+		} else {
+			assert.Zero(t, len(rhs))
+		}
+	}
+}
+
+func TestState_PlanetClaimConfig(t *testing.T) {
+	var err error
+	id := int64(1)
+	owner := common.MustNewAddressFromString("hx1")
+	delegate := common.MustNewAddressFromString("hx2")
+	beneficiary := common.MustNewAddressFromString("hx3")
+	newOwner := common.MustNewAddressFromString("hx4")
+	rev := hvhmodule.RevisionPlanetClaimDelegate
+	s := newDummyState()
+
+	_, err = s.GetPlanetClaimConfig(id)
+	assert.Error(t, err)
+
+	err = s.RegisterPlanet(rev, id, false, false, owner, toUSDT(10), toHVH(1), 5)
+	assert.NoError(t, err)
+
+	pcc, err := s.GetPlanetClaimConfig(id)
+	assert.NoError(t, err)
+	assert.True(t, pcc.IsEmpty())
+	to, err := s.GetRewardBeneficiary(id)
+	assert.NoError(t, err)
+	assert.True(t, to.Equal(owner))
+
+	// Only the planet owner can set a delegate and a beneficiary
+	assert.Error(t, s.SetPlanetClaimDelegate(id, delegate, delegate))
+	assert.Error(t, s.SetPlanetRewardBeneficiary(id, delegate, beneficiary))
+	assert.Error(t, s.SetPlanetClaimDelegate(id, owner, nil))
+	assert.Error(t, s.SetPlanetClaimDelegate(int64(2), owner, delegate))
+
+	assert.NoError(t, s.SetPlanetClaimDelegate(id, owner, delegate))
+	assert.NoError(t, s.SetPlanetRewardBeneficiary(id, owner, beneficiary))
+	pcc, err = s.GetPlanetClaimConfig(id)
+	assert.NoError(t, err)
+	assert.True(t, pcc.Delegate().Equal(delegate))
+	assert.True(t, pcc.Beneficiary().Equal(beneficiary))
+	to, err = s.GetRewardBeneficiary(id)
+	assert.NoError(t, err)
+	assert.True(t, to.Equal(beneficiary))
+
+	pcc2, err := newPlanetClaimConfigFromBytes(pcc.Bytes())
+	assert.NoError(t, err)
+	assert.True(t, pcc.equal(pcc2))
+
+	// The delegate can claim rewards but others can't
+	height := int64(100)
+	_, err = s.ClaimPlanetReward(id, height, delegate)
+	assert.NoError(t, err)
+	_, err = s.ClaimPlanetReward(id, height, beneficiary)
+	assert.Error(t, err)
+
+	// Designating the owner itself removes the delegate
+	assert.NoError(t, s.SetPlanetClaimDelegate(id, owner, owner))
+	pcc, err = s.GetPlanetClaimConfig(id)
+	assert.NoError(t, err)
+	assert.Nil(t, pcc.Delegate())
+	assert.True(t, pcc.Beneficiary().Equal(beneficiary))
+	_, err = s.ClaimPlanetReward(id, height, delegate)
+	assert.Error(t, err)
+
+	// Changing the planet owner resets the claim config
+	assert.NoError(t, s.SetPlanetClaimDelegate(id, owner, delegate))
+	assert.NoError(t, s.SetPlanetOwner(rev, id, newOwner))
+	pcc, err = s.GetPlanetClaimConfig(id)
+	assert.NoError(t, err)
+	assert.True(t, pcc.IsEmpty())
+	to, err = s.GetRewardBeneficiary(id)
+	assert.NoError(t, err)
+	assert.True(t, to.Equal(newOwner))
+
+	// Unregistering a planet removes its claim config
+	assert.NoError(t, s.SetPlanetRewardBeneficiary(id, newOwner, beneficiary))
+	_, err = s.UnregisterPlanet(rev, id)
+	assert.NoError(t, err)
+	pcc, err = s.getPlanetClaimConfig(s.getDictDB(hvhmodule.DictPlanetClaimConfig, 1), id)
+	assert.NoError(t, err)
+	assert.True(t, pcc.IsEmpty())
+}
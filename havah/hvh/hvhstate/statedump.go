@@ -0,0 +1,293 @@
+package hvhstate
+
+import (
+	"math/big"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+// PlanetDump is a readable form of a planet and its reward
+type PlanetDump struct {
+	ID             common.HexInt64 `json:"id"`
+	Owner          *common.Address `json:"owner"`
+	IsPrivate      common.HexBool  `json:"isPrivate"`
+	IsCompany      common.HexBool  `json:"isCompany"`
+	USDT           *common.HexInt  `json:"usdt"`
+	Price          *common.HexInt  `json:"price"`
+	Height         common.HexInt64 `json:"height"`
+	RewardTotal    *common.HexInt  `json:"rewardTotal"`
+	RewardRemain   *common.HexInt  `json:"rewardRemain"`
+	LastTermNumber common.HexInt64 `json:"lastTermNumber"`
+}
+
+// ValidatorDump is a readable form of a validator and its status
+type ValidatorDump struct {
+	Owner         *common.Address `json:"owner"`
+	NodePublicKey common.HexBytes `json:"nodePublicKey"`
+	Grade         string          `json:"grade"`
+	Name          string          `json:"name"`
+	URL           string          `json:"url,omitempty"`
+	Disabled      common.HexBool  `json:"disabled"`
+	Disqualified  common.HexBool  `json:"disqualified"`
+}
+
+// RewardStatusDump shows how much reward has been issued and used.
+// It is informational and not imported because a new network issues its own rewards
+type RewardStatusDump struct {
+	ActivePlanet    common.HexInt64 `json:"activePlanet"`
+	WorkingPlanet   common.HexInt64 `json:"workingPlanet"`
+	ActiveUSDTPrice *common.HexInt  `json:"activeUSDTPrice"`
+	RewardTotal     *common.HexInt  `json:"rewardTotal"`
+	RewardRemain    *common.HexInt  `json:"rewardRemain"`
+	EcoSystemReward *common.HexInt  `json:"ecoSystemReward"`
+	Lost            *common.HexInt  `json:"lost"`
+}
+
+// StateDump is a readable form of the HAVAH extension state.
+// Config and RewardStatus are filled in by ExportState and ignored by ImportState
+type StateDump struct {
+	Config       *StateConfig      `json:"config,omitempty"`
+	IssueStart   *common.HexInt64  `json:"issueStart,omitempty"`
+	RewardStatus *RewardStatusDump `json:"rewardStatus,omitempty"`
+
+	PrivateClaimableRate *PrivateClaimableRateDump `json:"privateClaimableRate,omitempty"`
+	BlockVoteCheckPeriod *common.HexInt64          `json:"blockVoteCheckPeriod,omitempty"`
+	NonVoteAllowance     *common.HexInt64          `json:"nonVoteAllowance,omitempty"`
+	ActiveValidatorCount *common.HexInt64          `json:"activeValidatorCount,omitempty"`
+
+	PlanetManagers []*common.Address `json:"planetManagers"`
+	Planets        []*PlanetDump     `json:"planets"`
+	Validators     []*ValidatorDump  `json:"validators"`
+}
+
+type PrivateClaimableRateDump struct {
+	Numerator   common.HexInt64 `json:"numerator"`
+	Denominator common.HexInt64 `json:"denominator"`
+}
+
+func newPlanetDump(id int64, p *Planet, pr *planetReward) *PlanetDump {
+	return &PlanetDump{
+		ID:             common.HexInt64{Value: id},
+		Owner:          common.AddressToPtr(p.Owner()),
+		IsPrivate:      common.HexBool{Value: p.IsPrivate()},
+		IsCompany:      common.HexBool{Value: p.IsCompany()},
+		USDT:           new(common.HexInt).SetValue(p.USDT()),
+		Price:          new(common.HexInt).SetValue(p.Price()),
+		Height:         common.HexInt64{Value: p.Height()},
+		RewardTotal:    new(common.HexInt).SetValue(pr.Total()),
+		RewardRemain:   new(common.HexInt).SetValue(pr.Current()),
+		LastTermNumber: common.HexInt64{Value: pr.LastTermNumber()},
+	}
+}
+
+func (s *State) exportPlanets(maxPlanetID int64) ([]*PlanetDump, error) {
+	allPlanet := s.getInt64(hvhmodule.VarAllPlanet)
+	planets := make([]*PlanetDump, 0, allPlanet)
+
+	// Planet ids are given by PlanetNFT, so they are looked up one by one
+	planetDictDB := s.getDictDB(hvhmodule.DictPlanet, 1)
+	for id := int64(0); id <= maxPlanetID && int64(len(planets)) < allPlanet; id++ {
+		if planetDictDB.Get(id) == nil {
+			continue
+		}
+		p, err := s.GetPlanet(id)
+		if err != nil {
+			return nil, err
+		}
+		pr, err := s.GetPlanetReward(id)
+		if err != nil {
+			return nil, err
+		}
+		planets = append(planets, newPlanetDump(id, p, pr))
+	}
+	if int64(len(planets)) < allPlanet {
+		return nil, errors.NotFoundError.Errorf(
+			"PlanetsNotFound(found=%d,allPlanet=%d,maxPlanetID=%d)", len(planets), allPlanet, maxPlanetID)
+	}
+	return planets, nil
+}
+
+func (s *State) exportValidators() ([]*ValidatorDump, error) {
+	owners, err := s.GetValidatorsOf(GradeFilterAll)
+	if err != nil {
+		return nil, err
+	}
+	disqualified, err := s.GetDisqualifiedValidators()
+	if err != nil {
+		return nil, err
+	}
+	owners = append(owners, disqualified...)
+
+	validators := make([]*ValidatorDump, len(owners))
+	for i, owner := range owners {
+		vi, err := s.GetValidatorInfo(owner)
+		if err != nil {
+			return nil, err
+		}
+		vs, err := s.GetValidatorStatus(owner)
+		if err != nil {
+			return nil, err
+		}
+		validators[i] = &ValidatorDump{
+			Owner:         common.AddressToPtr(owner),
+			NodePublicKey: vi.PublicKey().SerializeCompressed(),
+			Grade:         vi.Grade().String(),
+			Name:          vi.Name(),
+			URL:           vi.Url(),
+			Disabled:      common.HexBool{Value: vs.Disabled()},
+			Disqualified:  common.HexBool{Value: vs.Disqualified()},
+		}
+	}
+	return validators, nil
+}
+
+func (s *State) getPlanetManagers() []*common.Address {
+	arrayDB := s.getArrayDB(hvhmodule.ArrayPlanetManager)
+	size := arrayDB.Size()
+	managers := make([]*common.Address, size)
+	for i := 0; i < size; i++ {
+		managers[i] = common.AddressToPtr(arrayDB.Get(i).Address())
+	}
+	return managers
+}
+
+// ExportState returns the readable form of the state.
+// Planets are looked up from id 0 to maxPlanetID until all registered planets are found
+func (s *State) ExportState(maxPlanetID int64) (*StateDump, error) {
+	planets, err := s.exportPlanets(maxPlanetID)
+	if err != nil {
+		return nil, err
+	}
+	validators, err := s.exportValidators()
+	if err != nil {
+		return nil, err
+	}
+	lost, err := s.GetLost()
+	if err != nil {
+		return nil, err
+	}
+	num, denom := s.GetPrivateClaimableRate()
+
+	return &StateDump{
+		Config: &StateConfig{
+			TermPeriod:          &common.HexInt64{Value: s.GetTermPeriod()},
+			IssueReductionCycle: &common.HexInt64{Value: s.GetIssueReductionCycle()},
+			IssueLimit:          &common.HexInt64{Value: s.GetIssueLimit()},
+			IssueAmount: new(common.HexInt).SetValue(
+				s.getBigIntOrDefault(hvhmodule.VarIssueAmount, hvhmodule.BigIntInitIssueAmount)),
+			HooverBudget:        new(common.HexInt).SetValue(s.GetHooverBudget()),
+			USDTPrice:           new(common.HexInt).SetValue(s.GetUSDTPrice()),
+			ValidatorRewardRate: &common.HexInt64{Value: s.GetValidatorRewardRate()},
+		},
+		IssueStart: &common.HexInt64{Value: s.GetIssueStart()},
+		RewardStatus: &RewardStatusDump{
+			ActivePlanet:    common.HexInt64{Value: s.getInt64(hvhmodule.VarActivePlanet)},
+			WorkingPlanet:   common.HexInt64{Value: s.getInt64(hvhmodule.VarWorkingPlanet)},
+			ActiveUSDTPrice: new(common.HexInt).SetValue(s.GetActiveUSDTPrice()),
+			RewardTotal:     new(common.HexInt).SetValue(s.getBigInt(hvhmodule.VarRewardTotal)),
+			RewardRemain:    new(common.HexInt).SetValue(s.getBigInt(hvhmodule.VarRewardRemain)),
+			EcoSystemReward: new(common.HexInt).SetValue(s.getBigInt(hvhmodule.VarEcoReward)),
+			Lost:            new(common.HexInt).SetValue(lost),
+		},
+		PrivateClaimableRate: &PrivateClaimableRateDump{
+			Numerator:   common.HexInt64{Value: num},
+			Denominator: common.HexInt64{Value: denom},
+		},
+		BlockVoteCheckPeriod: &common.HexInt64{Value: s.GetBlockVoteCheckPeriod()},
+		NonVoteAllowance:     &common.HexInt64{Value: s.GetNonVoteAllowance()},
+		ActiveValidatorCount: &common.HexInt64{Value: s.GetActiveValidatorCount()},
+		PlanetManagers:       s.getPlanetManagers(),
+		Planets:              planets,
+		Validators:           validators,
+	}, nil
+}
+
+// ImportState loads a dump into the state of a new network at genesis.
+// Planets are registered at height 0 with their rewards and term numbers are reset,
+// because the terms of the new network start over.
+// Disqualified validators are not imported
+func (s *State) ImportState(rev int, d *StateDump) error {
+	s.logger.Debugf(
+		"ImportState() start: rev=%d planets=%d validators=%d", rev, len(d.Planets), len(d.Validators))
+
+	if r := d.PrivateClaimableRate; r != nil {
+		if err := s.SetPrivateClaimableRate(r.Numerator.Value, r.Denominator.Value); err != nil {
+			return err
+		}
+	}
+	if d.BlockVoteCheckPeriod != nil && d.NonVoteAllowance != nil {
+		if err := s.SetBlockVoteCheckParameters(
+			d.BlockVoteCheckPeriod.Value, d.NonVoteAllowance.Value); err != nil {
+			return err
+		}
+	}
+	if d.ActiveValidatorCount != nil && d.ActiveValidatorCount.Value > 0 {
+		if err := s.SetActiveValidatorCount(d.ActiveValidatorCount.Value); err != nil {
+			return err
+		}
+	}
+
+	for _, pm := range d.PlanetManagers {
+		if err := s.AddPlanetManager(pm); err != nil {
+			return err
+		}
+	}
+
+	for _, pd := range d.Planets {
+		if pd.Owner == nil || pd.USDT == nil || pd.Price == nil {
+			return scoreresult.InvalidParameterError.Errorf("InvalidPlanetDump(id=%d)", pd.ID.Value)
+		}
+		id := pd.ID.Value
+		if err := s.RegisterPlanet(
+			rev, id, pd.IsPrivate.Value, pd.IsCompany.Value, pd.Owner,
+			pd.USDT.Value(), pd.Price.Value(), 0); err != nil {
+			return err
+		}
+		pr := newEmpyPlanetReward()
+		if pd.RewardTotal != nil {
+			pr.total = new(big.Int).Set(pd.RewardTotal.Value())
+		}
+		if pd.RewardRemain != nil {
+			pr.current = new(big.Int).Set(pd.RewardRemain.Value())
+		}
+		if pr.current.Sign() < 0 || pr.current.Cmp(pr.total) > 0 {
+			return scoreresult.InvalidParameterError.Errorf(
+				"InvalidPlanetReward(id=%d,total=%d,remain=%d)", id, pr.total, pr.current)
+		}
+		if err := s.setPlanetReward(id, pr); err != nil {
+			return err
+		}
+	}
+
+	for _, vd := range d.Validators {
+		if vd.Disqualified.Value {
+			continue
+		}
+		if err := s.importValidator(vd); err != nil {
+			return err
+		}
+	}
+
+	s.logger.Debugf("ImportState() end")
+	return nil
+}
+
+func (s *State) importValidator(vd *ValidatorDump) error {
+	grade := StringToGrade(vd.Grade)
+	if vd.Owner == nil || !grade.IsValid() {
+		return scoreresult.InvalidParameterError.Errorf("InvalidValidatorDump(owner=%s)", vd.Owner)
+	}
+	var owner module.Address = vd.Owner
+	url := vd.URL
+	if err := s.RegisterValidator(owner, vd.NodePublicKey, grade, vd.Name, &url); err != nil {
+		return err
+	}
+	if vd.Disabled.Value {
+		return s.DisableValidator(owner)
+	}
+	return nil
+}
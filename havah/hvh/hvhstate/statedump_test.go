@@ -0,0 +1,98 @@
+package hvhstate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+)
+
+func TestState_ExportAndImportState(t *testing.T) {
+	var err error
+	rev := hvhmodule.RevisionPlanetOwnerIndex
+	s := newDummyState()
+
+	pm := newDummyAddress(100, false)
+	err = s.AddPlanetManager(pm)
+	assert.NoError(t, err)
+	err = s.SetPrivateClaimableRate(1, 4)
+	assert.NoError(t, err)
+
+	owner := newDummyAddress(1, false)
+	ids := []int64{3, 7, 20}
+	for _, id := range ids {
+		err = s.RegisterPlanet(rev, id, id == 7, id == 20, owner, toUSDT(1000), toHVH(1000), 10)
+		assert.NoError(t, err)
+	}
+	err = s.setPlanetReward(7, newPlanetReward(toHVH(100), toHVH(40), 5))
+	assert.NoError(t, err)
+
+	grades := []Grade{GradeMain, GradeSub, GradeSub}
+	for i, grade := range grades {
+		_, pubKey := crypto.GenerateKeyPair()
+		err = s.RegisterValidator(
+			newDummyAddress(10+i, false), pubKey.SerializeCompressed(), grade, "validator", nil)
+		assert.NoError(t, err)
+	}
+	err = s.DisableValidator(newDummyAddress(11, false))
+	assert.NoError(t, err)
+	_, err = s.UnregisterValidator(newDummyAddress(12, false))
+	assert.NoError(t, err)
+
+	// Some planets are beyond maxPlanetID
+	_, err = s.ExportState(10)
+	assert.Error(t, err)
+
+	dump, err := s.ExportState(100)
+	assert.NoError(t, err)
+	assert.Equal(t, len(ids), len(dump.Planets))
+	for i, id := range ids {
+		assert.Equal(t, id, dump.Planets[i].ID.Value)
+	}
+	assert.Equal(t, 3, len(dump.Validators))
+	assert.Equal(t, 1, len(dump.PlanetManagers))
+
+	s2 := newDummyState()
+	err = s2.ImportState(rev, dump)
+	assert.NoError(t, err)
+
+	ok, err := s2.IsPlanetManager(pm)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	num, denom := s2.GetPrivateClaimableRate()
+	assert.Equal(t, int64(1), num)
+	assert.Equal(t, int64(4), denom)
+	checkAllPlanet(t, s2, int64(len(ids)))
+
+	for _, id := range ids {
+		p, err := s2.GetPlanet(id)
+		assert.NoError(t, err)
+		assert.Equal(t, id == 7, p.IsPrivate())
+		assert.Equal(t, id == 20, p.IsCompany())
+		assert.Zero(t, p.Height())
+		assert.True(t, owner.Equal(p.Owner()))
+	}
+	pr, err := s2.GetPlanetReward(7)
+	assert.NoError(t, err)
+	assert.Zero(t, pr.Total().Cmp(toHVH(100)))
+	assert.Zero(t, pr.Current().Cmp(toHVH(40)))
+	assert.Zero(t, pr.LastTermNumber())
+
+	// The disqualified validator is not imported
+	owners, err := s2.GetValidatorsOf(GradeFilterAll)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(owners))
+	vs, err := s2.GetValidatorStatus(newDummyAddress(11, false))
+	assert.NoError(t, err)
+	assert.True(t, vs.Disabled())
+
+	// Remaining reward cannot exceed its total
+	dump.Planets = dump.Planets[1:2]
+	dump.Planets[0].RewardRemain.Set(toHVH(200))
+	dump.Validators = nil
+	dump.PlanetManagers = nil
+	err = newDummyState().ImportState(rev, dump)
+	assert.Error(t, err)
+}
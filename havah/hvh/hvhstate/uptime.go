@@ -0,0 +1,125 @@
+package hvhstate
+
+import (
+	"fmt"
+
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+// ValidatorUptime contains the counters accumulated at every block after decentralization.
+// They are never reset, so the rate over a period can be calculated from the difference of two queries
+type ValidatorUptime struct {
+	votes     int64 // the number of blocks which a validator voted for
+	misses    int64 // the number of blocks which a validator missed
+	proposals int64 // the number of blocks which a validator proposed
+	lastSeen  int64 // the last height where a validator voted for or proposed a block
+}
+
+func newValidatorUptimeFromBytes(b []byte) (*ValidatorUptime, error) {
+	vu := &ValidatorUptime{}
+	if len(b) > 0 {
+		if _, err := codec.BC.UnmarshalFromBytes(b, vu); err != nil {
+			return nil, scoreresult.UnknownFailureError.Wrap(
+				err, "Failed to create a ValidatorUptime from bytes")
+		}
+	}
+	return vu, nil
+}
+
+func (vu *ValidatorUptime) Votes() int64 {
+	return vu.votes
+}
+
+func (vu *ValidatorUptime) Misses() int64 {
+	return vu.misses
+}
+
+func (vu *ValidatorUptime) Proposals() int64 {
+	return vu.proposals
+}
+
+func (vu *ValidatorUptime) LastSeen() int64 {
+	return vu.lastSeen
+}
+
+func (vu *ValidatorUptime) onVote(vote bool, height int64) {
+	if vote {
+		vu.votes++
+		vu.seen(height)
+	} else {
+		vu.misses++
+	}
+}
+
+func (vu *ValidatorUptime) onProposal(height int64) {
+	vu.proposals++
+	vu.seen(height)
+}
+
+func (vu *ValidatorUptime) seen(height int64) {
+	if height > vu.lastSeen {
+		vu.lastSeen = height
+	}
+}
+
+func (vu *ValidatorUptime) RLPDecodeSelf(d codec.Decoder) error {
+	return d.DecodeListOf(&vu.votes, &vu.misses, &vu.proposals, &vu.lastSeen)
+}
+
+func (vu *ValidatorUptime) RLPEncodeSelf(e codec.Encoder) error {
+	return e.EncodeListOf(vu.votes, vu.misses, vu.proposals, vu.lastSeen)
+}
+
+func (vu *ValidatorUptime) Bytes() []byte {
+	return codec.BC.MustMarshalToBytes(vu)
+}
+
+func (vu *ValidatorUptime) ToJSON() map[string]interface{} {
+	return map[string]interface{}{
+		"votes":     vu.votes,
+		"misses":    vu.misses,
+		"proposals": vu.proposals,
+		"lastSeen":  vu.lastSeen,
+	}
+}
+
+func (vu *ValidatorUptime) String() string {
+	return fmt.Sprintf(
+		"ValidatorUptime(votes=%d,misses=%d,proposals=%d,lastSeen=%d)",
+		vu.votes, vu.misses, vu.proposals, vu.lastSeen)
+}
+
+func (s *State) GetValidatorUptime(owner module.Address) (*ValidatorUptime, error) {
+	var b []byte
+	if v := s.getDictDB(hvhmodule.DictValidatorUptime, 1).Get(ToKey(owner)); v != nil {
+		b = v.Bytes()
+	}
+	return newValidatorUptimeFromBytes(b)
+}
+
+func (s *State) setValidatorUptime(owner module.Address, vu *ValidatorUptime) error {
+	return s.getDictDB(hvhmodule.DictValidatorUptime, 1).Set(ToKey(owner), vu.Bytes())
+}
+
+// RecordBlockVote updates the uptime of a validator with its block vote at a given height
+func (s *State) RecordBlockVote(owner module.Address, vote bool, height int64) error {
+	vu, err := s.GetValidatorUptime(owner)
+	if err != nil {
+		return err
+	}
+	vu.onVote(vote, height)
+	return s.setValidatorUptime(owner, vu)
+}
+
+// RecordProposal updates the uptime of a validator which proposed a block at a given height
+func (s *State) RecordProposal(owner module.Address, height int64) error {
+	vu, err := s.GetValidatorUptime(owner)
+	if err != nil {
+		return err
+	}
+	vu.onProposal(height)
+	return s.setValidatorUptime(owner, vu)
+}
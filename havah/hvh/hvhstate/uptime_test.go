@@ -0,0 +1,38 @@
+package hvhstate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState_ValidatorUptime(t *testing.T) {
+	var err error
+	s := newDummyState()
+	owner := newDummyAddress(1, false)
+
+	vu, err := s.GetValidatorUptime(owner)
+	assert.NoError(t, err)
+	assert.Zero(t, vu.Votes())
+	assert.Zero(t, vu.LastSeen())
+
+	votes := []bool{true, false, true, true, false}
+	for i, vote := range votes {
+		err = s.RecordBlockVote(owner, vote, int64(10*(i+1)))
+		assert.NoError(t, err)
+	}
+	err = s.RecordProposal(owner, 35)
+	assert.NoError(t, err)
+
+	vu, err = s.GetValidatorUptime(owner)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), vu.Votes())
+	assert.Equal(t, int64(2), vu.Misses())
+	assert.Equal(t, int64(1), vu.Proposals())
+	// Missed votes don't update lastSeen
+	assert.Equal(t, int64(40), vu.LastSeen())
+
+	vu2, err := newValidatorUptimeFromBytes(vu.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, vu, vu2)
+}
@@ -38,7 +38,7 @@ func IsItTimeToCheckBlockVote(blockIndexInTerm, blockVoteCheckPeriod int64) bool
 	return blockVoteCheckPeriod > 0 && blockIndexInTerm%blockVoteCheckPeriod == 0
 }
 
-func validatePrivateClaimableRate(num, denom int64) bool {
+func ValidatePrivateClaimableRate(num, denom int64) bool {
 	if denom <= 0 || denom > 10000 {
 		return false
 	}
@@ -0,0 +1,214 @@
+package hvhstate
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+// ValidatorReward contains the rewards which a validator owner has received
+type ValidatorReward struct {
+	total     *big.Int // accumulated rewards
+	claimable *big.Int // rewards not claimed yet
+}
+
+func newValidatorReward() *ValidatorReward {
+	return &ValidatorReward{new(big.Int), new(big.Int)}
+}
+
+func newValidatorRewardFromBytes(b []byte) (*ValidatorReward, error) {
+	vr := newValidatorReward()
+	if len(b) > 0 {
+		if _, err := codec.UnmarshalFromBytes(b, vr); err != nil {
+			return nil, err
+		}
+	}
+	return vr, nil
+}
+
+func (vr *ValidatorReward) Total() *big.Int {
+	return vr.total
+}
+
+func (vr *ValidatorReward) Claimable() *big.Int {
+	return vr.claimable
+}
+
+func (vr *ValidatorReward) increase(amount *big.Int) {
+	vr.total = new(big.Int).Add(vr.total, amount)
+	vr.claimable = new(big.Int).Add(vr.claimable, amount)
+}
+
+func (vr *ValidatorReward) claim() *big.Int {
+	claimable := vr.claimable
+	vr.claimable = new(big.Int)
+	return claimable
+}
+
+func (vr *ValidatorReward) RLPDecodeSelf(d codec.Decoder) error {
+	return d.DecodeListOf(&vr.total, &vr.claimable)
+}
+
+func (vr *ValidatorReward) RLPEncodeSelf(e codec.Encoder) error {
+	return e.EncodeListOf(vr.total, vr.claimable)
+}
+
+func (vr *ValidatorReward) Bytes() []byte {
+	return codec.BC.MustMarshalToBytes(vr)
+}
+
+func (vr *ValidatorReward) String() string {
+	return fmt.Sprintf("ValidatorReward(total=%d,claimable=%d)", vr.total, vr.claimable)
+}
+
+func (s *State) GetValidatorRewardRate() int64 {
+	return s.getInt64OrDefault(hvhmodule.VarValidatorRewardRate, hvhmodule.ValidatorRewardRate)
+}
+
+func (s *State) SetValidatorRewardRate(rate int64) error {
+	if rate < 0 || rate > hvhmodule.ValidatorRewardRateDenom {
+		return scoreresult.InvalidParameterError.Errorf("InvalidArgument(rate=%d)", rate)
+	}
+	return s.setInt64(hvhmodule.VarValidatorRewardRate, rate)
+}
+
+// CalcValidatorRewardShare returns the part of issueAmount which is distributed to validators
+func (s *State) CalcValidatorRewardShare(issueAmount *big.Int) *big.Int {
+	rate := s.GetValidatorRewardRate()
+	if rate <= 0 || issueAmount == nil || issueAmount.Sign() <= 0 {
+		return hvhmodule.BigIntZero
+	}
+	share := new(big.Int).Mul(issueAmount, big.NewInt(rate))
+	return share.Div(share, big.NewInt(hvhmodule.ValidatorRewardRateDenom))
+}
+
+func (s *State) GetValidatorRewardPool() *big.Int {
+	return s.getBigInt(hvhmodule.VarValidatorRewardPool)
+}
+
+// SetValidatorRewardPool sets the amount of rewards which validators share in the current term
+func (s *State) SetValidatorRewardPool(amount *big.Int) error {
+	if amount == nil || amount.Sign() < 0 {
+		return scoreresult.Errorf(hvhmodule.StatusIllegalArgument, "Invalid amount: %v", amount)
+	}
+	return s.setBigInt(hvhmodule.VarValidatorRewardPool, amount)
+}
+
+// GetValidatorVotes returns the number of block votes of a validator in the current term
+func (s *State) GetValidatorVotes(owner module.Address) int64 {
+	if v := s.getDictDB(hvhmodule.DictValidatorVotes, 1).Get(ToKey(owner)); v != nil {
+		return v.Int64()
+	}
+	return 0
+}
+
+// IncreaseValidatorVotes counts a block vote of an enabled validator in the current term
+func (s *State) IncreaseValidatorVotes(owner module.Address) error {
+	vs, err := s.GetValidatorStatus(owner)
+	if err != nil {
+		return err
+	}
+	if !vs.Enabled() {
+		return nil
+	}
+
+	votesDB := s.getDictDB(hvhmodule.DictValidatorVotes, 1)
+	key := ToKey(owner)
+	votes := int64(0)
+	if v := votesDB.Get(key); v != nil {
+		votes = v.Int64()
+	} else {
+		if err = s.getArrayDB(hvhmodule.ArrayValidatorsVoted).Put(owner); err != nil {
+			return err
+		}
+	}
+	if err = votesDB.Set(key, votes+1); err != nil {
+		return err
+	}
+	total := s.getInt64(hvhmodule.VarValidatorVoteTotal)
+	return s.setInt64(hvhmodule.VarValidatorVoteTotal, total+1)
+}
+
+// DistributeValidatorReward divides the reward pool of the current term among validators
+// in proportion to their block votes and resets the states of the term.
+// It returns the number of rewarded validators and the remainder of the pool which is not distributed
+func (s *State) DistributeValidatorReward() (int, *big.Int, error) {
+	pool := s.GetValidatorRewardPool()
+	total := s.getInt64(hvhmodule.VarValidatorVoteTotal)
+	s.logger.Debugf("DistributeValidatorReward() start: pool=%d totalVotes=%d", pool, total)
+
+	remain := new(big.Int).Set(pool)
+	votedDB := s.getArrayDB(hvhmodule.ArrayValidatorsVoted)
+	votesDB := s.getDictDB(hvhmodule.DictValidatorVotes, 1)
+	rewardDB := s.getDictDB(hvhmodule.DictValidatorReward, 1)
+	count := votedDB.Size()
+
+	for i := 0; i < count; i++ {
+		owner := votedDB.Get(i).Address()
+		key := ToKey(owner)
+		if pool.Sign() > 0 && total > 0 {
+			votes := votesDB.Get(key).Int64()
+			reward := new(big.Int).Mul(pool, big.NewInt(votes))
+			reward.Div(reward, big.NewInt(total))
+
+			vr, err := s.GetValidatorReward(owner)
+			if err != nil {
+				return 0, nil, err
+			}
+			vr.increase(reward)
+			if err = rewardDB.Set(key, vr.Bytes()); err != nil {
+				return 0, nil, err
+			}
+			remain.Sub(remain, reward)
+		}
+		if err := votesDB.Delete(key); err != nil {
+			return 0, nil, err
+		}
+	}
+	for i := 0; i < count; i++ {
+		votedDB.Pop()
+	}
+
+	if total != 0 {
+		if err := s.setInt64(hvhmodule.VarValidatorVoteTotal, 0); err != nil {
+			return 0, nil, err
+		}
+	}
+	if pool.Sign() != 0 {
+		if err := s.setBigInt(hvhmodule.VarValidatorRewardPool, hvhmodule.BigIntZero); err != nil {
+			return 0, nil, err
+		}
+	}
+	s.logger.Debugf("DistributeValidatorReward() end: count=%d remain=%d", count, remain)
+	return count, remain, nil
+}
+
+func (s *State) GetValidatorReward(owner module.Address) (*ValidatorReward, error) {
+	var b []byte
+	if v := s.getDictDB(hvhmodule.DictValidatorReward, 1).Get(ToKey(owner)); v != nil {
+		b = v.Bytes()
+	}
+	return newValidatorRewardFromBytes(b)
+}
+
+// ClaimValidatorReward returns the claimable reward of a validator owner and makes it zero
+func (s *State) ClaimValidatorReward(owner module.Address) (*big.Int, error) {
+	s.logger.Debugf("ClaimValidatorReward() start: owner=%s", owner)
+	vr, err := s.GetValidatorReward(owner)
+	if err != nil {
+		return nil, err
+	}
+	reward := vr.claim()
+	if reward.Sign() > 0 {
+		db := s.getDictDB(hvhmodule.DictValidatorReward, 1)
+		if err = db.Set(ToKey(owner), vr.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+	s.logger.Debugf("ClaimValidatorReward() end: owner=%s reward=%d", owner, reward)
+	return reward, nil
+}
@@ -0,0 +1,107 @@
+package hvhstate
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/module"
+)
+
+func registerDummyValidators(t *testing.T, s *State, size int) []module.Address {
+	owners := make([]module.Address, size)
+	for i := 0; i < size; i++ {
+		name := fmt.Sprintf("name-%02d", i+1)
+		owner := newDummyAddress(i+1, false)
+		_, pubKey := crypto.GenerateKeyPair()
+		err := s.RegisterValidator(owner, pubKey.SerializeCompressed(), GradeSub, name, nil)
+		assert.NoError(t, err)
+		owners[i] = owner
+	}
+	return owners
+}
+
+func TestState_SetValidatorRewardRate(t *testing.T) {
+	s := newDummyState()
+	assert.Equal(t, int64(hvhmodule.ValidatorRewardRate), s.GetValidatorRewardRate())
+	assert.Zero(t, s.CalcValidatorRewardShare(toHVH(100)).Sign())
+
+	for _, rate := range []int64{-1, hvhmodule.ValidatorRewardRateDenom + 1} {
+		err := s.SetValidatorRewardRate(rate)
+		assert.Error(t, err)
+	}
+
+	err := s.SetValidatorRewardRate(1500)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1500), s.GetValidatorRewardRate())
+	assert.Zero(t, toHVH(15).Cmp(s.CalcValidatorRewardShare(toHVH(100))))
+	assert.Zero(t, s.CalcValidatorRewardShare(nil).Sign())
+}
+
+func TestState_DistributeValidatorReward(t *testing.T) {
+	var err error
+	s := newDummyState()
+	owners := registerDummyValidators(t, s, 3)
+
+	// Disabled validators are not rewarded
+	err = s.DisableValidator(owners[2])
+	assert.NoError(t, err)
+
+	votes := []int64{3, 1, 5}
+	for i, owner := range owners {
+		for j := int64(0); j < votes[i]; j++ {
+			err = s.IncreaseValidatorVotes(owner)
+			assert.NoError(t, err)
+		}
+	}
+	assert.Equal(t, int64(3), s.GetValidatorVotes(owners[0]))
+	assert.Equal(t, int64(1), s.GetValidatorVotes(owners[1]))
+	assert.Zero(t, s.GetValidatorVotes(owners[2]))
+
+	pool := big.NewInt(1001)
+	err = s.SetValidatorRewardPool(pool)
+	assert.NoError(t, err)
+	assert.Zero(t, pool.Cmp(s.GetValidatorRewardPool()))
+
+	count, remain, err := s.DistributeValidatorReward()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Zero(t, big.NewInt(1).Cmp(remain))
+
+	expRewards := []int64{750, 250, 0}
+	for i, owner := range owners {
+		vr, err := s.GetValidatorReward(owner)
+		assert.NoError(t, err)
+		assert.Zero(t, big.NewInt(expRewards[i]).Cmp(vr.Total()))
+		assert.Zero(t, big.NewInt(expRewards[i]).Cmp(vr.Claimable()))
+		assert.Zero(t, s.GetValidatorVotes(owner))
+	}
+	assert.Zero(t, s.GetValidatorRewardPool().Sign())
+	assert.Zero(t, s.getArrayDB(hvhmodule.ArrayValidatorsVoted).Size())
+
+	// No votes in a term
+	err = s.SetValidatorRewardPool(pool)
+	assert.NoError(t, err)
+	count, remain, err = s.DistributeValidatorReward()
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+	assert.Zero(t, pool.Cmp(remain))
+
+	// Claim
+	reward, err := s.ClaimValidatorReward(owners[0])
+	assert.NoError(t, err)
+	assert.Zero(t, big.NewInt(750).Cmp(reward))
+
+	vr, err := s.GetValidatorReward(owners[0])
+	assert.NoError(t, err)
+	assert.Zero(t, big.NewInt(750).Cmp(vr.Total()))
+	assert.Zero(t, vr.Claimable().Sign())
+
+	reward, err = s.ClaimValidatorReward(owners[0])
+	assert.NoError(t, err)
+	assert.Zero(t, reward.Sign())
+}
@@ -9,6 +9,8 @@ import (
 const (
 	FlagDisabled = 1 << iota
 	FlagDisqualified
+	FlagSlashed
+	FlagJailed
 )
 
 type ValidatorStatus struct {
@@ -46,6 +48,12 @@ func (vs *ValidatorStatus) Enable(calledByGov bool) error {
 	if vs.Enabled() {
 		return nil
 	}
+	if vs.Slashed() && !calledByGov {
+		return scoreresult.AccessDeniedError.New("Slashed")
+	}
+	if vs.Jailed() && !calledByGov {
+		return scoreresult.AccessDeniedError.New("Jailed")
+	}
 
 	if calledByGov {
 		return vs.enableByGov()
@@ -55,6 +63,7 @@ func (vs *ValidatorStatus) Enable(calledByGov bool) error {
 }
 
 func (vs *ValidatorStatus) enableByGov() error {
+	vs.setFlags(FlagSlashed|FlagJailed, false)
 	err := vs.enable()
 	vs.enabledCount = 0
 	return err
@@ -103,6 +112,32 @@ func (vs *ValidatorStatus) Disqualified() bool {
 	return vs.all(FlagDisqualified)
 }
 
+// SetSlashed marks a validator which has been reported for double signing
+func (vs *ValidatorStatus) SetSlashed() {
+	vs.setFlags(FlagSlashed, true)
+}
+
+func (vs *ValidatorStatus) Slashed() bool {
+	return vs.all(FlagSlashed)
+}
+
+// SetJailed replaces the disabled flag of a penalized validator with the jailed flag
+// which is turned off automatically after jailCooldown
+func (vs *ValidatorStatus) SetJailed() {
+	vs.setFlags(FlagDisabled, false)
+	vs.setFlags(FlagJailed, true)
+}
+
+// Release turns off the jailed flag and gives the validator a fresh start
+func (vs *ValidatorStatus) Release() {
+	vs.setFlags(FlagJailed, false)
+	vs.ResetNonVotes()
+}
+
+func (vs *ValidatorStatus) Jailed() bool {
+	return vs.all(FlagJailed)
+}
+
 func (vs *ValidatorStatus) all(flags int) bool {
 	return vs.flags&flags == flags
 }
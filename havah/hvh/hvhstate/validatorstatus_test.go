@@ -138,3 +138,43 @@ func TestValidatorStatus_RLPDecodeSelf(t *testing.T) {
 	assert.Equal(t, int64(1), vs2.NonVotes())
 	assert.Equal(t, hvhmodule.MaxEnableCount-1, vs2.EnableCount())
 }
+
+func TestValidatorStatus_Slashed(t *testing.T) {
+	vs := NewValidatorStatus()
+	assert.False(t, vs.Slashed())
+
+	vs.SetSlashed()
+	assert.True(t, vs.Slashed())
+	assert.False(t, vs.Enabled())
+
+	// Only governance can enable the slashed validator
+	err := vs.Enable(false)
+	assert.Error(t, err)
+	assert.True(t, vs.Slashed())
+
+	err = vs.Enable(true)
+	assert.NoError(t, err)
+	assert.False(t, vs.Slashed())
+	assert.True(t, vs.Enabled())
+}
+
+func TestValidatorStatus_Jailed(t *testing.T) {
+	vs := NewValidatorStatus()
+	vs.IncrementNonVotes()
+	vs.SetDisabled()
+
+	vs.SetJailed()
+	assert.True(t, vs.Jailed())
+	assert.False(t, vs.Disabled())
+	assert.False(t, vs.Enabled())
+
+	err := vs.Enable(false)
+	assert.Error(t, err)
+	assert.True(t, vs.Jailed())
+
+	vs.Release()
+	assert.False(t, vs.Jailed())
+	assert.True(t, vs.Enabled())
+	assert.Zero(t, vs.NonVotes())
+	assert.Equal(t, hvhmodule.MaxEnableCount, vs.EnableCount())
+}
@@ -0,0 +1,334 @@
+package hvhstate
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+type VestingType int
+
+const (
+	VestingCliff VestingType = iota
+	VestingLinear
+	VestingStep
+)
+
+func (vt VestingType) String() string {
+	switch vt {
+	case VestingCliff:
+		return "cliff"
+	case VestingLinear:
+		return "linear"
+	case VestingStep:
+		return "step"
+	default:
+		return "unknown"
+	}
+}
+
+func ParseVestingType(s string) (VestingType, error) {
+	switch s {
+	case "cliff":
+		return VestingCliff, nil
+	case "linear":
+		return VestingLinear, nil
+	case "step":
+		return VestingStep, nil
+	}
+	return -1, scoreresult.InvalidParameterError.Errorf("InvalidVestingType(%s)", s)
+}
+
+// VestingSchedule defines how the rewards of a planet are unlocked over blocks since its registration.
+// cliff: all rewards are locked until cliff blocks pass
+// linear: rewards are unlocked linearly for duration blocks after cliff
+// step: rewards are unlocked by 1/steps every duration/steps blocks after cliff
+type VestingSchedule struct {
+	name     string
+	vType    VestingType
+	cliff    int64
+	duration int64
+	steps    int64
+}
+
+func NewVestingSchedule(name string, vType VestingType, cliff, duration, steps int64) (*VestingSchedule, error) {
+	if len(name) == 0 || len(name) > hvhmodule.MaxVestingScheduleNameLen {
+		return nil, scoreresult.InvalidParameterError.Errorf("InvalidArgument(name=%s)", name)
+	}
+	if cliff < 0 {
+		return nil, scoreresult.InvalidParameterError.Errorf("InvalidArgument(cliff=%d)", cliff)
+	}
+	switch vType {
+	case VestingCliff:
+		if duration != 0 || steps != 0 {
+			return nil, scoreresult.InvalidParameterError.Errorf(
+				"InvalidArgument(type=%s,duration=%d,steps=%d)", vType, duration, steps)
+		}
+	case VestingLinear:
+		if duration < 1 || steps != 0 {
+			return nil, scoreresult.InvalidParameterError.Errorf(
+				"InvalidArgument(type=%s,duration=%d,steps=%d)", vType, duration, steps)
+		}
+	case VestingStep:
+		if steps < 1 || steps > hvhmodule.MaxVestingSteps || duration < steps {
+			return nil, scoreresult.InvalidParameterError.Errorf(
+				"InvalidArgument(type=%s,duration=%d,steps=%d)", vType, duration, steps)
+		}
+	default:
+		return nil, scoreresult.InvalidParameterError.Errorf("InvalidVestingType(%d)", vType)
+	}
+	return &VestingSchedule{
+		name:     name,
+		vType:    vType,
+		cliff:    cliff,
+		duration: duration,
+		steps:    steps,
+	}, nil
+}
+
+func newVestingScheduleFromBytes(b []byte) (*VestingSchedule, error) {
+	vs := &VestingSchedule{}
+	if _, err := codec.BC.UnmarshalFromBytes(b, vs); err != nil {
+		return nil, scoreresult.UnknownFailureError.Wrap(
+			err, "Failed to create a VestingSchedule from bytes")
+	}
+	return vs, nil
+}
+
+func (vs *VestingSchedule) Name() string {
+	return vs.name
+}
+
+func (vs *VestingSchedule) Type() VestingType {
+	return vs.vType
+}
+
+// vestedRate returns the unlocked portion of rewards as a fraction after elapsed blocks
+func (vs *VestingSchedule) vestedRate(elapsed int64) (int64, int64) {
+	if elapsed < vs.cliff {
+		return 0, 1
+	}
+	elapsed -= vs.cliff
+	switch vs.vType {
+	case VestingLinear:
+		if elapsed < vs.duration {
+			return elapsed, vs.duration
+		}
+	case VestingStep:
+		if elapsed < vs.duration {
+			return elapsed * vs.steps / vs.duration, vs.steps
+		}
+	}
+	return 1, 1
+}
+
+// Locked returns the locked amount out of total rewards after elapsed blocks
+func (vs *VestingSchedule) Locked(total *big.Int, elapsed int64) *big.Int {
+	num, denom := vs.vestedRate(elapsed)
+	if num >= denom {
+		return new(big.Int)
+	}
+	locked := new(big.Int).Mul(total, big.NewInt(denom-num))
+	return locked.Div(locked, big.NewInt(denom))
+}
+
+func (vs *VestingSchedule) RLPDecodeSelf(d codec.Decoder) error {
+	return d.DecodeListOf(&vs.name, &vs.vType, &vs.cliff, &vs.duration, &vs.steps)
+}
+
+func (vs *VestingSchedule) RLPEncodeSelf(e codec.Encoder) error {
+	return e.EncodeListOf(vs.name, vs.vType, vs.cliff, vs.duration, vs.steps)
+}
+
+func (vs *VestingSchedule) Bytes() []byte {
+	return codec.BC.MustMarshalToBytes(vs)
+}
+
+func (vs *VestingSchedule) ToJSON() map[string]interface{} {
+	return map[string]interface{}{
+		"name":     vs.name,
+		"type":     vs.vType.String(),
+		"cliff":    vs.cliff,
+		"duration": vs.duration,
+		"steps":    vs.steps,
+	}
+}
+
+func (vs *VestingSchedule) String() string {
+	return fmt.Sprintf(
+		"VestingSchedule(name=%s,type=%s,cliff=%d,duration=%d,steps=%d)",
+		vs.name, vs.vType, vs.cliff, vs.duration, vs.steps)
+}
+
+// AddVestingSchedule registers a new vesting schedule.
+// A registered schedule cannot be changed because planets may have been assigned to it
+func (s *State) AddVestingSchedule(vs *VestingSchedule) error {
+	s.logger.Debugf("AddVestingSchedule() start: %s", vs)
+
+	dictDB := s.getDictDB(hvhmodule.DictVestingSchedule, 1)
+	if dictDB.Get(vs.name) != nil {
+		return scoreresult.Errorf(hvhmodule.StatusDuplicate, "VestingScheduleAlreadyExists(%s)", vs.name)
+	}
+	arrayDB := s.getArrayDB(hvhmodule.ArrayVestingSchedules)
+	if arrayDB.Size() >= hvhmodule.MaxVestingScheduleCount {
+		return scoreresult.Errorf(
+			hvhmodule.StatusIllegalArgument,
+			"Too many vesting schedules: max(%d)", hvhmodule.MaxVestingScheduleCount)
+	}
+	if err := dictDB.Set(vs.name, vs.Bytes()); err != nil {
+		return err
+	}
+	err := arrayDB.Put(vs.name)
+
+	s.logger.Debugf("AddVestingSchedule() end")
+	return err
+}
+
+func (s *State) GetVestingSchedule(name string) (*VestingSchedule, error) {
+	v := s.getDictDB(hvhmodule.DictVestingSchedule, 1).Get(name)
+	if v == nil {
+		return nil, scoreresult.Errorf(hvhmodule.StatusNotFound, "VestingScheduleNotFound(%s)", name)
+	}
+	return newVestingScheduleFromBytes(v.Bytes())
+}
+
+func (s *State) GetVestingSchedules() ([]*VestingSchedule, error) {
+	arrayDB := s.getArrayDB(hvhmodule.ArrayVestingSchedules)
+	size := arrayDB.Size()
+	schedules := make([]*VestingSchedule, size)
+	for i := 0; i < size; i++ {
+		vs, err := s.GetVestingSchedule(arrayDB.Get(i).String())
+		if err != nil {
+			return nil, err
+		}
+		schedules[i] = vs
+	}
+	return schedules, nil
+}
+
+// SetClassVesting sets the vesting schedule which is assigned to the planets of a given class at registration.
+// An empty name removes the assignment
+func (s *State) SetClassVesting(class, name string) error {
+	if err := validatePlanetClass(class); err != nil {
+		return err
+	}
+	dictDB := s.getDictDB(hvhmodule.DictClassVesting, 1)
+	if len(name) == 0 {
+		return dictDB.Delete(class)
+	}
+	if _, err := s.GetVestingSchedule(name); err != nil {
+		return err
+	}
+	return dictDB.Set(class, name)
+}
+
+// GetClassVesting returns an empty string if no vesting schedule is assigned to a given class
+func (s *State) GetClassVesting(class string) string {
+	if v := s.getDictDB(hvhmodule.DictClassVesting, 1).Get(class); v != nil {
+		return v.String()
+	}
+	return ""
+}
+
+// SetPlanetVesting assigns a vesting schedule to a planet. An empty name removes the assignment
+func (s *State) SetPlanetVesting(id int64, name string) error {
+	if _, err := s.GetPlanet(id); err != nil {
+		return err
+	}
+	dictDB := s.getDictDB(hvhmodule.DictPlanetVesting, 1)
+	if len(name) == 0 {
+		return dictDB.Delete(id)
+	}
+	if _, err := s.GetVestingSchedule(name); err != nil {
+		return err
+	}
+	return dictDB.Set(id, name)
+}
+
+// GetPlanetVesting returns an empty string if no vesting schedule is assigned to a given planet
+func (s *State) GetPlanetVesting(id int64) string {
+	if v := s.getDictDB(hvhmodule.DictPlanetVesting, 1).Get(id); v != nil {
+		return v.String()
+	}
+	return ""
+}
+
+// assignClassVesting assigns the vesting schedule of its class to a newly registered planet
+func (s *State) assignClassVesting(id int64, p *Planet) error {
+	if name := s.GetClassVesting(PlanetClassOf(p)); len(name) > 0 {
+		return s.getDictDB(hvhmodule.DictPlanetVesting, 1).Set(id, name)
+	}
+	return nil
+}
+
+func (s *State) deletePlanetVesting(id int64) error {
+	return s.getDictDB(hvhmodule.DictPlanetVesting, 1).Delete(id)
+}
+
+// calcLockedReward returns the locked amount out of the total reward of a planet.
+// The planets without any vesting schedule follow privateClaimableRate
+func (s *State) calcLockedReward(height, id int64, p *Planet, pr *planetReward) (*big.Int, error) {
+	if name := s.GetPlanetVesting(id); len(name) > 0 {
+		vs, err := s.GetVestingSchedule(name)
+		if err != nil {
+			return nil, err
+		}
+		return vs.Locked(pr.Total(), height-p.Height()), nil
+	}
+
+	if !p.IsPrivate() {
+		return new(big.Int), nil
+	}
+	num, denom := s.GetPrivateClaimableRate()
+	if !ValidatePrivateClaimableRate(num, denom) {
+		return nil, scoreresult.InvalidParameterError.Errorf(
+			"InvalidPrivateClaimableRate: num=%d denom=%d", num, denom)
+	}
+	lockedReward := new(big.Int)
+	if num < denom {
+		lockedReward.SetInt64(denom - num)
+		lockedReward.Mul(lockedReward, pr.Total())
+		lockedReward.Div(lockedReward, big.NewInt(denom))
+	}
+	return lockedReward, nil
+}
+
+// GetPlanetVestingInfo returns the vesting schedule of a planet and its locked and claimable rewards
+func (s *State) GetPlanetVestingInfo(height, id int64) (map[string]interface{}, error) {
+	p, err := s.GetPlanet(id)
+	if err != nil {
+		return nil, err
+	}
+	pr, err := s.GetPlanetReward(id)
+	if err != nil {
+		return nil, err
+	}
+	locked, err := s.calcLockedReward(height, id, p, pr)
+	if err != nil {
+		return nil, err
+	}
+	claimable, err := s.calcClaimableReward(height, id, p, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	jso := map[string]interface{}{
+		"id":        id,
+		"class":     PlanetClassOf(p),
+		"total":     pr.Total(),
+		"remain":    pr.Current(),
+		"locked":    locked,
+		"claimable": claimable,
+	}
+	if name := s.GetPlanetVesting(id); len(name) > 0 {
+		vs, err := s.GetVestingSchedule(name)
+		if err != nil {
+			return nil, err
+		}
+		jso["schedule"] = vs.ToJSON()
+	}
+	return jso, nil
+}
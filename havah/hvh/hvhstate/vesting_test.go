@@ -0,0 +1,144 @@
+package hvhstate
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/havah/hvhmodule"
+)
+
+func TestNewVestingSchedule(t *testing.T) {
+	args := []struct {
+		vType    VestingType
+		cliff    int64
+		duration int64
+		steps    int64
+		ok       bool
+	}{
+		{VestingCliff, 100, 0, 0, true},
+		{VestingCliff, -1, 0, 0, false},
+		{VestingCliff, 100, 10, 0, false},
+		{VestingLinear, 0, 100, 0, true},
+		{VestingLinear, 0, 0, 0, false},
+		{VestingLinear, 0, 100, 4, false},
+		{VestingStep, 10, 100, 4, true},
+		{VestingStep, 10, 100, 0, false},
+		{VestingStep, 10, 3, 4, false},
+		{VestingStep, 0, 1e12, hvhmodule.MaxVestingSteps, true},
+		{VestingStep, 0, 1e12, 1e12, false},
+		{VestingType(10), 0, 0, 0, false},
+	}
+
+	for i, arg := range args {
+		_, err := NewVestingSchedule("test", arg.vType, arg.cliff, arg.duration, arg.steps)
+		assert.Equal(t, arg.ok, err == nil, "case %d", i)
+	}
+
+	_, err := NewVestingSchedule("", VestingCliff, 0, 0, 0)
+	assert.Error(t, err)
+}
+
+func TestVestingSchedule_Locked(t *testing.T) {
+	total := big.NewInt(1000)
+	cliff, _ := NewVestingSchedule("cliff", VestingCliff, 100, 0, 0)
+	linear, _ := NewVestingSchedule("linear", VestingLinear, 100, 200, 0)
+	step, _ := NewVestingSchedule("step", VestingStep, 100, 200, 4)
+
+	args := []struct {
+		vs      *VestingSchedule
+		elapsed int64
+		locked  int64
+	}{
+		{cliff, 0, 1000},
+		{cliff, 99, 1000},
+		{cliff, 100, 0},
+		{linear, 99, 1000},
+		{linear, 100, 1000},
+		{linear, 150, 750},
+		{linear, 299, 5},
+		{linear, 300, 0},
+		{step, 100, 1000},
+		{step, 149, 1000},
+		{step, 150, 750},
+		{step, 299, 250},
+		{step, 300, 0},
+	}
+
+	for _, arg := range args {
+		locked := arg.vs.Locked(total, arg.elapsed)
+		assert.Equal(t, arg.locked, locked.Int64(), "%s elapsed=%d", arg.vs, arg.elapsed)
+	}
+}
+
+func TestState_VestingSchedule(t *testing.T) {
+	s := newDummyState()
+	owner := newDummyAddress(1, false)
+	rev := hvhmodule.RevisionVestingSchedule
+
+	linear, err := NewVestingSchedule("partner", VestingLinear, 0, 100, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, s.AddVestingSchedule(linear))
+	assert.Error(t, s.AddVestingSchedule(linear))
+
+	cliff, err := NewVestingSchedule("cliff", VestingCliff, 1000, 0, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, s.AddVestingSchedule(cliff))
+
+	schedules, err := s.GetVestingSchedules()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(schedules))
+
+	assert.Error(t, s.SetClassVesting("unknown", "partner"))
+	assert.Error(t, s.SetClassVesting(PlanetClassCompany, "unknown"))
+	assert.NoError(t, s.SetClassVesting(PlanetClassCompany, "partner"))
+	assert.Equal(t, "partner", s.GetClassVesting(PlanetClassCompany))
+
+	// id 1: public, id 2: company, id 3: private
+	height := int64(10)
+	for id := int64(1); id <= 3; id++ {
+		err = s.RegisterPlanet(rev, id, id == 3, id == 2, owner, toUSDT(1000), toHVH(1000), height)
+		assert.NoError(t, err)
+		err = s.setPlanetReward(id, newPlanetReward(toHVH(10), toHVH(10), 0))
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, "", s.GetPlanetVesting(1))
+	assert.Equal(t, "partner", s.GetPlanetVesting(2))
+	assert.Equal(t, "", s.GetPlanetVesting(3))
+
+	// The class assignment does not affect the planets which have been already registered
+	assert.NoError(t, s.SetClassVesting(PlanetClassCompany, ""))
+	assert.Equal(t, "partner", s.GetPlanetVesting(2))
+
+	jso, err := s.GetPlanetVestingInfo(height+50, 1)
+	assert.NoError(t, err)
+	assert.Zero(t, jso["locked"].(*big.Int).Sign())
+	assert.Zero(t, toHVH(10).Cmp(jso["claimable"].(*big.Int)))
+
+	jso, err = s.GetPlanetVestingInfo(height+50, 2)
+	assert.NoError(t, err)
+	assert.Zero(t, toHVH(5).Cmp(jso["locked"].(*big.Int)))
+	assert.Zero(t, toHVH(5).Cmp(jso["claimable"].(*big.Int)))
+	assert.Equal(t, "partner", jso["schedule"].(map[string]interface{})["name"])
+
+	// The private planet without any schedule follows privateClaimableRate, which locks all rewards by default
+	jso, err = s.GetPlanetVestingInfo(height+50, 3)
+	assert.NoError(t, err)
+	assert.Zero(t, jso["claimable"].(*big.Int).Sign())
+
+	assert.NoError(t, s.SetPlanetVesting(3, "cliff"))
+	reward, err := s.ClaimPlanetReward(3, height+999, owner)
+	assert.NoError(t, err)
+	assert.Zero(t, reward.Sign())
+	reward, err = s.ClaimPlanetReward(3, height+1000, owner)
+	assert.NoError(t, err)
+	assert.Zero(t, toHVH(10).Cmp(reward))
+
+	assert.Error(t, s.SetPlanetVesting(4, "cliff"))
+	assert.Error(t, s.SetPlanetVesting(1, "unknown"))
+
+	_, err = s.UnregisterPlanet(rev, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "", s.GetPlanetVesting(2))
+}
@@ -0,0 +1,158 @@
+package hvh
+
+import (
+	"encoding/json"
+
+	"github.com/icon-project/goloop/havah/hvh/hvhstate"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+func (es *ExtensionStateImpl) CreateMultisig(
+	cc hvhmodule.CallContext, signers []module.Address, threshold int64) (module.Address, error) {
+	height := cc.BlockHeight()
+	es.Logger().Debugf(
+		"CreateMultisig() start: height=%d from=%s signers=%v threshold=%d", height, cc.From(), signers, threshold)
+
+	address, err := es.state.CreateMultisig(cc.From(), signers, threshold)
+	if err != nil {
+		return nil, err
+	}
+	onMultisigCreatedEvent(cc, address, threshold)
+
+	es.Logger().Debugf("CreateMultisig() end: height=%d address=%s", height, address)
+	return address, nil
+}
+
+// SetMultisigSigners changes the signers of a multisig account, which only the account itself can do
+// through one of its operations
+func (es *ExtensionStateImpl) SetMultisigSigners(
+	cc hvhmodule.CallContext, signers []module.Address, threshold int64) error {
+	height := cc.BlockHeight()
+	address := cc.From()
+	es.Logger().Debugf(
+		"SetMultisigSigners() start: height=%d address=%s signers=%v threshold=%d",
+		height, address, signers, threshold)
+
+	if err := es.state.SetMultisigSigners(address, signers, threshold); err != nil {
+		return err
+	}
+	onMultisigSignersChangedEvent(cc, address, threshold)
+
+	es.Logger().Debugf("SetMultisigSigners() end: height=%d", height)
+	return nil
+}
+
+// SubmitMultisigOperation registers an operation which is approved by its submitter.
+// The operation is returned with whether it is ready to be executed
+func (es *ExtensionStateImpl) SubmitMultisigOperation(
+	cc hvhmodule.CallContext, multisig, to module.Address, method, params string,
+) (*hvhstate.MultisigOperation, bool, error) {
+	height := cc.BlockHeight()
+	submitter := cc.From()
+	es.Logger().Debugf(
+		"SubmitMultisigOperation() start: height=%d multisig=%s submitter=%s to=%s method=%s params=%s",
+		height, multisig, submitter, to, method, params)
+
+	if len(params) > 0 && !json.Valid([]byte(params)) {
+		return nil, false, scoreresult.InvalidParameterError.Errorf("InvalidArgument(params=%s)", params)
+	}
+	op, ready, err := es.state.SubmitMultisigOperation(multisig, submitter, to, method, []byte(params), height)
+	if err != nil {
+		return nil, false, err
+	}
+	onMultisigOperationSubmittedEvent(cc, op.ID(), multisig, to, method)
+	onMultisigOperationApprovedEvent(cc, op.ID(), submitter)
+
+	es.Logger().Debugf("SubmitMultisigOperation() end: height=%d %s ready=%t", height, op, ready)
+	return op, ready, nil
+}
+
+// ApproveMultisigOperation records the approval of a signer.
+// The operation is returned with whether it is ready to be executed
+func (es *ExtensionStateImpl) ApproveMultisigOperation(
+	cc hvhmodule.CallContext, id int64) (*hvhstate.MultisigOperation, bool, error) {
+	height := cc.BlockHeight()
+	signer := cc.From()
+	es.Logger().Debugf("ApproveMultisigOperation() start: height=%d id=%d signer=%s", height, id, signer)
+
+	op, ready, err := es.state.ApproveMultisigOperation(id, signer, height)
+	if err != nil {
+		return nil, false, err
+	}
+	onMultisigOperationApprovedEvent(cc, id, signer)
+
+	es.Logger().Debugf("ApproveMultisigOperation() end: height=%d %s ready=%t", height, op, ready)
+	return op, ready, nil
+}
+
+func (es *ExtensionStateImpl) CancelMultisigOperation(cc hvhmodule.CallContext, id int64) error {
+	height := cc.BlockHeight()
+	es.Logger().Debugf("CancelMultisigOperation() start: height=%d id=%d from=%s", height, id, cc.From())
+
+	if err := es.state.CancelMultisigOperation(id, cc.From(), height); err != nil {
+		return err
+	}
+	onMultisigOperationStatusChangedEvent(cc, id, hvhstate.MultisigOperationCanceled.String())
+
+	es.Logger().Debugf("CancelMultisigOperation() end: height=%d", height)
+	return nil
+}
+
+// GetExecutableMultisigOperation returns a pending operation which a signer can execute
+// because it already has enough approvals
+func (es *ExtensionStateImpl) GetExecutableMultisigOperation(
+	cc hvhmodule.CallContext, id int64) (*hvhstate.MultisigOperation, error) {
+	return es.state.GetExecutableMultisigOperation(id, cc.From(), cc.BlockHeight())
+}
+
+// ExecuteMultisigOperation runs an operation which has enough approvals with a given call.
+// A failed call closes the operation with failed status instead of making the tx fail
+func (es *ExtensionStateImpl) ExecuteMultisigOperation(
+	cc hvhmodule.CallContext, op *hvhstate.MultisigOperation, call func() error) error {
+	height := cc.BlockHeight()
+	es.Logger().Debugf("ExecuteMultisigOperation() start: height=%d %s", height, op)
+
+	if err := es.state.StartMultisigOperation(op.ID()); err != nil {
+		return err
+	}
+	status := hvhstate.MultisigOperationExecuted
+	if err := call(); err != nil {
+		if !scoreresult.IsValid(err) || scoreresult.OutOfStepError.Equals(err) {
+			// Not enough steps for the call is not the failure of the operation
+			return err
+		}
+		es.Logger().Infof("Failed to execute a multisig operation: %s err=%v", op, err)
+		status = hvhstate.MultisigOperationFailed
+	}
+	if err := es.state.CloseMultisigOperation(op.ID(), status); err != nil {
+		return err
+	}
+	onMultisigOperationStatusChangedEvent(cc, op.ID(), status.String())
+
+	es.Logger().Debugf("ExecuteMultisigOperation() end: height=%d status=%s", height, status)
+	return nil
+}
+
+func (es *ExtensionStateImpl) GetMultisig(
+	cc hvhmodule.CallContext, address module.Address) (map[string]interface{}, error) {
+	ms, err := es.state.GetMultisig(address)
+	if err != nil {
+		return nil, err
+	}
+	jso := ms.ToJSON()
+	jso["height"] = cc.BlockHeight()
+	return jso, nil
+}
+
+func (es *ExtensionStateImpl) GetMultisigOperation(
+	cc hvhmodule.CallContext, id int64) (map[string]interface{}, error) {
+	op, err := es.state.GetMultisigOperation(id)
+	if err != nil {
+		return nil, err
+	}
+	jso := op.ToJSON()
+	jso["height"] = cc.BlockHeight()
+	return jso, nil
+}
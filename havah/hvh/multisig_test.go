@@ -0,0 +1,88 @@
+package hvh
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+func TestExtensionStateImpl_Multisig(t *testing.T) {
+	mcc, es := newMockContextAndExtensionState(t, newSimplePlatformConfig(10, 1))
+	mcc.SetRevision(hvhmodule.RevisionMultisig)
+	mcc.setBlockHeight(100)
+
+	signers := make([]module.Address, 3)
+	for i := range signers {
+		signers[i] = common.MustNewAddressFromString(fmt.Sprintf("hx%d", i+1))
+	}
+	to := common.MustNewAddressFromString("cx1234")
+
+	address, err := es.CreateMultisig(NewCallContext(mcc, signers[0]), signers, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(mcc.eventsOf(SigMultisigCreated)))
+
+	jso, err := es.GetMultisig(NewCallContext(mcc, nil), address)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), jso["threshold"])
+
+	_, _, err = es.SubmitMultisigOperation(
+		NewCallContext(mcc, signers[0]), address, to, "addPlanetManager", `{"address":`)
+	assert.Error(t, err)
+	op, ready, err := es.SubmitMultisigOperation(
+		NewCallContext(mcc, signers[0]), address, to, "addPlanetManager", `{"address":"hx4"}`)
+	assert.NoError(t, err)
+	assert.False(t, ready)
+	assert.Equal(t, 1, len(mcc.eventsOf(SigMultisigOperationSubmitted)))
+	assert.Equal(t, 1, len(mcc.eventsOf(SigMultisigOperationApproved)))
+
+	_, _, err = es.ApproveMultisigOperation(NewCallContext(mcc, signers[0]), op.ID())
+	assert.Error(t, err)
+	op, ready, err = es.ApproveMultisigOperation(NewCallContext(mcc, signers[2]), op.ID())
+	assert.NoError(t, err)
+	assert.True(t, ready)
+	assert.Equal(t, 2, len(mcc.eventsOf(SigMultisigOperationApproved)))
+
+	err = es.ExecuteMultisigOperation(NewCallContext(mcc, signers[2]), op, func() error {
+		return scoreresult.AccessDeniedError.New("NoPermission")
+	})
+	assert.NoError(t, err)
+	jso, err = es.GetMultisigOperation(NewCallContext(mcc, nil), op.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, "failed", jso["status"])
+	assert.Equal(t, int64(100), jso["startHeight"])
+
+	op, _, err = es.SubmitMultisigOperation(
+		NewCallContext(mcc, signers[1]), address, to, "startRewardIssue", `{"height":"0x200"}`)
+	assert.NoError(t, err)
+	err = es.CancelMultisigOperation(NewCallContext(mcc, signers[0]), op.ID())
+	assert.Error(t, err)
+	err = es.CancelMultisigOperation(NewCallContext(mcc, signers[1]), op.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(mcc.eventsOf(SigMultisigOperationStatusChanged)))
+
+	// Not enough steps makes the tx fail instead of closing the operation
+	op, _, err = es.SubmitMultisigOperation(
+		NewCallContext(mcc, signers[1]), address, to, "startRewardIssue", `{"height":"0x200"}`)
+	assert.NoError(t, err)
+	op, ready, err = es.ApproveMultisigOperation(NewCallContext(mcc, signers[0]), op.ID())
+	assert.NoError(t, err)
+	assert.True(t, ready)
+	err = es.ExecuteMultisigOperation(NewCallContext(mcc, signers[0]), op, func() error {
+		return scoreresult.OutOfStepError.New("OutOfStep")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 2, len(mcc.eventsOf(SigMultisigOperationStatusChanged)))
+
+	// Only the multisig account itself can change its signers
+	err = es.SetMultisigSigners(NewCallContext(mcc, signers[0]), signers[:2], 2)
+	assert.Error(t, err)
+	err = es.SetMultisigSigners(NewCallContext(mcc, address), signers[:2], 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(mcc.eventsOf(SigMultisigSignersChanged)))
+}
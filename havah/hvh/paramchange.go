@@ -0,0 +1,89 @@
+package hvh
+
+import (
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+// ScheduleParameterChange schedules a parameter change to be applied at a given height.
+// If height is 0, the change is applied at the start of a given term
+func (es *ExtensionStateImpl) ScheduleParameterChange(
+	cc hvhmodule.CallContext, pType, value string, height, termSeq int64) error {
+	curHeight := cc.BlockHeight()
+	es.Logger().Debugf(
+		"ScheduleParameterChange() start: height=%d type=%s value=%s target=%d term=%d",
+		curHeight, pType, value, height, termSeq)
+
+	if (height > 0) == (termSeq > 0) {
+		return scoreresult.InvalidParameterError.Errorf(
+			"InvalidArgument(height=%d,term=%d)", height, termSeq)
+	}
+	if termSeq > 0 {
+		issueStart := es.state.GetIssueStart()
+		if issueStart <= 0 {
+			return scoreresult.Errorf(hvhmodule.StatusNotReady, "IssueNotStarted")
+		}
+		height = issueStart + termSeq*es.state.GetTermPeriod()
+	}
+	if _, err := newChangeParams(pType, []byte(value)); err != nil {
+		return err
+	}
+	id, err := es.state.ScheduleParameterChange(pType, []byte(value), height, curHeight)
+	if err != nil {
+		return err
+	}
+	onParameterChangeScheduledEvent(cc, id, pType, height)
+
+	es.Logger().Debugf("ScheduleParameterChange() end: height=%d id=%d", curHeight, id)
+	return nil
+}
+
+func (es *ExtensionStateImpl) CancelParameterChange(cc hvhmodule.CallContext, id int64) error {
+	if err := es.state.CancelParameterChange(id); err != nil {
+		return err
+	}
+	onParameterChangeCanceledEvent(cc, id)
+	return nil
+}
+
+// applyPendingChanges applies the parameter changes which are due at the current height.
+// A change which fails to be applied is dropped without leaving its partial writes
+// instead of making the base tx fail
+func (es *ExtensionStateImpl) applyPendingChanges(cc hvhmodule.CallContext) error {
+	height := cc.BlockHeight()
+	changes, err := es.state.PopDueChanges(height)
+	if err != nil || len(changes) == 0 {
+		return err
+	}
+
+	es.Logger().Debugf("applyPendingChanges() start: height=%d changes=%d", height, len(changes))
+	for _, pc := range changes {
+		success := true
+		params, err := newChangeParams(pc.Type(), pc.Value())
+		if err == nil {
+			err = es.applyChangeParams(cc, params)
+		}
+		if err != nil {
+			es.Logger().Infof("Failed to apply a parameter change: %s err=%v", pc, err)
+			success = false
+		}
+		onParameterChangeAppliedEvent(cc, pc.ID(), pc.Type(), success)
+	}
+	es.Logger().Debugf("applyPendingChanges() end: height=%d", height)
+	return nil
+}
+
+func (es *ExtensionStateImpl) GetPendingChanges(cc hvhmodule.CallContext) (map[string]interface{}, error) {
+	changes, err := es.state.GetPendingChanges()
+	if err != nil {
+		return nil, err
+	}
+	jso := make([]interface{}, len(changes))
+	for i, pc := range changes {
+		jso[i] = pc.ToJSON()
+	}
+	return map[string]interface{}{
+		"height":  cc.BlockHeight(),
+		"changes": jso,
+	}, nil
+}
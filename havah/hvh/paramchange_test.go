@@ -0,0 +1,70 @@
+package hvh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/havah/hvhmodule"
+)
+
+func TestExtensionStateImpl_ScheduleParameterChange(t *testing.T) {
+	var err error
+	const termPeriod = 10
+	mcc, es := newMockContextAndExtensionState(t, newSimplePlatformConfig(termPeriod, 1))
+	mcc.SetRevision(hvhmodule.RevisionParameterChange)
+	mcc.setBlockHeight(10)
+	cc := NewCallContext(mcc, nil)
+
+	// Term-based schedules need issueStart
+	err = es.ScheduleParameterChange(cc, ParamTypeActiveValidatorCount, `{"count":"0x7"}`, 0, 2)
+	assert.Error(t, err)
+
+	issueStart := int64(20)
+	err = es.state.SetIssueStart(10, issueStart)
+	assert.NoError(t, err)
+
+	// Either height or term should be given
+	err = es.ScheduleParameterChange(cc, ParamTypeActiveValidatorCount, `{"count":"0x7"}`, 0, 0)
+	assert.Error(t, err)
+	err = es.ScheduleParameterChange(cc, ParamTypeActiveValidatorCount, `{"count":"0x7"}`, 50, 2)
+	assert.Error(t, err)
+	// Invalid parameters
+	err = es.ScheduleParameterChange(cc, ParamTypeActiveValidatorCount, `{"count":"0x0"}`, 50, 0)
+	assert.Error(t, err)
+
+	err = es.ScheduleParameterChange(cc, ParamTypeActiveValidatorCount, `{"count":"0x7"}`, 0, 2)
+	assert.NoError(t, err)
+	err = es.ScheduleParameterChange(cc, ParamTypeJailParameters, `{"cooldown":"0x64"}`, 35, 0)
+	assert.NoError(t, err)
+	err = es.ScheduleParameterChange(cc, ParamTypeUSDTPrice, `{"price":"0x1"}`, 45, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(mcc.eventsOf(SigParameterChangeScheduled)))
+
+	err = es.CancelParameterChange(cc, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(mcc.eventsOf(SigParameterChangeCanceled)))
+
+	jso, err := es.GetPendingChanges(cc)
+	assert.NoError(t, err)
+	changes := jso["changes"].([]interface{})
+	assert.Equal(t, 2, len(changes))
+	assert.Equal(t, int64(35), changes[0].(map[string]interface{})["height"])
+	assert.Equal(t, issueStart+2*termPeriod, changes[1].(map[string]interface{})["height"])
+
+	mcc.setBlockHeight(35)
+	err = es.applyPendingChanges(cc)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), es.state.GetJailCooldown())
+	assert.NotEqual(t, int64(7), es.state.GetActiveValidatorCount())
+
+	mcc.setBlockHeight(issueStart + 2*termPeriod)
+	err = es.applyPendingChanges(cc)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), es.state.GetActiveValidatorCount())
+	assert.Equal(t, 2, len(mcc.eventsOf(SigParameterChangeApplied)))
+
+	jso, err = es.GetPendingChanges(cc)
+	assert.NoError(t, err)
+	assert.Zero(t, len(jso["changes"].([]interface{})))
+}
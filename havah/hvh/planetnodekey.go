@@ -0,0 +1,106 @@
+package hvh
+
+import (
+	"fmt"
+
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/havah/hvh/hvhstate"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+// PlanetWorkHash returns the hash of the message which the node of a planet signs
+// to attest its work in a term of a chain
+func PlanetWorkHash(cid int, id, termSeq int64) []byte {
+	msg := fmt.Sprintf("havah.planetWork.%#x.%d.%d", cid, id, termSeq)
+	return crypto.SHA3Sum256([]byte(msg))
+}
+
+// SetPlanetNodeKey registers the public key of the node which runs a planet.
+// Only the planet owner can do it and an empty key removes the existing one
+func (es *ExtensionStateImpl) SetPlanetNodeKey(cc hvhmodule.CallContext, id int64, key []byte) error {
+	owner := cc.From()
+	es.Logger().Debugf(
+		"SetPlanetNodeKey() start: height=%d id=%d owner=%s key=%#x", cc.BlockHeight(), id, owner, key)
+
+	key, err := es.state.SetPlanetNodeKey(id, owner, key)
+	if err != nil {
+		return err
+	}
+	onPlanetNodeKeySetEvent(cc, id, owner, key)
+
+	es.Logger().Debugf("SetPlanetNodeKey() end: id=%d", id)
+	return nil
+}
+
+func (es *ExtensionStateImpl) GetPlanetNodeKey(
+	cc hvhmodule.CallContext, id int64) (map[string]interface{}, error) {
+	key, err := es.state.GetPlanetNodeKey(id)
+	if err != nil {
+		return nil, err
+	}
+	jso := map[string]interface{}{
+		"height": cc.BlockHeight(),
+		"id":     id,
+	}
+	if key != nil {
+		jso["nodeKey"] = key.SerializeCompressed()
+	}
+	return jso, nil
+}
+
+// ReportAttestedPlanetWork handles the work report of a planet which is signed by its node.
+// signature is made with the node key over PlanetWorkHash() of the current term
+func (es *ExtensionStateImpl) ReportAttestedPlanetWork(
+	cc hvhmodule.CallContext, id int64, signature []byte) error {
+	height := cc.BlockHeight()
+	es.Logger().Debugf("ReportAttestedPlanetWork() start: height=%d id=%d signature=%#x", height, id, signature)
+
+	if len(signature) == 0 {
+		return scoreresult.InvalidParameterError.New("InvalidArgument(signature=empty)")
+	}
+	issueStart := es.state.GetIssueStart()
+	if !hvhstate.IsIssueStarted(height, issueStart) {
+		return errors.InvalidStateError.Errorf(
+			"IssueDoesntStarted(height=%d,issueStart=%d)", height, issueStart)
+	}
+
+	termPeriod := es.state.GetTermPeriod()
+	termSeq := (height - issueStart) / termPeriod
+	termStart := termSeq*termPeriod + issueStart
+
+	if err := es.reportPlanetWork(cc, id, termSeq, termStart, signature, false); err != nil {
+		return err
+	}
+	es.Logger().Debugf("ReportAttestedPlanetWork() end: height=%d id=%d", height, id)
+	return nil
+}
+
+// verifyPlanetWork checks if the work report of a planet with a node key is signed by the key.
+// A planet without a node key needs no signature
+func (es *ExtensionStateImpl) verifyPlanetWork(
+	cc hvhmodule.CallContext, id, termSeq int64, signature []byte) error {
+	key, err := es.state.GetPlanetNodeKey(id)
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		if len(signature) > 0 {
+			return scoreresult.Errorf(hvhmodule.StatusRewardError, "NoPlanetNodeKey: id=%d", id)
+		}
+		return nil
+	}
+	if len(signature) == 0 {
+		return scoreresult.Errorf(hvhmodule.StatusRewardError, "AttestationRequired: id=%d", id)
+	}
+	sig, err := crypto.ParseSignature(signature)
+	if err != nil {
+		return scoreresult.InvalidParameterError.Wrapf(err, "InvalidArgument(signature=%#x)", signature)
+	}
+	if !sig.Verify(PlanetWorkHash(cc.ChainID(), id, termSeq), key) {
+		return scoreresult.Errorf(
+			hvhmodule.StatusRewardError, "InvalidAttestation: id=%d termSeq=%d", id, termSeq)
+	}
+	return nil
+}
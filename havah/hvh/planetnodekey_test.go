@@ -0,0 +1,105 @@
+package hvh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/havah/hvh/hvhstate"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+)
+
+func TestExtensionStateImpl_ReportAttestedPlanetWork(t *testing.T) {
+	var err error
+	stateCfg := hvhstate.StateConfig{
+		TermPeriod:  &common.HexInt64{Value: 10},
+		USDTPrice:   new(common.HexInt).SetValue(toHVH(1)),
+		IssueAmount: new(common.HexInt).SetValue(toHVH(100)),
+	}
+	mcc, es := newMockContextAndExtensionState(t, &PlatformConfig{StateConfig: stateCfg})
+	mcc.height = 1
+	mcc.SetRevision(hvhmodule.RevisionPlanetNodeKey)
+	owner := common.MustNewAddressFromString("hx1234")
+	other := common.MustNewAddressFromString("hx5678")
+	cc := NewCallContext(mcc, nil)
+	occ := NewCallContext(mcc, owner)
+
+	err = es.StartRewardIssue(cc, 10)
+	assert.NoError(t, err)
+	for id := int64(1); id <= 3; id++ {
+		err = es.RegisterPlanet(cc, id, false, false, owner, toUSDT(1_000), toHVH(10_000))
+		assert.NoError(t, err)
+	}
+
+	// Planet 1 and 2 have node keys
+	privKey, pubKey := crypto.GenerateKeyPair()
+	for id := int64(1); id <= 2; id++ {
+		err = es.SetPlanetNodeKey(NewCallContext(mcc, other), id, pubKey.SerializeCompressed())
+		assert.Error(t, err)
+		err = es.SetPlanetNodeKey(occ, id, pubKey.SerializeCompressed())
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, len(mcc.eventsOf(SigPlanetNodeKeySet)))
+	jso, err := es.GetPlanetNodeKey(cc, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, pubKey.SerializeCompressed(), jso["nodeKey"])
+	jso, err = es.GetPlanetNodeKey(cc, 3)
+	assert.NoError(t, err)
+	assert.Nil(t, jso["nodeKey"])
+
+	// Term 0 starts
+	err = goToNextTerm(t, es, mcc, nil, 1)
+	assert.NoError(t, err)
+	termSeq := int64(0)
+	sign := func(id, termSeq int64) []byte {
+		sig, err := crypto.NewSignature(PlanetWorkHash(mcc.ChainID(), id, termSeq), privKey)
+		assert.NoError(t, err)
+		b, err := sig.SerializeRSV()
+		assert.NoError(t, err)
+		return b
+	}
+
+	// Planets with node keys need attestation
+	err = es.ReportPlanetWork(cc, 1)
+	assert.Error(t, err)
+	err = es.ReportPlanetWorks(cc, []int64{1, 2, 3})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(mcc.eventsOf(SigPlanetWorkRejected)))
+	assert.Equal(t, 1, len(mcc.eventsOf(SigRewardOffered)))
+
+	err = es.ReportAttestedPlanetWork(cc, 1, nil)
+	assert.Error(t, err)
+	err = es.ReportAttestedPlanetWork(cc, 1, sign(2, termSeq))
+	assert.Error(t, err)
+	err = es.ReportAttestedPlanetWork(cc, 1, sign(1, termSeq+1))
+	assert.Error(t, err)
+	err = es.ReportAttestedPlanetWork(cc, 1, sign(1, termSeq))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(mcc.eventsOf(SigRewardOffered)))
+	err = es.ReportAttestedPlanetWork(cc, 1, sign(1, termSeq))
+	assert.Error(t, err)
+
+	// A planet without a node key cannot be attested
+	err = es.ReportAttestedPlanetWork(cc, 3, sign(3, termSeq))
+	assert.Error(t, err)
+
+	// Removing the node key makes the planet work as before
+	err = es.SetPlanetNodeKey(occ, 2, nil)
+	assert.NoError(t, err)
+	err = es.ReportPlanetWork(cc, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(mcc.eventsOf(SigRewardOffered)))
+
+	// A transferred planet loses the node key of its previous owner
+	err = es.SetPlanetOwner(cc, 1, other)
+	assert.NoError(t, err)
+	jso, err = es.GetPlanetNodeKey(cc, 1)
+	assert.NoError(t, err)
+	assert.Nil(t, jso["nodeKey"])
+	err = es.SetPlanetNodeKey(occ, 1, pubKey.SerializeCompressed())
+	assert.Error(t, err)
+	err = es.SetPlanetNodeKey(NewCallContext(mcc, other), 1, pubKey.SerializeCompressed())
+	assert.NoError(t, err)
+}
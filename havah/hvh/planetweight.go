@@ -0,0 +1,65 @@
+package hvh
+
+import (
+	"github.com/icon-project/goloop/havah/hvh/hvhstate"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+)
+
+func (es *ExtensionStateImpl) SetPlanetClassWeight(class string, weight int64) error {
+	es.Logger().Debugf("SetPlanetClassWeight() start: class=%s weight=%d", class, weight)
+	defer es.Logger().Debugf("SetPlanetClassWeight() end")
+	return es.state.SetPlanetClassWeight(class, weight)
+}
+
+func (es *ExtensionStateImpl) SetPlanetWeight(id, weight int64) error {
+	es.Logger().Debugf("SetPlanetWeight() start: id=%d weight=%d", id, weight)
+	defer es.Logger().Debugf("SetPlanetWeight() end")
+	return es.state.SetPlanetWeight(id, weight)
+}
+
+func (es *ExtensionStateImpl) GetPlanetWeights(cc hvhmodule.CallContext) (map[string]interface{}, error) {
+	classes := make(map[string]interface{})
+	for _, class := range hvhstate.PlanetClasses() {
+		jso := map[string]interface{}{
+			"weight": es.state.GetPlanetClassWeight(class),
+		}
+		if weight, ok := es.state.GetPendingPlanetClassWeight(class); ok {
+			jso["pendingWeight"] = weight
+		}
+		classes[class] = jso
+	}
+	return map[string]interface{}{
+		"height":             cc.BlockHeight(),
+		"denominator":        hvhmodule.PlanetWeightDenom,
+		"classes":            classes,
+		"allPlanetWeight":    es.state.GetAllPlanetWeight(),
+		"activePlanetWeight": es.state.GetActivePlanetWeight(),
+	}, nil
+}
+
+func (es *ExtensionStateImpl) GetPlanetWeight(cc hvhmodule.CallContext, id int64) (map[string]interface{}, error) {
+	p, err := es.state.GetPlanet(id)
+	if err != nil {
+		return nil, err
+	}
+	weight, individual, err := es.state.GetPlanetWeight(id)
+	if err != nil {
+		return nil, err
+	}
+	reward := es.state.GetActivePlanetReward(weight)
+	if es.state.GetActivePlanetWeight().Sign() == 0 {
+		_, reward = es.state.GetActivePlanetCountAndReward()
+	}
+	jso := map[string]interface{}{
+		"height":     cc.BlockHeight(),
+		"id":         id,
+		"class":      hvhstate.PlanetClassOf(p),
+		"weight":     weight,
+		"individual": individual,
+		"reward":     reward,
+	}
+	if pending, ok := es.state.GetPendingPlanetWeight(id); ok {
+		jso["pendingWeight"] = pending
+	}
+	return jso, nil
+}
@@ -0,0 +1,130 @@
+package hvh
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/havah/hvh/hvhstate"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+)
+
+func TestExtensionStateImpl_PlanetWeight(t *testing.T) {
+	var err error
+	termPeriod := int64(10)
+	issueAmount := toHVH(300)
+	owner := common.MustNewAddressFromString("hx1234")
+	denom := int64(hvhmodule.PlanetWeightDenom)
+
+	stateCfg := hvhstate.StateConfig{
+		TermPeriod:  &common.HexInt64{Value: termPeriod},
+		USDTPrice:   new(common.HexInt).SetValue(toHVH(1)),
+		IssueAmount: new(common.HexInt).SetValue(issueAmount),
+	}
+	mcc, es := newMockContextAndExtensionState(t, &PlatformConfig{StateConfig: stateCfg})
+	mcc.height = 1
+	mcc.SetRevision(hvhmodule.RevisionPlanetWeight)
+	cc := NewCallContext(mcc, nil)
+
+	err = es.StartRewardIssue(cc, 10)
+	assert.NoError(t, err)
+
+	// id 1: public, id 2: company
+	for id := int64(1); id <= 2; id++ {
+		err = es.RegisterPlanet(cc, id, false, id == 2, owner, toUSDT(1_000), toHVH(10_000))
+		assert.NoError(t, err)
+	}
+	err = es.SetPlanetClassWeight(hvhstate.PlanetClassCompany, 2*denom)
+	assert.NoError(t, err)
+
+	jso, err := es.GetPlanetWeights(cc)
+	assert.NoError(t, err)
+	classes := jso["classes"].(map[string]interface{})
+	company := classes[hvhstate.PlanetClassCompany].(map[string]interface{})
+	assert.Equal(t, denom, company["weight"])
+	assert.Equal(t, 2*denom, company["pendingWeight"])
+
+	// Pending weights are applied at the start of the next term
+	err = goToNextTerm(t, es, mcc, nil, 1)
+	assert.NoError(t, err)
+	assert.Zero(t, big.NewInt(3*denom).Cmp(es.state.GetActivePlanetWeight()))
+
+	jso, err = es.GetPlanetWeight(cc, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, hvhstate.PlanetClassCompany, jso["class"])
+	assert.Equal(t, 2*denom, jso["weight"])
+	assert.False(t, jso["individual"].(bool))
+	assert.Zero(t, toHVH(200).Cmp(jso["reward"].(*big.Int)))
+
+	err = es.ReportPlanetWorks(cc, []int64{1, 2})
+	assert.NoError(t, err)
+	offered := mcc.eventsOf(SigRewardOffered)
+	assert.Equal(t, 2, len(offered))
+	for i, expected := range []*big.Int{toHVH(100), toHVH(200)} {
+		reward := intconv.BigIntSetBytes(new(big.Int), offered[i].data[2])
+		assert.Zero(t, expected.Cmp(reward), "id=%d", i+1)
+	}
+
+	_, err = es.GetPlanetWeight(cc, 3)
+	assert.Error(t, err)
+}
+
+func TestExtensionStateImpl_PlanetWeightOfLegacyPlanets(t *testing.T) {
+	var err error
+	issueAmount := toHVH(300)
+	owner := common.MustNewAddressFromString("hx1234")
+	denom := int64(hvhmodule.PlanetWeightDenom)
+
+	stateCfg := hvhstate.StateConfig{
+		TermPeriod:  &common.HexInt64{Value: 10},
+		USDTPrice:   new(common.HexInt).SetValue(toHVH(1)),
+		IssueAmount: new(common.HexInt).SetValue(issueAmount),
+	}
+	mcc, es := newMockContextAndExtensionState(t, &PlatformConfig{StateConfig: stateCfg})
+	mcc.height = 1
+	mcc.SetRevision(hvhmodule.Revision4)
+	cc := NewCallContext(mcc, nil)
+
+	err = es.StartRewardIssue(cc, 10)
+	assert.NoError(t, err)
+	for id := int64(1); id <= 2; id++ {
+		err = es.RegisterPlanet(cc, id, false, true, owner, toUSDT(1_000), toHVH(10_000))
+		assert.NoError(t, err)
+	}
+	mcc.SetRevision(hvhmodule.RevisionPlanetWeight)
+	err = es.SetPlanetClassWeight(hvhstate.PlanetClassCompany, 2*denom)
+	assert.NoError(t, err)
+
+	checkOffered := func(n int, expected *big.Int) {
+		offered := mcc.eventsOf(SigRewardOffered)
+		assert.True(t, len(offered) >= n)
+		for _, e := range offered[len(offered)-n:] {
+			reward := intconv.BigIntSetBytes(new(big.Int), e.data[2])
+			assert.Zero(t, expected.Cmp(reward))
+		}
+	}
+
+	// Legacy planets have the default weight in the term when they are indexed
+	err = goToNextTerm(t, es, mcc, nil, 1)
+	assert.NoError(t, err)
+	assert.Zero(t, big.NewInt(2*denom).Cmp(es.state.GetActivePlanetWeight()))
+	err = es.IndexPlanets(cc, []int64{1})
+	assert.NoError(t, err)
+	err = es.ReportPlanetWorks(cc, []int64{1, 2})
+	assert.NoError(t, err)
+	checkOffered(2, toHVH(150))
+
+	// Planets which reported their work are weighted by their class from the next term
+	err = goToNextTerm(t, es, mcc, nil, 1)
+	assert.NoError(t, err)
+	assert.Zero(t, big.NewInt(4*denom).Cmp(es.state.GetActivePlanetWeight()))
+	jso, err := es.GetPlanetWeight(cc, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2*denom, jso["weight"])
+	err = es.ReportPlanetWorks(cc, []int64{1, 2})
+	assert.NoError(t, err)
+	checkOffered(2, toHVH(150))
+}
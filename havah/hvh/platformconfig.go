@@ -6,4 +6,7 @@ import (
 
 type PlatformConfig struct {
 	hvhstate.StateConfig
+
+	// State is imported at genesis to make a network which mirrors the state of another one
+	State *hvhstate.StateDump `json:"state,omitempty"`
 }
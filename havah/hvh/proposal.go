@@ -0,0 +1,134 @@
+package hvh
+
+import (
+	"github.com/icon-project/goloop/havah/hvh/hvhstate"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/module"
+)
+
+func (es *ExtensionStateImpl) SubmitProposal(
+	cc hvhmodule.CallContext, pType, value, description string) error {
+	height := cc.BlockHeight()
+	proposer := cc.From()
+	es.Logger().Debugf(
+		"SubmitProposal() start: height=%d proposer=%s type=%s value=%s", height, proposer, pType, value)
+
+	if _, err := newChangeParams(pType, []byte(value)); err != nil {
+		return err
+	}
+	id, err := es.state.SubmitProposal(proposer, pType, []byte(value), description, height)
+	if err != nil {
+		return err
+	}
+	onProposalSubmittedEvent(cc, id, proposer, pType)
+
+	es.Logger().Debugf("SubmitProposal() end: height=%d id=%d", height, id)
+	return nil
+}
+
+func (es *ExtensionStateImpl) VoteProposal(cc hvhmodule.CallContext, id int64, agree bool) error {
+	height := cc.BlockHeight()
+	voter := cc.From()
+	es.Logger().Debugf("VoteProposal() start: height=%d id=%d voter=%s agree=%t", height, id, voter, agree)
+
+	p, err := es.state.VoteProposal(id, voter, agree, height)
+	if err != nil {
+		return err
+	}
+	onProposalVotedEvent(cc, id, voter, agree)
+	if p.Status() != hvhstate.ProposalPending {
+		onProposalStatusChangedEvent(cc, id, p.Status().String())
+	}
+
+	es.Logger().Debugf("VoteProposal() end: height=%d %s", height, p)
+	return nil
+}
+
+func (es *ExtensionStateImpl) CancelProposal(cc hvhmodule.CallContext, id int64) error {
+	height := cc.BlockHeight()
+	es.Logger().Debugf("CancelProposal() start: height=%d id=%d from=%s", height, id, cc.From())
+
+	if err := es.state.CancelProposal(id, cc.From()); err != nil {
+		return err
+	}
+	onProposalStatusChangedEvent(cc, id, hvhstate.ProposalCanceled.String())
+
+	es.Logger().Debugf("CancelProposal() end: height=%d", height)
+	return nil
+}
+
+// handleProposals applies approved proposals and closes expired ones.
+// A proposal which fails to be applied is closed with failed status instead of making the base tx fail
+func (es *ExtensionStateImpl) handleProposals(cc hvhmodule.CallContext) error {
+	proposals, err := es.state.GetActiveProposals()
+	if err != nil || len(proposals) == 0 {
+		return err
+	}
+
+	height := cc.BlockHeight()
+	es.Logger().Debugf("handleProposals() start: height=%d proposals=%d", height, len(proposals))
+	for _, p := range proposals {
+		var status hvhstate.ProposalStatus
+		switch p.Status() {
+		case hvhstate.ProposalApproved:
+			status = hvhstate.ProposalApplied
+			if err = es.applyProposal(cc, p); err != nil {
+				es.Logger().Infof("Failed to apply a proposal: %s err=%v", p, err)
+				status = hvhstate.ProposalFailed
+			}
+		case hvhstate.ProposalPending:
+			if height <= p.EndHeight() {
+				continue
+			}
+			status = hvhstate.ProposalExpired
+		default:
+			continue
+		}
+		if err = es.state.CloseProposal(p.ID(), status); err != nil {
+			return err
+		}
+		onProposalStatusChangedEvent(cc, p.ID(), status.String())
+	}
+	es.Logger().Debugf("handleProposals() end: height=%d", height)
+	return nil
+}
+
+func (es *ExtensionStateImpl) applyProposal(cc hvhmodule.CallContext, p *hvhstate.Proposal) error {
+	params, err := newChangeParams(p.Type(), p.Value())
+	if err != nil {
+		return err
+	}
+	return es.applyChangeParams(cc, params)
+}
+
+func (es *ExtensionStateImpl) GetProposal(
+	cc hvhmodule.CallContext, id int64, voter module.Address) (map[string]interface{}, error) {
+	p, err := es.state.GetProposal(id)
+	if err != nil {
+		return nil, err
+	}
+	jso := p.ToJSON()
+	jso["height"] = cc.BlockHeight()
+	if voter != nil {
+		if agree, ok := es.state.GetProposalVote(id, voter); ok {
+			jso["vote"] = agree
+		}
+	}
+	return jso, nil
+}
+
+func (es *ExtensionStateImpl) GetProposals(cc hvhmodule.CallContext) (map[string]interface{}, error) {
+	ps, err := es.state.GetActiveProposals()
+	if err != nil {
+		return nil, err
+	}
+	proposals := make([]interface{}, len(ps))
+	for i, p := range ps {
+		proposals[i] = p.ToJSON()
+	}
+	return map[string]interface{}{
+		"height":           cc.BlockHeight(),
+		"proposalDuration": es.state.GetProposalDuration(),
+		"proposals":        proposals,
+	}, nil
+}
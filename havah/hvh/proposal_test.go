@@ -0,0 +1,148 @@
+package hvh
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/havah/hvh/hvhstate"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoredb"
+	"github.com/icon-project/goloop/service/state"
+)
+
+func TestNewChangeParams(t *testing.T) {
+	args := []struct {
+		pType string
+		value string
+		ok    bool
+	}{
+		{ParamTypeStepPrice, `{"price":"0x2e90edd00"}`, true},
+		{ParamTypeStepPrice, `{"price":"-0x1"}`, false},
+		{ParamTypeActiveValidatorCount, `{"count":"0x10"}`, true},
+		{ParamTypeActiveValidatorCount, `{"count":"0x0"}`, false},
+		{ParamTypeBlockVoteCheckParameters, `{"period":"0x1e","allowance":"0x14"}`, true},
+		{ParamTypePrivateClaimableRate, `{"numerator":"0x1","denominator":"0x2"}`, true},
+		{ParamTypePrivateClaimableRate, `{"numerator":"0x3","denominator":"0x2"}`, false},
+		{ParamTypeValidatorRewardRate, `{"rate":"0x3e8"}`, true},
+		{ParamTypeValidatorRewardRate, `{"rate":"0x2711"}`, false},
+		{ParamTypeJailParameters, `{"cooldown":"0x0"}`, false},
+		{ParamTypePriceReportParameters, `{"ttl":"0xa","minReports":"0x3"}`, true},
+		{ParamTypeProposalDuration, `{"duration":"0x64"}`, true},
+		{ParamTypeUSDTPrice, `{"price":"0x2386f26fc10000"}`, true},
+		{ParamTypeUSDTPrice, `{"price":"0x0"}`, false},
+		{ParamTypePlanetClassWeight, `{"class":"company","weight":"0x4e20"}`, true},
+		{ParamTypePlanetClassWeight, `{"class":"unknown","weight":"0x4e20"}`, false},
+		{ParamTypePlanetClassWeight, `{"class":"company","weight":"-0x1"}`, false},
+		{ParamTypeActiveValidatorCount, `{"count":"0x10","unknown":"0x1"}`, false},
+		{ParamTypeActiveValidatorCount, `invalid`, false},
+		{"revision", `{"revision":"0x9"}`, false},
+	}
+	for i, arg := range args {
+		name := fmt.Sprintf("%d-%s", i, arg.pType)
+		t.Run(name, func(t *testing.T) {
+			_, err := newChangeParams(arg.pType, []byte(arg.value))
+			assert.Equal(t, arg.ok, err == nil)
+		})
+	}
+}
+
+func TestExtensionStateImpl_Proposal(t *testing.T) {
+	var err error
+	mcc, es := newMockContextAndExtensionState(t, newSimplePlatformConfig(10, 1))
+	mcc.SetRevision(hvhmodule.RevisionGovernanceProposal)
+	mcc.setBlockHeight(100)
+
+	owners := make([]module.Address, 3)
+	for i := range owners {
+		owners[i] = common.MustNewAddressFromString(fmt.Sprintf("hx%d", i+1))
+		_, pubKey := crypto.GenerateKeyPair()
+		err = es.state.RegisterValidator(
+			owners[i], pubKey.SerializeCompressed(), hvhstate.GradeMain, fmt.Sprintf("main-%d", i), nil)
+		assert.NoError(t, err)
+	}
+
+	err = es.SubmitProposal(NewCallContext(mcc, owners[0]), ParamTypeActiveValidatorCount, `{"count":"0x0"}`, "")
+	assert.Error(t, err)
+	err = es.SubmitProposal(NewCallContext(mcc, owners[0]), ParamTypeActiveValidatorCount, `{"count":"0x7"}`, "")
+	assert.NoError(t, err)
+	err = es.SubmitProposal(NewCallContext(mcc, owners[1]), ParamTypeJailParameters, `{"cooldown":"0x64"}`, "")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(mcc.eventsOf(SigProposalSubmitted)))
+
+	for _, owner := range owners {
+		err = es.VoteProposal(NewCallContext(mcc, owner), 1, true)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 3, len(mcc.eventsOf(SigProposalVoted)))
+	assert.Equal(t, 1, len(mcc.eventsOf(SigProposalStatusChanged)))
+
+	// Approved proposals are applied by the base transaction
+	cc := NewCallContext(mcc, nil)
+	mcc.setBlockHeight(101)
+	err = es.handleProposals(cc)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), es.state.GetActiveValidatorCount())
+	jso, err := es.GetProposal(cc, 1, owners[0])
+	assert.NoError(t, err)
+	assert.Equal(t, hvhstate.ProposalApplied.String(), jso["status"])
+	assert.Equal(t, true, jso["vote"])
+
+	// Pending proposals expire after proposalDuration
+	mcc.setBlockHeight(101 + es.state.GetProposalDuration())
+	err = es.handleProposals(cc)
+	assert.NoError(t, err)
+	jso, err = es.GetProposal(cc, 2, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, hvhstate.ProposalExpired.String(), jso["status"])
+	assert.Equal(t, hvhmodule.JailCooldown, es.state.GetJailCooldown())
+
+	jso, err = es.GetProposals(cc)
+	assert.NoError(t, err)
+	assert.Zero(t, len(jso["proposals"].([]interface{})))
+}
+
+type partialFailParams struct {
+	cooldown int64
+	price    *big.Int
+}
+
+func (p *partialFailParams) validate() error {
+	return nil
+}
+
+func (p *partialFailParams) apply(es *ExtensionStateImpl, cc hvhmodule.CallContext) error {
+	if err := es.state.SetJailCooldown(p.cooldown); err != nil {
+		return err
+	}
+	as := cc.GetAccountState(state.SystemID)
+	if err := scoredb.NewVarDB(as, state.VarStepPrice).Set(p.price); err != nil {
+		return err
+	}
+	return errors.New("FailAfterWrites")
+}
+
+func TestExtensionStateImpl_applyChangeParams(t *testing.T) {
+	mcc, es := newMockContextAndExtensionState(t, newSimplePlatformConfig(10, 1))
+	mcc.SetRevision(hvhmodule.RevisionGovernanceProposal)
+	cc := NewCallContext(mcc, nil)
+
+	// Writes made before a failure are restored
+	err := es.applyChangeParams(cc, &partialFailParams{cooldown: 100, price: big.NewInt(1000)})
+	assert.Error(t, err)
+	assert.Equal(t, hvhmodule.JailCooldown, es.state.GetJailCooldown())
+	as := cc.GetAccountState(state.SystemID)
+	assert.Nil(t, scoredb.NewVarDB(as, state.VarStepPrice).BigInt())
+
+	params, err := newChangeParams(ParamTypeStepPrice, []byte(`{"price":"0x3e8"}`))
+	assert.NoError(t, err)
+	err = es.applyChangeParams(cc, params)
+	assert.NoError(t, err)
+	assert.Zero(t, big.NewInt(1000).Cmp(scoredb.NewVarDB(as, state.VarStepPrice).BigInt()))
+}
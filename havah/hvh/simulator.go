@@ -0,0 +1,392 @@
+/*
+ * Copyright 2023 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hvh
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/havah/hvhutils"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/contract"
+	"github.com/icon-project/goloop/service/scoredb"
+	"github.com/icon-project/goloop/service/state"
+	"github.com/icon-project/goloop/service/trace"
+)
+
+var (
+	// simTreasury collects tx fees in a simulation. It is always empty
+	simTreasury = common.MustNewAddressFromString("hxf100000000000000000000000000000000000000")
+	// simHolder holds the initial supply except for HooverFund and SustainableFund
+	simHolder = common.MustNewAddressFromString("hxf200000000000000000000000000000000000000")
+	// simPlanetOwner owns all planets registered in a simulation
+	simPlanetOwner = common.MustNewAddressFromString("hxf300000000000000000000000000000000000000")
+	simTxID        = []byte("simulation")
+)
+
+// SimPlanetGroup is a set of planets which have the same attributes
+type SimPlanetGroup struct {
+	Count     common.HexInt64 `json:"count"`
+	IsPrivate bool            `json:"isPrivate,omitempty"`
+	IsCompany bool            `json:"isCompany,omitempty"`
+	USDT      *common.HexInt  `json:"usdt"`            // unit: USDT decimal
+	Price     *common.HexInt  `json:"price,omitempty"` // unit: HVH; usdt * usdtPrice by default
+	// Working is the number of planets in this group which report their work every term.
+	// All planets are considered to be working if it is omitted
+	Working *common.HexInt64 `json:"working,omitempty"`
+}
+
+func (g *SimPlanetGroup) working() int64 {
+	if g.Working == nil || g.Working.Value > g.Count.Value {
+		return g.Count.Value
+	}
+	return g.Working.Value
+}
+
+func (g *SimPlanetGroup) price(usdtPrice *big.Int) *big.Int {
+	if g.Price != nil {
+		return g.Price.Value()
+	}
+	price := new(big.Int).Mul(g.USDT.Value(), usdtPrice)
+	return price.Div(price, hvhmodule.BigIntUSDTDecimal)
+}
+
+// SimulationConfig contains the assumptions of an issuance simulation
+type SimulationConfig struct {
+	PlatformConfig
+	Revision *common.HexInt64 `json:"revision,omitempty"` // LatestRevision by default
+	Terms    common.HexInt64  `json:"terms"`
+	// Initial balances; HooverFund is filled up to hooverBudget by default
+	TotalSupply     *common.HexInt    `json:"totalSupply,omitempty"`
+	HooverFund      *common.HexInt    `json:"hooverFund,omitempty"`
+	SustainableFund *common.HexInt    `json:"sustainableFund,omitempty"`
+	Planets         []*SimPlanetGroup `json:"planets"`
+}
+
+func (cfg *SimulationConfig) validate() error {
+	if cfg.Terms.Value < 1 {
+		return errors.IllegalArgumentError.Errorf("Invalid terms: %d", cfg.Terms.Value)
+	}
+	if cfg.TermPeriod != nil && cfg.TermPeriod.Value < 2 {
+		return errors.IllegalArgumentError.Errorf("Invalid termPeriod: %d", cfg.TermPeriod.Value)
+	}
+	if cfg.USDTPrice == nil || cfg.USDTPrice.Sign() <= 0 {
+		return errors.IllegalArgumentError.New("Invalid usdtPrice")
+	}
+	for i, g := range cfg.Planets {
+		if g.Count.Value < 0 || g.USDT == nil || g.USDT.Sign() < 0 ||
+			(g.Working != nil && g.Working.Value < 0) {
+			return errors.IllegalArgumentError.Errorf("Invalid planet group: index=%d", i)
+		}
+	}
+	return nil
+}
+
+// SimTermResult shows how coins are issued and distributed in a term
+type SimTermResult struct {
+	TermSequence    int64
+	IssueAmount     *big.Int
+	ActivePlanets   *big.Int
+	WorkingPlanets  int64
+	RewardPerPlanet *big.Int
+	// PlanetReward is the sum of rewards offered to working planets including hoover subsidies
+	PlanetReward *big.Int
+	HooverUsed   *big.Int
+	// The values below are settled at the end of a term
+	EcoSystemReward *big.Int
+	MissedReward    *big.Int
+	HooverRefill    *big.Int
+	TotalSupply     *big.Int
+	HooverFund      *big.Int
+	SustainableFund *big.Int
+}
+
+func (r *SimTermResult) ToJSON() map[string]interface{} {
+	return map[string]interface{}{
+		"termSequence":    r.TermSequence,
+		"issueAmount":     r.IssueAmount,
+		"activePlanets":   r.ActivePlanets,
+		"workingPlanets":  r.WorkingPlanets,
+		"rewardPerPlanet": r.RewardPerPlanet,
+		"planetReward":    r.PlanetReward,
+		"hooverUsed":      r.HooverUsed,
+		"ecoSystemReward": r.EcoSystemReward,
+		"missedReward":    r.MissedReward,
+		"hooverRefill":    r.HooverRefill,
+		"totalSupply":     r.TotalSupply,
+		"hooverFund":      r.HooverFund,
+		"sustainableFund": r.SustainableFund,
+	}
+}
+
+// simWorld provides the minimum of contract.CallContext which a simulation needs
+type simWorld struct {
+	contract.CallContext
+	ws       state.WorldState
+	height   int64
+	revision module.Revision
+	logger   *trace.Logger
+}
+
+func (w *simWorld) BlockHeight() int64 {
+	return w.height
+}
+
+func (w *simWorld) Revision() module.Revision {
+	return w.revision
+}
+
+func (w *simWorld) TransactionID() []byte {
+	return simTxID
+}
+
+func (w *simWorld) Treasury() module.Address {
+	return simTreasury
+}
+
+func (w *simWorld) GetAccountState(id []byte) state.AccountState {
+	return w.ws.GetAccountState(id)
+}
+
+func (w *simWorld) OnEvent(_ module.Address, _, _ [][]byte) {}
+
+func (w *simWorld) FrameLogger() *trace.Logger {
+	return w.logger
+}
+
+func (w *simWorld) ReadOnlyMode() bool {
+	return false
+}
+
+// Simulator runs the same reward logic as the one executed on-chain
+// with the assumed planets and returns per-term issuance results
+type Simulator struct {
+	cfg   *SimulationConfig
+	es    *ExtensionStateImpl
+	world *simWorld
+	cc    hvhmodule.CallContext
+}
+
+func NewSimulator(cfg *SimulationConfig, logger log.Logger) (*Simulator, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	if logger == nil {
+		logger = log.GlobalLogger()
+	}
+
+	dbase := db.NewMapDB()
+	es := NewExtensionSnapshot(dbase, nil).NewState(false).(*ExtensionStateImpl)
+	es.SetLogger(hvhutils.NewLogger(logger))
+	if err := es.InitPlatformConfig(&cfg.PlatformConfig); err != nil {
+		return nil, err
+	}
+
+	rev := hvhmodule.LatestRevision
+	if cfg.Revision != nil {
+		rev = int(cfg.Revision.Value)
+	}
+	world := &simWorld{
+		ws:       state.NewWorldState(dbase, nil, nil, nil, nil),
+		revision: hvhmodule.ValueToRevision(rev),
+		logger:   trace.LoggerOf(logger),
+	}
+	sim := &Simulator{
+		cfg:   cfg,
+		es:    es,
+		world: world,
+		cc:    NewCallContext(world, state.SystemAddress),
+	}
+	if err := sim.init(); err != nil {
+		return nil, err
+	}
+	return sim, nil
+}
+
+func (sim *Simulator) init() error {
+	cfg := sim.cfg
+	cc := sim.cc
+
+	hooverFund := sim.es.state.GetHooverBudget()
+	if cfg.HooverFund != nil {
+		hooverFund = cfg.HooverFund.Value()
+	}
+	sustainableFund := hvhmodule.BigIntZero
+	if cfg.SustainableFund != nil {
+		sustainableFund = cfg.SustainableFund.Value()
+	}
+	rest := hvhmodule.BigIntZero
+	if cfg.TotalSupply != nil {
+		rest = new(big.Int).Sub(cfg.TotalSupply.Value(), hooverFund)
+		rest.Sub(rest, sustainableFund)
+		if rest.Sign() < 0 {
+			return errors.IllegalArgumentError.Errorf(
+				"TotalSupply is less than initial funds: ts=%d hf=%d sf=%d",
+				cfg.TotalSupply.Value(), hooverFund, sustainableFund)
+		}
+	}
+	for _, item := range []struct {
+		to     module.Address
+		amount *big.Int
+	}{
+		{hvhmodule.HooverFund, hooverFund},
+		{hvhmodule.SustainableFund, sustainableFund},
+		{simHolder, rest},
+	} {
+		if _, err := cc.Issue(item.to, item.amount); err != nil {
+			return err
+		}
+	}
+
+	// Planets are registered before the first term to be active from the beginning
+	if err := sim.es.StartRewardIssue(cc, 1); err != nil {
+		return err
+	}
+	usdtPrice := cfg.USDTPrice.Value()
+	id := int64(1)
+	for _, g := range cfg.Planets {
+		price := g.price(usdtPrice)
+		for i := int64(0); i < g.Count.Value; i++ {
+			if err := sim.es.RegisterPlanet(
+				cc, id, g.IsPrivate, g.IsCompany, simPlanetOwner, g.USDT.Value(), price); err != nil {
+				return err
+			}
+			id++
+		}
+	}
+	return nil
+}
+
+func (sim *Simulator) sustainableFundVar(key string) *big.Int {
+	as := sim.cc.GetAccountState(hvhmodule.SustainableFund.ID())
+	if value := scoredb.NewVarDB(as, key).BigInt(); value != nil {
+		return value
+	}
+	return hvhmodule.BigIntZero
+}
+
+func (sim *Simulator) onBaseTx(height int64) (*big.Int, error) {
+	sim.world.height = height
+	issueAmount := hvhmodule.BigIntZero
+	data := sim.es.NewBaseTransactionData(height)
+	if data == nil {
+		data = map[string]interface{}{
+			"issueAmount": new(common.HexInt).SetValue(issueAmount),
+		}
+	} else {
+		issueAmount = data["issueAmount"].(*common.HexInt).Value()
+	}
+	bs, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return issueAmount, sim.es.OnBaseTx(sim.cc, bs)
+}
+
+// Run executes the simulation for the configured number of terms
+func (sim *Simulator) Run() ([]*SimTermResult, error) {
+	cc := sim.cc
+	es := sim.es
+	issueStart := es.GetIssueStart()
+	termPeriod := es.GetTermPeriod()
+	terms := sim.cfg.Terms.Value
+	results := make([]*SimTermResult, 0, terms)
+
+	// One more baseTx is executed to settle the last term
+	for termSeq := int64(0); termSeq <= terms; termSeq++ {
+		height := issueStart + termSeq*termPeriod
+		ecoSystem := cc.GetBalance(hvhmodule.EcoSystem)
+		missed := sim.sustainableFundVar(hvhmodule.VarMissingReward)
+		refill := sim.sustainableFundVar(hvhmodule.VarHooverRefill)
+
+		issueAmount, err := sim.onBaseTx(height)
+		if err != nil {
+			return nil, err
+		}
+		if termSeq > 0 {
+			r := results[termSeq-1]
+			r.EcoSystemReward = new(big.Int).Sub(cc.GetBalance(hvhmodule.EcoSystem), ecoSystem)
+			r.MissedReward = new(big.Int).Sub(sim.sustainableFundVar(hvhmodule.VarMissingReward), missed)
+			r.HooverRefill = new(big.Int).Sub(sim.sustainableFundVar(hvhmodule.VarHooverRefill), refill)
+			r.HooverFund = cc.GetBalance(hvhmodule.HooverFund)
+			r.SustainableFund = cc.GetBalance(hvhmodule.SustainableFund)
+		}
+		if termSeq == terms {
+			break
+		}
+
+		r := &SimTermResult{
+			TermSequence: termSeq,
+			IssueAmount:  issueAmount,
+			TotalSupply:  cc.GetTotalSupply(),
+		}
+		r.ActivePlanets, r.RewardPerPlanet = es.state.GetActivePlanetCountAndReward()
+
+		// Working planets report their work at the second block of each term
+		sim.world.height = height + 1
+		hooverFund := cc.GetBalance(hvhmodule.HooverFund)
+		r.PlanetReward = new(big.Int)
+		id := int64(1)
+		for _, g := range sim.cfg.Planets {
+			working := g.working()
+			for i := int64(0); i < working; i++ {
+				reward, err := sim.reportPlanetWork(id + i)
+				if err != nil {
+					return nil, err
+				}
+				r.PlanetReward.Add(r.PlanetReward, reward)
+			}
+			r.WorkingPlanets += working
+			id += g.Count.Value
+		}
+		r.HooverUsed = new(big.Int).Sub(hooverFund, cc.GetBalance(hvhmodule.HooverFund))
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// reportPlanetWork reports the work of a planet and returns the reward offered to it,
+// which depends on its weight and includes hoover subsidies
+func (sim *Simulator) reportPlanetWork(id int64) (*big.Int, error) {
+	es := sim.es
+	before, err := es.state.GetPlanetReward(id)
+	if err != nil {
+		return nil, err
+	}
+	if err = es.ReportPlanetWork(sim.cc, id); err != nil {
+		return nil, err
+	}
+	after, err := es.state.GetPlanetReward(id)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Sub(after.Total(), before.Total()), nil
+}
+
+// Simulate is a shortcut to run a Simulator with a given config
+func Simulate(cfg *SimulationConfig, logger log.Logger) ([]*SimTermResult, error) {
+	sim, err := NewSimulator(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	return sim.Run()
+}
@@ -0,0 +1,133 @@
+package hvh
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/havah/hvh/hvhstate"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+)
+
+func TestSimulator(t *testing.T) {
+	const (
+		terms        = 5
+		activeCount  = 10
+		workingCount = 8
+	)
+	cfgJSON := `{
+		"termPeriod": "0xa",
+		"issueAmount": "5000000000000000000000",
+		"hooverBudget": "10000000000000000000000",
+		"usdtPrice": "100000000000000000000",
+		"terms": 5,
+		"sustainableFund": "1000000000000000000000000",
+		"planets": [
+			{"count": 6, "usdt": "36000000000", "working": 4},
+			{"count": 4, "usdt": "1000000000", "isCompany": true}
+		]
+	}`
+	cfg := new(SimulationConfig)
+	err := json.Unmarshal([]byte(cfgJSON), cfg)
+	assert.NoError(t, err)
+
+	results, err := Simulate(cfg, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, terms, len(results))
+
+	issueAmount := toHVH(5_000)
+	reward := toHVH(5_000 / activeCount)
+	ecoProportion := hvhmodule.BigRatEcoSystemToCompanyReward
+	totalSupply := new(big.Int).Add(toHVH(10_000), toHVH(1_000_000))
+	for i, r := range results {
+		assert.Equal(t, int64(i), r.TermSequence)
+		assert.Zero(t, issueAmount.Cmp(r.IssueAmount))
+		assert.Zero(t, r.ActivePlanets.Cmp(big.NewInt(activeCount)))
+		assert.Equal(t, int64(workingCount), r.WorkingPlanets)
+		assert.Zero(t, reward.Cmp(r.RewardPerPlanet))
+
+		totalSupply.Add(totalSupply, issueAmount)
+		assert.Zero(t, totalSupply.Cmp(r.TotalSupply))
+
+		// Only the planets in the first group get hoover subsidies
+		assert.True(t, r.HooverUsed.Sign() > 0)
+		expPlanetReward := new(big.Int).Mul(reward, big.NewInt(workingCount))
+		expPlanetReward.Add(expPlanetReward, r.HooverUsed)
+		assert.Zero(t, expPlanetReward.Cmp(r.PlanetReward))
+
+		// 2 planets in the first group don't work
+		assert.Zero(t, new(big.Int).Mul(reward, big.NewInt(2)).Cmp(r.MissedReward))
+
+		// A part of company planet rewards goes to EcoSystem
+		ecoReward := new(big.Int).Mul(reward, ecoProportion.Num())
+		ecoReward.Div(ecoReward, ecoProportion.Denom())
+		ecoReward.Mul(ecoReward, big.NewInt(4))
+		assert.Zero(t, ecoReward.Cmp(r.EcoSystemReward))
+
+		// HooverFund is refilled by SustainableFund at the end of every term
+		assert.Zero(t, r.HooverUsed.Cmp(r.HooverRefill))
+		assert.Zero(t, toHVH(10_000).Cmp(r.HooverFund))
+	}
+}
+
+func TestSimulator_PlanetWeight(t *testing.T) {
+	cfgJSON := `{
+		"termPeriod": "0xa",
+		"issueAmount": "6000000000000000000000",
+		"hooverBudget": "10000000000000000000000",
+		"usdtPrice": "100000000000000000000",
+		"terms": 3,
+		"sustainableFund": "1000000000000000000000000",
+		"planets": [
+			{"count": 6, "usdt": "36000000000", "working": 4},
+			{"count": 4, "usdt": "1000000000", "isCompany": true}
+		]
+	}`
+	cfg := new(SimulationConfig)
+	err := json.Unmarshal([]byte(cfgJSON), cfg)
+	assert.NoError(t, err)
+
+	sim, err := NewSimulator(cfg, nil)
+	assert.NoError(t, err)
+	// Company planets get no reward, so the others share the whole issue amount
+	err = sim.es.state.SetPlanetClassWeight(hvhstate.PlanetClassCompany, 0)
+	assert.NoError(t, err)
+
+	results, err := sim.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(results))
+
+	// Rewards missed in a term are carried over to the next term
+	assert.Zero(t, toHVH(6_000/6).Cmp(results[0].RewardPerPlanet))
+	for _, r := range results {
+		assert.Equal(t, int64(8), r.WorkingPlanets)
+		// Only 4 working planets which are not company ones get rewards
+		expPlanetReward := new(big.Int).Mul(r.RewardPerPlanet, big.NewInt(4))
+		expPlanetReward.Add(expPlanetReward, r.HooverUsed)
+		assert.Zero(t, expPlanetReward.Cmp(r.PlanetReward))
+		assert.Zero(t, r.EcoSystemReward.Sign())
+	}
+}
+
+func TestSimulator_InvalidConfig(t *testing.T) {
+	cfgs := []*SimulationConfig{
+		{Terms: common.HexInt64{Value: 0}},
+		{Terms: common.HexInt64{Value: 1}},
+		{
+			PlatformConfig: *newSimplePlatformConfig(1, 10),
+			Terms:          common.HexInt64{Value: 1},
+		},
+		{
+			PlatformConfig: *newSimplePlatformConfig(10, 10),
+			Terms:          common.HexInt64{Value: 1},
+			Planets:        []*SimPlanetGroup{{Count: common.HexInt64{Value: 1}}},
+		},
+	}
+	for _, cfg := range cfgs {
+		_, err := Simulate(cfg, nil)
+		assert.Error(t, err)
+	}
+}
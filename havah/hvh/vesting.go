@@ -0,0 +1,79 @@
+package hvh
+
+import (
+	"github.com/icon-project/goloop/havah/hvh/hvhstate"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+)
+
+func (es *ExtensionStateImpl) AddVestingSchedule(
+	cc hvhmodule.CallContext, name, vType string, cliff, duration, steps int64) error {
+	es.Logger().Debugf(
+		"AddVestingSchedule() start: name=%s type=%s cliff=%d duration=%d steps=%d",
+		name, vType, cliff, duration, steps)
+
+	vt, err := hvhstate.ParseVestingType(vType)
+	if err != nil {
+		return err
+	}
+	vs, err := hvhstate.NewVestingSchedule(name, vt, cliff, duration, steps)
+	if err != nil {
+		return err
+	}
+	if err = es.state.AddVestingSchedule(vs); err != nil {
+		return err
+	}
+	onVestingScheduleAddedEvent(cc, name, vType)
+
+	es.Logger().Debugf("AddVestingSchedule() end: %s", vs)
+	return nil
+}
+
+func (es *ExtensionStateImpl) SetClassVesting(class, name string) error {
+	es.Logger().Debugf("SetClassVesting() start: class=%s name=%s", class, name)
+	defer es.Logger().Debugf("SetClassVesting() end")
+	return es.state.SetClassVesting(class, name)
+}
+
+func (es *ExtensionStateImpl) SetPlanetVesting(cc hvhmodule.CallContext, id int64, name string) error {
+	es.Logger().Debugf("SetPlanetVesting() start: id=%d name=%s", id, name)
+
+	if err := es.state.SetPlanetVesting(id, name); err != nil {
+		return err
+	}
+	onPlanetVestingChangedEvent(cc, id, name)
+
+	es.Logger().Debugf("SetPlanetVesting() end: id=%d", id)
+	return nil
+}
+
+func (es *ExtensionStateImpl) GetVestingSchedules(cc hvhmodule.CallContext) (map[string]interface{}, error) {
+	schedules, err := es.state.GetVestingSchedules()
+	if err != nil {
+		return nil, err
+	}
+	jso := make([]interface{}, len(schedules))
+	for i, vs := range schedules {
+		jso[i] = vs.ToJSON()
+	}
+	classes := make(map[string]interface{})
+	for _, class := range hvhstate.PlanetClasses() {
+		if name := es.state.GetClassVesting(class); len(name) > 0 {
+			classes[class] = name
+		}
+	}
+	return map[string]interface{}{
+		"height":    cc.BlockHeight(),
+		"schedules": jso,
+		"classes":   classes,
+	}, nil
+}
+
+func (es *ExtensionStateImpl) GetPlanetVestingInfo(cc hvhmodule.CallContext, id int64) (map[string]interface{}, error) {
+	height := cc.BlockHeight()
+	jso, err := es.state.GetPlanetVestingInfo(height, id)
+	if err != nil {
+		return nil, err
+	}
+	jso["height"] = height
+	return jso, nil
+}
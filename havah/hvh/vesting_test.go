@@ -0,0 +1,50 @@
+package hvh
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/havah/hvh/hvhstate"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+)
+
+func TestExtensionStateImpl_VestingSchedule(t *testing.T) {
+	var err error
+	mcc, es := newMockContextAndExtensionState(t, newSimplePlatformConfig(10, 1))
+	mcc.SetRevision(hvhmodule.RevisionVestingSchedule)
+	mcc.setBlockHeight(100)
+	cc := NewCallContext(mcc, nil)
+
+	err = es.AddVestingSchedule(cc, "partner", "unknown", 0, 100, 0)
+	assert.Error(t, err)
+	err = es.AddVestingSchedule(cc, "partner", "step", 0, 100, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(mcc.eventsOf(SigVestingScheduleAdded)))
+
+	err = es.SetClassVesting(hvhstate.PlanetClassPrivate, "partner")
+	assert.NoError(t, err)
+
+	owner := common.MustNewAddressFromString("hx1234")
+	err = es.RegisterPlanet(cc, 1, true, false, owner, big.NewInt(1000), toHVH(1000))
+	assert.NoError(t, err)
+
+	jso, err := es.GetPlanetVestingInfo(cc, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, hvhstate.PlanetClassPrivate, jso["class"])
+	assert.Equal(t, "partner", jso["schedule"].(map[string]interface{})["name"])
+
+	err = es.SetPlanetVesting(cc, 1, "")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(mcc.eventsOf(SigPlanetVestingChanged)))
+	jso, err = es.GetPlanetVestingInfo(cc, 1)
+	assert.NoError(t, err)
+	assert.Nil(t, jso["schedule"])
+
+	jso, err = es.GetVestingSchedules(cc)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(jso["schedules"].([]interface{})))
+	assert.Equal(t, "partner", jso["classes"].(map[string]interface{})[hvhstate.PlanetClassPrivate])
+}
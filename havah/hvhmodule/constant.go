@@ -38,6 +38,43 @@ const (
 	RoundLimitFactor = 3
 	MaxPlanetCount   = 50000
 	MaxCountToClaim  = 50
+	MaxCountToReport = 100
+	MaxCountToIndex  = 100
+	MaxCountToQuery  = 100
+
+	// MaxPriceReporterCount limits the number of price reporters whose reports are aggregated at every term start
+	MaxPriceReporterCount = 30
+
+	// RewardHistoryPeriod is the number of recent terms whose rewards are kept for each planet
+	RewardHistoryPeriod = DayPerMonth * 3
+
+	// FundLedgerPeriod is the number of recent terms whose ledgers are kept for HooverFund and SustainableFund
+	FundLedgerPeriod = DayPerYear
+
+	// MaxVestingScheduleCount limits the number of named vesting schedules for planet rewards
+	MaxVestingScheduleCount   = 100
+	MaxVestingScheduleNameLen = 64
+	// MaxVestingSteps keeps elapsed*steps of a step vesting schedule from overflowing int64
+	MaxVestingSteps = 10_000
+
+	// PlanetWeightDenom is the denominator of planet reward weights. A planet of weight PlanetWeightDenom gets
+	// the same reward as the one before planet weights were introduced
+	PlanetWeightDenom = 10_000
+	MaxPlanetWeight   = PlanetWeightDenom * 10
+
+	// MaxPendingPlanetWeights limits the number of planets whose weights are changed at the next term
+	MaxPendingPlanetWeights = 100
+
+	// Limits of multisig accounts and the operations which they approve
+	MaxMultisigSigners           = 20
+	MaxMultisigsPerCreator       = 10
+	MaxPendingMultisigOperations = 20
+	MaxMultisigMethodLen         = 64
+	MaxMultisigParamsLen         = 4096
+
+	// MultisigOperationLifetime is the number of blocks after its submission in which an operation can be approved
+	// and executed. An expired operation can be canceled by any signer to release its pending slot
+	MultisigOperationLifetime = DayBlock * 7
 
 	StepPrice          = 12500000000
 	MaxStepLimitInvoke = 2500000000
@@ -72,6 +109,9 @@ const (
 	HooverBudget         = 4_300_000                // unit: HVH
 	IssueLimit           = 50 * IssueReductionCycle // unit: term
 	PrivateClaimableRate = MonthPerYear * 2         // 0 / 24
+	PriceReportTTL       = DayBlock                 // unit: block
+	MinPriceReports      = 1                        // unit: count
+	ValidatorRewardRate  = 0                        // unit: 1 / ValidatorRewardRateDenom
 )
 
 // VarDB, DictDB, ArrayDB keys
@@ -106,6 +146,60 @@ const (
 	VarSubValidatorsIndex   = "sub_validators_index"
 	VarNetworkStatus        = "network_status"
 	ArrayDisqualifiedValidators = "disqualified_validators"
+	DictPlanetRewardHistory     = "planet_reward_history"
+	DictPlanetRewardTerms       = "planet_reward_terms"
+	DictPlanetClaimConfig       = "planet_claim_config"
+	ArrayPlanetsOf              = "planets_of"
+	DictPlanetIndex             = "planet_index"
+	VarIndexedPlanet            = "indexed_planet"
+	ArrayPriceReporter          = "price_reporter"
+	DictPriceReport             = "price_report"
+	VarPriceReportTTL           = "price_report_ttl"  // unit: block
+	VarMinPriceReports          = "min_price_reports" // unit: count
+	VarValidatorRewardRate      = "validator_reward_rate"
+	VarValidatorRewardPool      = "validator_reward_pool" // unit: hvh
+	VarValidatorVoteTotal       = "validator_vote_total"
+	ArrayValidatorsVoted        = "validators_voted"
+	DictValidatorVotes          = "validator_votes"
+	DictValidatorReward         = "validator_reward"
+	DictDoubleSignEvidence      = "double_sign_evidence"
+	VarJailCooldown             = "jail_cooldown" // unit: block
+	ArrayJailedValidators       = "jailed_validators"
+	DictJailedUntil             = "jailed_until"
+	DictValidatorUptime         = "validator_uptime"
+	VarProposalID               = "proposal_id"
+	VarProposalDuration         = "proposal_duration" // unit: block
+	DictProposal                = "proposal"
+	DictProposalVotes           = "proposal_votes"
+	DictProposalVoters          = "proposal_voters"
+	ArrayActiveProposals        = "active_proposals"
+	VarParameterChangeID        = "parameter_change_id"
+	DictParameterChange         = "parameter_change"
+	ArrayPendingChanges         = "pending_changes"
+	DictVestingSchedule         = "vesting_schedule"
+	ArrayVestingSchedules       = "vesting_schedules"
+	DictClassVesting            = "class_vesting"
+	DictPlanetVesting           = "planet_vesting"
+	DictPlanetClassWeight       = "planet_class_weight"
+	DictPlanetClassCount        = "planet_class_count"
+	DictPlanetWeight            = "planet_weight"
+	VarPlanetWeightCount        = "planet_weight_count"
+	VarPlanetWeightSum          = "planet_weight_sum"
+	VarActivePlanetWeight       = "active_planet_weight"
+	DictPlanetWeighted          = "planet_weighted"
+	ArrayPendingWeightedPlanets = "pending_weighted_planets"
+	DictPendingClassWeight      = "pending_class_weight"
+	DictPendingPlanetWeight     = "pending_planet_weight"
+	ArrayPendingPlanetWeights   = "pending_planet_weights"
+	VarMultisigCount            = "multisig_count"
+	DictMultisig                = "multisig"
+	VarMultisigOperationID      = "multisig_operation_id"
+	DictMultisigOperation       = "multisig_operation"
+	DictMultisigPendingCount    = "multisig_pending_count"
+	DictMultisigCreatorCount    = "multisig_creator_count"
+	DictFundLedger              = "fund_ledger"
+	DictFundLedgerCurrent       = "fund_ledger_current"
+	DictPlanetNodeKey           = "planet_node_key"
 )
 
 // VarDBs in SustainableFund Score
@@ -134,6 +228,15 @@ const (
 	MaxValidatorNameLen        = 100
 	MaxValidatorUrlLen         = 200
 	MaxEnableCount             = 3
+	JailCooldown         int64 = DayBlock     // unit: block
+	ProposalDuration     int64 = DayBlock * 7 // unit: block
+	MaxActiveProposals         = 10
+	MaxProposalDescriptionLen  = 1024
+	MaxProposalValueLen        = 512
+	MaxPendingChanges          = 20
+
+	// ValidatorRewardRateDenom is the denominator of validatorRewardRate, which is the share of issuance for validators
+	ValidatorRewardRateDenom = 10_000
 )
 
 // BTP
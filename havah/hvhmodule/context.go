@@ -33,6 +33,7 @@ type WorldContext interface {
 
 type CallContext interface {
 	WorldContext
+	ChainID() int
 	From() module.Address
 	SumOfStepUsed() *big.Int
 	OnEvent(addr module.Address, indexed, data [][]byte)
@@ -27,6 +27,7 @@ const (
 	Revision5
 	Revision6
 	Revision7
+	Revision8
 	RevisionReserved
 )
 
@@ -52,6 +53,24 @@ const (
 
 	// Do not use Revision7 for the reason that the revision is reserved for VegaNet problem replay
 	RevisionFixVegaNetProblem = Revision7
+
+	RevisionPlanetRewardHistory = Revision8
+	RevisionBatchedPlanetWork   = Revision8
+	RevisionPlanetClaimDelegate = Revision8
+	RevisionPlanetOwnerIndex    = Revision8
+	RevisionPriceOracle         = Revision8
+	RevisionValidatorReward     = Revision8
+	RevisionDoubleSignSlashing  = Revision8
+	RevisionValidatorJail       = Revision8
+	RevisionValidatorUptime     = Revision8
+	RevisionGovernanceProposal  = Revision8
+	RevisionParameterChange     = Revision8
+	RevisionVestingSchedule     = Revision8
+	RevisionPlanetWeight        = Revision8
+	RevisionClaimAllPlanets     = Revision8
+	RevisionMultisig            = Revision8
+	RevisionFundLedger          = Revision8
+	RevisionPlanetNodeKey       = Revision8
 )
 
 var revisionFlags = []module.Revision{
@@ -72,6 +91,10 @@ var revisionFlags = []module.Revision{
 	0,
 	// Revision 7
 	0,
+	// Revision 8
+	// ReportDoubleSign is the same bit as UseNIDInConsensusMessage,
+	// so network ID is also included in consensus messages since this revision
+	module.ReportDoubleSign,
 }
 
 func init() {
@@ -5,6 +5,7 @@ import (
 
 	"github.com/icon-project/goloop/chain/base"
 	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/codec"
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/common/log"
@@ -14,6 +15,7 @@ import (
 	"github.com/icon-project/goloop/havah/hvh/hvhstate"
 	"github.com/icon-project/goloop/havah/hvhmodule"
 	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service"
 	"github.com/icon-project/goloop/service/contract"
 	"github.com/icon-project/goloop/service/platform/basic"
 	"github.com/icon-project/goloop/service/state"
@@ -45,6 +47,30 @@ func (p *platform) NewExtensionWithBuilder(builder merkle.Builder, raw []byte) s
 	return hvh.NewExtensionSnapshotWithBuilder(builder, raw)
 }
 
+// ShowDiff shows the differences between two extension states.
+// The keys shown are the hashed ones stored in the extension state
+func (p *platform) ShowDiff(ctx service.DiffContext, name string, e, r []byte) error {
+	eHash, err := extensionStateHash(e)
+	if err != nil {
+		return err
+	}
+	rHash, err := extensionStateHash(r)
+	if err != nil {
+		return err
+	}
+	return ctx.ShowBytesMPTDiff(name, ctx.Database(), eHash, rHash)
+}
+
+func extensionStateHash(raw []byte) ([]byte, error) {
+	var hash []byte
+	if len(raw) > 0 {
+		if _, err := codec.BC.UnmarshalFromBytes(raw, &hash); err != nil {
+			return nil, err
+		}
+	}
+	return hash, nil
+}
+
 func (p *platform) ToRevision(value int) module.Revision {
 	return hvhmodule.ValueToRevision(value)
 }
@@ -121,7 +147,11 @@ func (p *platform) OnValidateTransactions(wc state.WorldContext, patches, txs mo
 }
 
 func (p *platform) OnExecutionBegin(wc state.WorldContext, logger log.Logger) error {
-	return nil
+	es := hvh.GetExtensionStateFromWorldContext(wc, logger)
+	if es == nil {
+		return nil
+	}
+	return es.OnExecutionBegin(wc)
 }
 
 func (p *platform) OnExecutionEnd(wc state.WorldContext, _ base.ExecutionResult, logger log.Logger) error {
@@ -0,0 +1,316 @@
+package havah
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/icon-project/goloop/block"
+	"github.com/icon-project/goloop/chain"
+	"github.com/icon-project/goloop/chain/gs"
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/consensus"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service"
+	"github.com/icon-project/goloop/service/contract"
+	"github.com/icon-project/goloop/service/eeproxy"
+	"github.com/icon-project/goloop/service/state"
+	"github.com/icon-project/goloop/service/txresult"
+)
+
+var dryRunTxHash = []byte("revision dry-run")
+
+var errNotForDryRun = errors.UnsupportedError.New("NotSupportedInDryRun")
+
+// dryRunChain provides the chain properties which chainScore refers to in a dry run.
+// It has no running components, so it returns nil for them and an error for any operation
+type dryRunChain struct {
+	dbase  db.Database
+	cid    int
+	logger log.Logger
+}
+
+func (c *dryRunChain) Database() db.Database {
+	return c.dbase
+}
+
+func (c *dryRunChain) DoDBTask(f func(database db.Database)) {
+	f(c.dbase)
+}
+
+func (c *dryRunChain) Wallet() module.Wallet {
+	return nil
+}
+
+func (c *dryRunChain) NID() int {
+	return 0
+}
+
+func (c *dryRunChain) CID() int {
+	return c.cid
+}
+
+func (c *dryRunChain) NetID() int {
+	return 0
+}
+
+func (c *dryRunChain) Channel() string {
+	return ""
+}
+
+func (c *dryRunChain) ConcurrencyLevel() int {
+	return 1
+}
+
+func (c *dryRunChain) NormalTxPoolSize() int {
+	return chain.ConfigDefaultNormalTxPoolSize
+}
+
+func (c *dryRunChain) PatchTxPoolSize() int {
+	return chain.ConfigDefaultPatchTxPoolSize
+}
+
+func (c *dryRunChain) MaxBlockTxBytes() int {
+	return chain.ConfigDefaultMaxBlockTxBytes
+}
+
+func (c *dryRunChain) DefaultWaitTimeout() time.Duration {
+	return 0
+}
+
+func (c *dryRunChain) MaxWaitTimeout() time.Duration {
+	return 0
+}
+
+func (c *dryRunChain) TransactionTimeout() time.Duration {
+	return chain.ConfigDefaultTxTimeout
+}
+
+func (c *dryRunChain) ChildrenLimit() int {
+	return chain.ConfigDefaultChildrenLimit
+}
+
+func (c *dryRunChain) NephewsLimit() int {
+	return chain.ConfigDefaultNephewLimit
+}
+
+func (c *dryRunChain) ValidateTxOnSend() bool {
+	return false
+}
+
+func (c *dryRunChain) TxAddressIndex() bool {
+	return false
+}
+
+func (c *dryRunChain) Genesis() []byte {
+	return nil
+}
+
+func (c *dryRunChain) GenesisStorage() module.GenesisStorage {
+	return gs.NewFromTx(nil)
+}
+
+func (c *dryRunChain) CommitVoteSetDecoder() module.CommitVoteSetDecoder {
+	return func(bytes []byte) module.CommitVoteSet {
+		return consensus.NewCommitVoteSetFromBytes(bytes)
+	}
+}
+
+func (c *dryRunChain) PatchDecoder() module.PatchDecoder {
+	return consensus.DecodePatch
+}
+
+func (c *dryRunChain) PlatformName() string {
+	return "havah"
+}
+
+func (c *dryRunChain) BlockManager() module.BlockManager {
+	return nil
+}
+
+func (c *dryRunChain) Consensus() module.Consensus {
+	return nil
+}
+
+func (c *dryRunChain) ServiceManager() module.ServiceManager {
+	return nil
+}
+
+func (c *dryRunChain) NetworkManager() module.NetworkManager {
+	return nil
+}
+
+func (c *dryRunChain) GetLocatorManager() (module.LocatorManager, error) {
+	return nil, errNotForDryRun
+}
+
+func (c *dryRunChain) Regulator() module.Regulator {
+	return nil
+}
+
+func (c *dryRunChain) Init() error {
+	return errNotForDryRun
+}
+
+func (c *dryRunChain) Start() error {
+	return errNotForDryRun
+}
+
+func (c *dryRunChain) Stop() error {
+	return errNotForDryRun
+}
+
+func (c *dryRunChain) Import(src string, height int64) error {
+	return errNotForDryRun
+}
+
+func (c *dryRunChain) Prune(gs string, dbt string, height int64) error {
+	return errNotForDryRun
+}
+
+func (c *dryRunChain) Backup(file string, extra []string) error {
+	return errNotForDryRun
+}
+
+func (c *dryRunChain) RunTask(task string, params json.RawMessage) error {
+	return errNotForDryRun
+}
+
+func (c *dryRunChain) Term() error {
+	return errNotForDryRun
+}
+
+func (c *dryRunChain) State() (string, int64, error) {
+	return "", 0, errNotForDryRun
+}
+
+func (c *dryRunChain) IsStarted() bool {
+	return false
+}
+
+func (c *dryRunChain) IsStopped() bool {
+	return true
+}
+
+func (c *dryRunChain) Reset(gs string, height int64, blockHash []byte) error {
+	return errNotForDryRun
+}
+
+func (c *dryRunChain) Verify() error {
+	return errNotForDryRun
+}
+
+func (c *dryRunChain) MetricContext() context.Context {
+	return context.Background()
+}
+
+func (c *dryRunChain) Logger() log.Logger {
+	return c.logger
+}
+
+func (c *dryRunChain) WalletFor(dsa string) module.BaseWallet {
+	return nil
+}
+
+// RevisionDryRunResult is what setRevision would make at a block height
+type RevisionDryRunResult struct {
+	Height      int64
+	OldRevision int
+	NewRevision int
+	// Receipt contains the events emitted by setRevision and its revision handlers
+	Receipt txresult.Receipt
+}
+
+func (r *RevisionDryRunResult) ToJSON() (map[string]interface{}, error) {
+	rct, err := r.Receipt.ToJSON(module.JSONVersionLast)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"height":      r.Height,
+		"oldRevision": r.OldRevision,
+		"newRevision": r.NewRevision,
+		"eventLogs":   rct.(map[string]interface{})["eventLogs"],
+	}, nil
+}
+
+// DryRunRevision calls setRevision with a target revision on the state at a given height
+// as if governance sent it in the block of the height.
+// The state changes are shown with logger in the same way as service.ShowResultDiff
+// and nothing is written to dbase
+func DryRunRevision(
+	dbase db.Database, height int64, cid, revision int, logger log.Logger,
+) (*RevisionDryRunResult, error) {
+	result, err := block.GetBlockResultByHeight(dbase, codec.BC, height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to get the result of block(height=%d)", height)
+	}
+	timestamp, err := block.GetBlockTimestampByHeight(dbase, codec.BC, height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to get the timestamp of block(height=%d)", height)
+	}
+
+	// All writes including flushes of snapshots stay in the layer
+	ldb := db.NewLayerDB(dbase)
+	plt, err := NewPlatform("", cid)
+	if err != nil {
+		return nil, err
+	}
+	wss, err := service.NewWorldSnapshot(ldb, plt, result, nil)
+	if err != nil {
+		return nil, err
+	}
+	ws, err := state.WorldStateFromSnapshot(wss)
+	if err != nil {
+		return nil, err
+	}
+	wc := state.NewWorldContext(ws, common.NewBlockInfo(height, timestamp), nil, plt)
+	gov := wc.Governance()
+	wc.SetTransactionInfo(&state.TransactionInfo{
+		Group:     module.TransactionGroupNormal,
+		Hash:      dryRunTxHash,
+		From:      gov,
+		Timestamp: timestamp,
+	})
+	oldRev := wc.Revision().Value()
+	if revision <= oldRev {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"InvalidRevision(current=%d,target=%d)", oldRev, revision)
+	}
+
+	ctx := contract.NewContext(
+		wc, nil, nil, &dryRunChain{dbase: ldb, cid: cid, logger: logger}, logger, nil, eeproxy.ForTransaction)
+	cc := contract.NewCallContext(ctx, big.NewInt(math.MaxInt64), false)
+	score, err := newChainScore(cc, gov, hvhmodule.BigIntZero)
+	if err != nil {
+		return nil, err
+	}
+	if err = score.(*chainScore).Ex_setRevision(common.NewHexInt(int64(revision))); err != nil {
+		return nil, err
+	}
+
+	rct := txresult.NewReceipt(ldb, wc.ToRevision(revision), state.SystemAddress)
+	cc.GetEventLogs(rct)
+	rct.SetResult(module.StatusSuccess, new(big.Int), new(big.Int), nil)
+
+	nwss := ws.GetSnapshot()
+	if err = nwss.Flush(); err != nil {
+		return nil, err
+	}
+	if err = service.ShowWorldSnapshotDiff(ldb, plt, logger, wss, nwss); err != nil {
+		return nil, err
+	}
+	return &RevisionDryRunResult{
+		Height:      height,
+		OldRevision: oldRev,
+		NewRevision: revision,
+		Receipt:     rct,
+	}, nil
+}
@@ -0,0 +1,68 @@
+package havah
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/havah/hvh"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/service"
+	"github.com/icon-project/goloop/service/state"
+)
+
+// putDummyBlock stores the minimum of a block header which DryRunRevision reads
+func putDummyBlock(t *testing.T, dbase db.Database, height, timestamp int64, result []byte) {
+	header := codec.BC.MustMarshalToBytes([]interface{}{
+		2, height, timestamp, nil, nil, nil, nil, nil, nil, nil, result,
+	})
+	hash := crypto.SHA3Sum256(header)
+	bk, err := dbase.GetBucket(db.BytesByHash)
+	assert.NoError(t, err)
+	assert.NoError(t, bk.Set(hash, header))
+	hashByHeight, err := db.NewCodedBucket(dbase, db.BlockHeaderHashByHeight, codec.BC)
+	assert.NoError(t, err)
+	assert.NoError(t, hashByHeight.Set(height, db.Raw(hash)))
+}
+
+func TestDryRunRevision(t *testing.T) {
+	dbase := db.NewMapDB()
+	ess := hvh.NewExtensionSnapshot(dbase, nil)
+	ws := state.NewWorldState(dbase, nil, nil, ess, nil)
+	wss := ws.GetSnapshot()
+	assert.NoError(t, wss.Flush())
+	result := codec.BC.MustMarshalToBytes([]interface{}{wss.StateHash(), nil, nil, wss.ExtensionData()})
+
+	height := int64(100)
+	putDummyBlock(t, dbase, height, 1_000_000, result)
+
+	logger := log.New()
+	buf := new(bytes.Buffer)
+	logger.SetOutput(buf)
+
+	_, err := DryRunRevision(dbase, height+1, 0, hvhmodule.Revision5, logger)
+	assert.Error(t, err)
+	_, err = DryRunRevision(dbase, height, 0, hvhmodule.Revision0, logger)
+	assert.Error(t, err)
+
+	ret, err := DryRunRevision(dbase, height, 0, hvhmodule.Revision5, logger)
+	assert.NoError(t, err)
+	assert.Equal(t, height, ret.Height)
+	assert.Equal(t, hvhmodule.Revision0, ret.OldRevision)
+	assert.Equal(t, hvhmodule.Revision5, ret.NewRevision)
+	jso, err := ret.ToJSON()
+	assert.NoError(t, err)
+	assert.Contains(t, jso, "eventLogs")
+	assert.Contains(t, buf.String(), "world")
+
+	// Nothing is written to the database
+	plt, _ := NewPlatform("", 0)
+	wss, err = service.NewWorldSnapshot(dbase, plt, result, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, wss.GetAccountSnapshot(state.SystemID))
+}
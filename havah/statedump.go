@@ -0,0 +1,152 @@
+package havah
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+
+	"github.com/icon-project/goloop/block"
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/havah/hvh"
+	"github.com/icon-project/goloop/havah/hvh/hvhstate"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service"
+)
+
+// systemAccounts are the accounts whose balances are exported with the extension state
+var systemAccounts = []module.Address{
+	hvhmodule.PublicTreasury,
+	hvhmodule.SustainableFund,
+	hvhmodule.CompanyTreasury,
+	hvhmodule.HooverFund,
+	hvhmodule.EcoSystem,
+	hvhmodule.ServiceTreasury,
+}
+
+// StateDump is the HAVAH extension state and the balances of system accounts at a block height
+type StateDump struct {
+	Height   common.HexInt64           `json:"height"`
+	Balances map[string]*common.HexInt `json:"balances"`
+	State    *hvhstate.StateDump       `json:"state"`
+}
+
+// ExportState reads the state at a given height from the database of a HAVAH chain.
+// As with queries, the state at a height is the one before the transactions in the block are executed
+func ExportState(dbase db.Database, height, maxPlanetID int64) (*StateDump, error) {
+	result, err := block.GetBlockResultByHeight(dbase, codec.BC, height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to get the result of block(height=%d)", height)
+	}
+	plt, err := NewPlatform("", 0)
+	if err != nil {
+		return nil, err
+	}
+	wss, err := service.NewWorldSnapshot(dbase, plt, result, nil)
+	if err != nil {
+		return nil, err
+	}
+	ess, ok := wss.GetExtensionSnapshot().(*hvh.ExtensionSnapshotImpl)
+	if !ok || ess == nil {
+		return nil, errors.NotFoundError.Errorf("NoExtensionState(height=%d)", height)
+	}
+
+	dump, err := ess.ExportState(maxPlanetID)
+	if err != nil {
+		return nil, err
+	}
+	balances := make(map[string]*common.HexInt)
+	for _, address := range systemAccounts {
+		balance := new(common.HexInt)
+		if as := wss.GetAccountSnapshot(address.ID()); as != nil {
+			balance.Set(as.GetBalance())
+		}
+		balances[address.String()] = balance
+	}
+	return &StateDump{
+		Height:   common.HexInt64{Value: height},
+		Balances: balances,
+		State:    dump,
+	}, nil
+}
+
+// NewGenesisWithState fills a genesis template with a state dump.
+// The config of the dump overwrites the havah section of the template and the rest is put into its state field.
+// The balances of system accounts in the dump overwrite those in the template or are appended to it
+func NewGenesisWithState(template []byte, dump *StateDump) ([]byte, error) {
+	if dump == nil || dump.State == nil {
+		return nil, errors.IllegalArgumentError.New("NoStateDump")
+	}
+
+	d := json.NewDecoder(bytes.NewBuffer(template))
+	d.UseNumber()
+	var genesis map[string]interface{}
+	if err := d.Decode(&genesis); err != nil {
+		return nil, errors.Wrap(err, "Failed to decode genesis template")
+	}
+
+	chain, ok := genesis["chain"].(map[string]interface{})
+	if !ok {
+		return nil, errors.IllegalArgumentError.New("NoChainConfigInTemplate")
+	}
+	havah, ok := chain["havah"].(map[string]interface{})
+	if !ok {
+		havah = make(map[string]interface{})
+	}
+	state := *dump.State
+	if state.Config != nil {
+		if err := mergeJSONObject(havah, state.Config); err != nil {
+			return nil, err
+		}
+	}
+	state.Config = nil
+	state.IssueStart = nil
+	state.RewardStatus = nil
+	havah["state"] = &state
+	chain["havah"] = havah
+
+	addresses := make([]string, 0, len(dump.Balances))
+	for address := range dump.Balances {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	accounts, _ := genesis["accounts"].([]interface{})
+	for _, address := range addresses {
+		balance := dump.Balances[address]
+		found := false
+		for _, account := range accounts {
+			if obj, ok := account.(map[string]interface{}); ok && obj["address"] == address {
+				obj["balance"] = balance
+				found = true
+			}
+		}
+		if !found {
+			accounts = append(accounts, map[string]interface{}{
+				"address": address,
+				"balance": balance,
+			})
+		}
+	}
+	genesis["accounts"] = accounts
+
+	return json.MarshalIndent(genesis, "", "  ")
+}
+
+func mergeJSONObject(dst map[string]interface{}, src interface{}) error {
+	bs, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	var obj map[string]interface{}
+	if err = json.Unmarshal(bs, &obj); err != nil {
+		return err
+	}
+	for k, v := range obj {
+		dst[k] = v
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package havah
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/havah/hvh/hvhstate"
+	"github.com/icon-project/goloop/havah/hvhmodule"
+)
+
+func TestNewGenesisWithState(t *testing.T) {
+	template := []byte(`{
+  "accounts": [
+    {"name": "god", "address": "hx0000000000000000000000000000000000000000", "balance": "0x100"},
+    {"name": "treasury", "address": "hx3000000000000000000000000000000000000000", "balance": "0x1"}
+  ],
+  "chain": {
+    "revision": "0x8",
+    "havah": {"termPeriod": "0x10", "issueStart": "0x20"}
+  },
+  "message": "genesis"
+}`)
+	dump := &StateDump{
+		Height: common.HexInt64{Value: 100},
+		Balances: map[string]*common.HexInt{
+			hvhmodule.PublicTreasury.String(): common.NewHexInt(1000),
+			hvhmodule.HooverFund.String():     common.NewHexInt(2000),
+		},
+		State: &hvhstate.StateDump{
+			Config: &hvhstate.StateConfig{
+				TermPeriod: &common.HexInt64{Value: 43200},
+			},
+			IssueStart: &common.HexInt64{Value: 50},
+			Planets: []*hvhstate.PlanetDump{
+				{
+					ID:           common.HexInt64{Value: 1},
+					Owner:        common.MustNewAddressFromString("hx1234"),
+					USDT:         common.NewHexInt(1000),
+					Price:        common.NewHexInt(2000),
+					RewardTotal:  common.NewHexInt(0),
+					RewardRemain: common.NewHexInt(0),
+				},
+			},
+		},
+	}
+
+	_, err := NewGenesisWithState(template, &StateDump{})
+	assert.Error(t, err)
+
+	bs, err := NewGenesisWithState(template, dump)
+	assert.NoError(t, err)
+
+	var genesis struct {
+		Accounts []struct {
+			Address string         `json:"address"`
+			Balance *common.HexInt `json:"balance"`
+		} `json:"accounts"`
+		Chain struct {
+			Havah struct {
+				TermPeriod common.HexInt64     `json:"termPeriod"`
+				IssueStart common.HexInt64     `json:"issueStart"`
+				State      *hvhstate.StateDump `json:"state"`
+			} `json:"havah"`
+		} `json:"chain"`
+		Message string `json:"message"`
+	}
+	err = json.Unmarshal(bs, &genesis)
+	assert.NoError(t, err)
+	assert.Equal(t, "genesis", genesis.Message)
+
+	havah := genesis.Chain.Havah
+	assert.Equal(t, int64(43200), havah.TermPeriod.Value)
+	// issueStart of the template is kept
+	assert.Equal(t, int64(0x20), havah.IssueStart.Value)
+	assert.NotNil(t, havah.State)
+	assert.Nil(t, havah.State.Config)
+	assert.Nil(t, havah.State.IssueStart)
+	assert.Equal(t, 1, len(havah.State.Planets))
+
+	balances := make(map[string]*big.Int)
+	for _, account := range genesis.Accounts {
+		balances[account.Address] = account.Balance.Value()
+	}
+	assert.Equal(t, 3, len(balances))
+	assert.Equal(t, int64(0x100), balances["hx0000000000000000000000000000000000000000"].Int64())
+	assert.Equal(t, int64(1000), balances[hvhmodule.PublicTreasury.String()].Int64())
+	assert.Equal(t, int64(2000), balances[hvhmodule.HooverFund.String()].Int64())
+}
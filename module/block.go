@@ -112,6 +112,12 @@ type BlockManager interface {
 	GetLastBlock() (Block, error)
 	GetBlock(id []byte) (Block, error)
 
+	// GetPendingResult returns the last finalized block with the result and
+	// the next validators of the transactions in the block, which become
+	// the result of the next block. It returns errors.NotFoundError if
+	// the transactions are not executed yet.
+	GetPendingResult() (Block, []byte, ValidatorList, error)
+
 	// WaitForBlock returns a channel that receives the block with the given
 	// height.
 	WaitForBlock(height int64) (<-chan Block, error)
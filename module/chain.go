@@ -38,6 +38,7 @@ type Chain interface {
 	ChildrenLimit() int
 	NephewsLimit() int
 	ValidateTxOnSend() bool
+	TxAddressIndex() bool
 	Genesis() []byte
 	GenesisStorage() GenesisStorage
 	CommitVoteSetDecoder() CommitVoteSetDecoder
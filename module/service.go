@@ -297,6 +297,47 @@ type TransitionManager interface {
 	WaitForTransaction(parent Transition, bi BlockInfo, cb func()) bool
 }
 
+type TxPoolEventType int
+
+const (
+	TxPoolEventAdded TxPoolEventType = iota
+	TxPoolEventDropped
+	TxPoolEventIncluded
+)
+
+func (t TxPoolEventType) String() string {
+	switch t {
+	case TxPoolEventAdded:
+		return "added"
+	case TxPoolEventDropped:
+		return "dropped"
+	case TxPoolEventIncluded:
+		return "included"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(t))
+	}
+}
+
+// TxPoolEvent is a change of the transaction pool.
+type TxPoolEvent struct {
+	Type TxPoolEventType
+	ID   []byte
+	From Address
+
+	// Height is the height of the finalized block including the transaction
+	// for TxPoolEventIncluded.
+	Height int64
+
+	// Err is the reason of the drop for TxPoolEventDropped.
+	Err error
+}
+
+// TxPoolWatcher receives events of the transaction pool.
+// OnTxPoolEvent shouldn't block, and it shouldn't call the service manager.
+type TxPoolWatcher interface {
+	OnTxPoolEvent(e *TxPoolEvent)
+}
+
 type ServiceManager interface {
 	TransitionManager
 
@@ -395,6 +436,18 @@ type ServiceManager interface {
 	// WaitTransactionResult return channel for result.
 	WaitTransactionResult(id []byte) (<-chan interface{}, error)
 
+	// GetPendingTransactions returns transactions in the pool for the group
+	// in the order of the pool. It returns the transactions of the sender
+	// if from isn't nil. It returns all the transactions for a negative limit.
+	GetPendingTransactions(g TransactionGroup, from Address, limit int) []Transaction
+
+	// WatchTxPool registers the watcher for events of the transaction pools.
+	WatchTxPool(watcher TxPoolWatcher)
+
+	// UnwatchTxPool unregisters the watcher. It returns false if
+	// the watcher isn't registered.
+	UnwatchTxPool(watcher TxPoolWatcher) bool
+
 	// ExportResult exports all related entries related with the result
 	// should be exported to the database
 	ExportResult(result []byte, vh []byte, dst db.Database) error
@@ -413,6 +413,7 @@ func (n *Node) JoinChain(
 		ChildrenLimit:    p.ChildrenLimit,
 		NephewsLimit:     p.NephewsLimit,
 		ValidateTxOnSend: p.ValidateTxOnSend,
+		TxAddressIndex:   p.TxAddressIndex,
 	}
 
 	if err := cfg.Save(); err != nil {
@@ -103,6 +103,7 @@ type ChainConfig struct {
 	ChildrenLimit    *int   `json:"childrenLimit,omitempty"`
 	NephewsLimit     *int   `json:"nephewsLimit,omitempty"`
 	ValidateTxOnSend bool   `json:"validateTxOnSend,omitempty"`
+	TxAddressIndex   bool   `json:"txAddressIndex,omitempty"`
 }
 
 type ChainResetParam struct {
@@ -185,6 +186,7 @@ func NewChainConfig(cfg *chain.Config) *ChainConfig {
 		ChildrenLimit:    cfg.ChildrenLimit,
 		NephewsLimit:     cfg.NephewsLimit,
 		ValidateTxOnSend: cfg.ValidateTxOnSend,
+		TxAddressIndex:   cfg.TxAddressIndex,
 	}
 	return v
 }
@@ -243,6 +245,7 @@ func (r *Rest) RegisterChainHandlers(g *echo.Group) {
 	}
 	g.GET(UrlChainRes+"/configure", r.GetChainConfig, r.ChainInjector)
 	g.POST(UrlChainRes+"/configure", r.ConfigureChain, r.ChainInjector)
+	g.GET(UrlChainRes+"/txpool", r.GetChainTxPool, r.ChainInjector)
 	g.POST(UrlChainRes+"/:"+TaskID, r.RunChainTask, r.ChainInjector)
 }
 
@@ -439,6 +442,50 @@ func (r *Rest) GetChainConfig(ctx echo.Context) error {
 	return ctx.JSON(http.StatusOK, NewChainConfig(c.cfg))
 }
 
+const (
+	DefaultTxPoolLimit = 100
+	MaxTxPoolLimit     = 1000
+)
+
+// GetChainTxPool returns pending normal transactions in the transaction pool.
+// They can be filtered by the sender with "from" query parameter.
+func (r *Rest) GetChainTxPool(ctx echo.Context) error {
+	c := ctx.Get("chain").(*Chain)
+	sm := c.ServiceManager()
+	if sm == nil {
+		return ctx.String(http.StatusServiceUnavailable, "NotStarted")
+	}
+	limit := DefaultTxPoolLimit
+	if p := ctx.QueryParam("limit"); p != "" {
+		v, err := strconv.Atoi(p)
+		if err != nil || v <= 0 || v > MaxTxPoolLimit {
+			return ctx.String(http.StatusBadRequest, "InvalidLimit(limit:"+p+")")
+		}
+		limit = v
+	}
+	var from module.Address
+	if p := ctx.QueryParam("from"); p != "" {
+		addr, err := common.NewAddressFromString(p)
+		if err != nil {
+			return ctx.String(http.StatusBadRequest, "InvalidFrom(from:"+p+")")
+		}
+		from = addr
+	}
+
+	txs := sm.GetPendingTransactions(module.TransactionGroupNormal, from, limit)
+	l := make([]interface{}, 0, len(txs))
+	for _, tx := range txs {
+		js, err := tx.ToJSON(module.JSONVersion3)
+		if err != nil {
+			return err
+		}
+		v := js.(map[string]interface{})
+		v["txHash"] = "0x" + hex.EncodeToString(tx.ID())
+		l = append(l, v)
+	}
+	return ctx.JSON(http.StatusOK, l)
+}
+
 func (r *Rest) ConfigureChain(ctx echo.Context) error {
 	c := ctx.Get("chain").(*Chain)
 	p := &ConfigureParam{}
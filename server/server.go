@@ -255,6 +255,8 @@ func (srv *Manager) RegisterAPIHandler(g *echo.Group) {
 	ws.GET("/v3/:channel/block", srv.wssm.RunBlockSession, ChainInjector(srv))
 	ws.GET("/v3/:channel/event", srv.wssm.RunEventSession, ChainInjector(srv))
 	ws.GET("/v3/:channel/btp", srv.wssm.RunBtpSession, ChainInjector(srv))
+	ws.GET("/v3/:channel/txpool", srv.wssm.RunTxPoolSession, ChainInjector(srv))
+	ws.GET("/v3/:channel/mux", srv.wssm.RunMuxSession, ChainInjector(srv))
 }
 
 func (srv *Manager) RegisterMetricsHandler(g *echo.Group) {
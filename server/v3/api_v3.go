@@ -16,6 +16,7 @@ import (
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/common/txlocator"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/server/jsonrpc"
 	"github.com/icon-project/goloop/server/metric"
@@ -42,9 +43,11 @@ func MethodRepository(mtr *metric.JsonrpcMetric) *jsonrpc.MethodRepository {
 	mr.RegisterMethod("icx_getTotalSupply", getTotalSupply)
 	mr.RegisterMethod("icx_getTransactionResult", getTransactionResult)
 	mr.RegisterMethod("icx_getTransactionByHash", getTransactionByHash)
+	mr.RegisterMethod("icx_getTransactionsByAddress", getTransactionsByAddress)
 	mr.RegisterMethod("icx_sendTransaction", sendTransaction)
 	mr.RegisterMethod("icx_sendTransactionAndWait", sendTransactionAndWait)
 	mr.RegisterMethod("icx_waitTransactionResult", waitTransactionResult)
+	mr.RegisterMethod("icx_getEventLogs", getEventLogs)
 
 	mr.RegisterMethod("icx_getDataByHash", getDataByHash)
 	mr.RegisterMethod("icx_getBlockHeaderByHeight", getBlockHeaderByHeight)
@@ -154,10 +157,13 @@ func (c *contextWithBM) Init(ctx *jsonrpc.Context) error {
 }
 
 func (c *contextWithBM) GetBlockByHeight(height jsonrpc.HexInt) (module.Block, error) {
-	if height == "" {
+	switch height {
+	case "", HeightLatest, HeightFinalized:
 		blk, err := c.bm.GetLastBlock()
 		return blk, c.AsRPCError(err)
-	} else {
+	case HeightPending:
+		return nil, jsonrpc.ErrorCodeInvalidParams.Errorf("NoPendingBlock(height=%s)", height)
+	default:
 		h, err := height.Int64()
 		if err != nil {
 			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
@@ -186,6 +192,40 @@ type contextWithSM struct {
 	sm module.ServiceManager
 }
 
+// queryState is the state for queries with the block information of it.
+type queryState struct {
+	module.BlockInfo
+	result     []byte
+	validators module.ValidatorList
+}
+
+// GetStateByHeight returns the state at the height. It returns the state
+// after executing the transactions in the last block for HeightPending.
+func (c *contextWithSM) GetStateByHeight(height jsonrpc.HexInt) (*queryState, error) {
+	if height == HeightPending {
+		blk, result, vl, err := c.bm.GetPendingResult()
+		if errors.NotFoundError.Equals(err) {
+			return nil, jsonrpc.ErrorCodeExecuting.Wrap(err, c.debug)
+		} else if err != nil {
+			return nil, c.AsRPCError(err)
+		}
+		return &queryState{
+			BlockInfo:  common.NewBlockInfo(blk.Height()+1, blk.Timestamp()),
+			result:     result,
+			validators: vl,
+		}, nil
+	}
+	blk, err := c.GetBlockByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	return &queryState{
+		BlockInfo:  common.NewBlockInfo(blk.Height(), blk.Timestamp()),
+		result:     blk.Result(),
+		validators: blk.NextValidators(),
+	}, nil
+}
+
 func (c *contextWithSM) Init(ctx *jsonrpc.Context) error {
 	if err := c.contextWithBM.Init(ctx); err != nil {
 		return err
@@ -305,13 +345,12 @@ func call(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
 		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
 	}
 
-	blk, err := c.GetBlockByHeight(param.Height)
+	qs, err := c.GetStateByHeight(param.Height)
 	if err != nil {
 		return nil, err
 	}
 
-	bi := common.NewBlockInfo(blk.Height(), blk.Timestamp())
-	result, err := c.sm.Call(blk.Result(), blk.NextValidators(), params.RawMessage(), bi)
+	result, err := c.sm.Call(qs.result, qs.validators, params.RawMessage(), qs.BlockInfo)
 	if err != nil {
 		if service.InvalidQueryError.Equals(err) {
 			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
@@ -337,12 +376,12 @@ func getBalance(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, erro
 	}
 
 	var balance common.HexInt
-	blk, err := c.GetBlockByHeight(param.Height)
+	qs, err := c.GetStateByHeight(param.Height)
 	if err != nil {
 		return nil, err
 	}
 
-	b, err := c.sm.GetBalance(blk.Result(), param.Address.Address())
+	b, err := c.sm.GetBalance(qs.result, param.Address.Address())
 	if err != nil {
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
 	}
@@ -361,11 +400,11 @@ func getScoreApi(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, err
 		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
 	}
 
-	b, err := c.GetBlockByHeight(param.Height)
+	qs, err := c.GetStateByHeight(param.Height)
 	if err != nil {
 		return nil, err
 	}
-	info, err := c.sm.GetAPIInfo(b.Result(), param.Address.Address())
+	info, err := c.sm.GetAPIInfo(qs.result, param.Address.Address())
 	if service.NoActiveContractError.Equals(err) {
 		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, c.debug)
 	}
@@ -394,12 +433,12 @@ func getTotalSupply(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{},
 		}
 	}
 
-	b, err := c.GetBlockByHeight(height)
+	qs, err := c.GetStateByHeight(height)
 	if err != nil {
 		return nil, err
 	}
 	var tsValue common.HexInt
-	ts, err := c.sm.GetTotalSupply(b.Result())
+	ts, err := c.sm.GetTotalSupply(qs.result)
 	if err != nil {
 		return nil, c.AsRPCError(err)
 	}
@@ -490,6 +529,240 @@ func getTransactionByHash(ctx *jsonrpc.Context, params *jsonrpc.Params) (interfa
 	return result, nil
 }
 
+const (
+	DefaultTransactionsByAddressLimit = 20
+	MaxTransactionsByAddressLimit     = 100
+)
+
+func getTransactionsByAddress(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithChain
+	if err := c.Init(ctx); err != nil {
+		return nil, err
+	}
+
+	var param TransactionsByAddressParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+	if !c.chain.TxAddressIndex() {
+		return nil, jsonrpc.ErrorCodeServer.New("AddressIndexDisabled")
+	}
+
+	cursor := int64(-1)
+	if len(param.Cursor) > 0 {
+		v, err := param.Cursor.Int64()
+		if err != nil || v <= 0 {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Errorf("InvalidCursor(cursor=%s)", param.Cursor)
+		}
+		cursor = v
+	}
+	limit := int64(DefaultTransactionsByAddressLimit)
+	if len(param.Limit) > 0 {
+		v, err := param.Limit.Int64()
+		if err != nil || v <= 0 || v > MaxTransactionsByAddressLimit {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Errorf("InvalidLimit(limit=%s)", param.Limit)
+		}
+		limit = v
+	}
+
+	ai, err := txlocator.NewAddressIndex(c.chain.Database())
+	if err != nil {
+		return nil, c.AsRPCError(err)
+	}
+	addr := param.Address.Address()
+	atxs, next, total, err := ai.GetTransactions(addr, cursor, int(limit))
+	if err != nil {
+		if errors.IllegalArgumentError.Equals(err) {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+		}
+		return nil, c.AsRPCError(err)
+	}
+	first, err := ai.FirstIndexedHeight()
+	if err != nil {
+		return nil, c.AsRPCError(err)
+	}
+
+	txs := make([]interface{}, len(atxs))
+	for i, atx := range atxs {
+		txs[i] = map[string]interface{}{
+			"txHash":      "0x" + hex.EncodeToString(atx.ID),
+			"blockHeight": "0x" + strconv.FormatInt(atx.BlockHeight, 16),
+			"txIndex":     "0x" + strconv.FormatInt(int64(atx.Index), 16),
+		}
+	}
+	result := map[string]interface{}{
+		"address":      addr,
+		"total":        "0x" + strconv.FormatInt(total, 16),
+		"transactions": txs,
+	}
+	if first >= 0 {
+		result["firstIndexedHeight"] = "0x" + strconv.FormatInt(first, 16)
+	}
+	if next > 0 {
+		result["next"] = "0x" + strconv.FormatInt(next, 16)
+	}
+	return result, nil
+}
+
+const (
+	MaxEventLogsBlockRange = 1000
+	DefaultEventLogsLimit  = 100
+	MaxEventLogsLimit      = 1000
+)
+
+// EventLogs is the events of a receipt matched by the filters.
+// Height, Hash and Index are the same as those of the websocket event
+// notification, so Index is the index of the receipt in the result of the
+// block.
+type EventLogs struct {
+	Hash   common.HexBytes   `json:"hash"`
+	Height common.HexInt64   `json:"height"`
+	Index  common.HexInt32   `json:"index"`
+	TxHash common.HexBytes   `json:"txHash"`
+	Events []common.HexInt32 `json:"events"`
+	Logs   []module.EventLog `json:"logs"`
+}
+
+type EventLogsCursor struct {
+	Height common.HexInt64 `json:"height"`
+	Index  common.HexInt32 `json:"index"`
+}
+
+func getEventLogs(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var c contextWithSM
+	if err := c.Init(ctx); err != nil {
+		return nil, err
+	}
+
+	var param EventLogsParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+	if len(param.Filters) == 0 {
+		return nil, jsonrpc.ErrorCodeInvalidParams.New("NoEventFilters")
+	}
+	if err := param.Filters.Compile(); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+
+	from, err := param.FromHeight.Int64()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+	}
+	if err = c.CheckBaseHeight(from); err != nil {
+		return nil, err
+	}
+	last, err := c.bm.GetLastBlock()
+	if err != nil {
+		return nil, c.AsRPCError(err)
+	}
+	to := last.Height()
+	if len(param.ToHeight) > 0 {
+		if to, err = param.ToHeight.Int64(); err != nil {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
+		}
+		if to > last.Height() {
+			return nil, jsonrpc.ErrorCodeNotFound.Errorf(
+				"NoBlock(toHeight=%d,last=%d)", to, last.Height())
+		}
+	}
+	if from > to {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Errorf("InvalidRange(from=%d,to=%d)", from, to)
+	}
+	var cursor int
+	if v, err := param.Cursor.ParseInt(32); err != nil || v < 0 {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Errorf("InvalidCursor(cursor=%s)", param.Cursor)
+	} else {
+		cursor = int(v)
+	}
+	limit := DefaultEventLogsLimit
+	if len(param.Limit) > 0 {
+		v, err := param.Limit.Int64()
+		if err != nil || v <= 0 || v > MaxEventLogsLimit {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Errorf("InvalidLimit(limit=%s)", param.Limit)
+		}
+		limit = int(v)
+	}
+
+	end := to
+	if end-from >= MaxEventLogsBlockRange {
+		end = from + MaxEventLogsBlockRange - 1
+	}
+	results := make([]*EventLogs, 0)
+	var next *EventLogsCursor
+loop:
+	for h := from; h <= end; h++ {
+		blk, err := c.bm.GetBlockByHeight(h)
+		if err != nil {
+			return nil, c.AsRPCError(err)
+		}
+		filters, contained := param.Filters.FilteredByLogBloom(blk.LogsBloom())
+		if !contained {
+			continue
+		}
+		rl, err := c.sm.ReceiptListFromResult(blk.Result(), module.TransactionGroupNormal)
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+		}
+		var txs module.TransactionList
+		for rit, idx := rl.Iterator(), 0; rit.Has(); _, idx = rit.Next(), idx+1 {
+			if h == from && idx < cursor {
+				continue
+			}
+			if len(results) >= limit {
+				next = &EventLogsCursor{
+					Height: common.HexInt64{Value: h},
+					Index:  common.HexInt32{Value: int32(idx)},
+				}
+				break loop
+			}
+			r, err := rit.Get()
+			if err != nil {
+				return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+			}
+			es, logs, err := filters.MatchEvents(r, true)
+			if err != nil {
+				return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+			}
+			if len(es) == 0 {
+				continue
+			}
+			// receipts in the result of the block are for the transactions
+			// in the previous block
+			if txs == nil {
+				pblk, err := c.bm.GetBlock(blk.PrevID())
+				if err != nil {
+					return nil, c.AsRPCError(err)
+				}
+				txs = pblk.NormalTransactions()
+			}
+			tx, err := txs.Get(idx)
+			if err != nil {
+				return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
+			}
+			results = append(results, &EventLogs{
+				Hash:   blk.ID(),
+				Height: common.HexInt64{Value: h},
+				Index:  common.HexInt32{Value: int32(idx)},
+				TxHash: tx.ID(),
+				Events: es,
+				Logs:   logs,
+			})
+		}
+	}
+	if next == nil && end < to {
+		next = &EventLogsCursor{Height: common.HexInt64{Value: end + 1}}
+	}
+
+	result := map[string]interface{}{
+		"results": results,
+	}
+	if next != nil {
+		result["next"] = next
+	}
+	return result, nil
+}
+
 func sendTransaction(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
 	var c contextWithSM
 	if err := c.Init(ctx); err != nil {
@@ -714,15 +987,15 @@ func getScoreStatus(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{},
 		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, c.debug)
 	}
 
-	b, err := c.GetBlockByHeight(param.Height)
+	qs, err := c.GetStateByHeight(param.Height)
 	if err != nil {
 		return nil, err
 	}
-	s, err := c.sm.GetSCOREStatus(b.Result(), param.Address.Address())
+	s, err := c.sm.GetSCOREStatus(qs.result, param.Address.Address())
 	if err != nil {
 		return nil, c.AsRPCError(err)
 	}
-	jso, err := s.ToJSON(b.Height(), module.JSONVersion3)
+	jso, err := s.ToJSON(qs.Height(), module.JSONVersion3)
 	if err != nil {
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, c.debug)
 	}
@@ -17,8 +17,18 @@ type BlockHeightParam struct {
 	Height jsonrpc.HexInt `json:"height" validate:"required,t_int"`
 }
 
+// Block tags which can be used in place of the height for state queries.
+// HeightLatest and HeightFinalized are the same since blocks are final once
+// they are finalized. HeightPending is the state after executing
+// the transactions in the last block.
+const (
+	HeightLatest    jsonrpc.HexInt = "latest"
+	HeightFinalized jsonrpc.HexInt = "finalized"
+	HeightPending   jsonrpc.HexInt = "pending"
+)
+
 type HeightParam struct {
-	Height jsonrpc.HexInt `json:"height,omitempty" validate:"optional,t_int"`
+	Height jsonrpc.HexInt `json:"height,omitempty" validate:"optional,t_height"`
 }
 
 type BlockHashParam struct {
@@ -30,17 +40,31 @@ type CallParam struct {
 	ToAddress   jsonrpc.Address `json:"to" validate:"required,t_addr_score"`
 	DataType    string          `json:"dataType" validate:"required,call"`
 	Data        interface{}     `json:"data"`
-	Height      jsonrpc.HexInt  `json:"height,omitempty" validate:"optional,t_int"`
+	Height      jsonrpc.HexInt  `json:"height,omitempty" validate:"optional,t_height"`
 }
 
 type AddressParam struct {
 	Address jsonrpc.Address `json:"address" validate:"required,t_addr"`
-	Height  jsonrpc.HexInt  `json:"height,omitempty" validate:"optional,t_int"`
+	Height  jsonrpc.HexInt  `json:"height,omitempty" validate:"optional,t_height"`
 }
 
 type ScoreAddressParam struct {
 	Address jsonrpc.Address `json:"address" validate:"required,t_addr_score"`
-	Height  jsonrpc.HexInt  `json:"height,omitempty" validate:"optional,t_int"`
+	Height  jsonrpc.HexInt  `json:"height,omitempty" validate:"optional,t_height"`
+}
+
+type TransactionsByAddressParam struct {
+	Address jsonrpc.Address `json:"address" validate:"required,t_addr"`
+	Cursor  jsonrpc.HexInt  `json:"cursor,omitempty" validate:"optional,t_int"`
+	Limit   jsonrpc.HexInt  `json:"limit,omitempty" validate:"optional,t_int"`
+}
+
+type EventLogsParam struct {
+	FromHeight jsonrpc.HexInt `json:"fromHeight" validate:"required,t_int"`
+	ToHeight   jsonrpc.HexInt `json:"toHeight,omitempty" validate:"optional,t_int"`
+	Filters    EventFilters   `json:"eventFilters" validate:"required"`
+	Cursor     jsonrpc.HexInt `json:"cursor,omitempty" validate:"optional,t_int"`
+	Limit      jsonrpc.HexInt `json:"limit,omitempty" validate:"optional,t_int"`
 }
 
 type TransactionHashParam struct {
@@ -12,6 +12,7 @@ import (
 
 var (
 	hexString          = regexp.MustCompile("^0x[0-9a-f]+$")
+	hexHeight          = regexp.MustCompile("^0x(0|[1-9a-f][0-9a-f]*)$")
 	deployContentTypes = []string{"application/zip", "application/java"}
 )
 
@@ -21,6 +22,7 @@ func RegisterValidationRule(v *jsonrpc.Validator) {
 	v.RegisterValidation("deploy", isDeploy)
 	v.RegisterValidation("message", isMessage)
 	v.RegisterValidation("deposit", isDeposit)
+	v.RegisterValidation("t_height", isHeight)
 
 	// validate : CallParam.Data, TransactionParam.Data
 	v.RegisterStructValidation(DataParamValidation, CallParam{}, TransactionParam{})
@@ -43,6 +45,16 @@ func isDeposit(fl validator.FieldLevel) bool {
 	return fl.Field().String() == contract.DataTypeDeposit
 }
 
+// isHeight accepts a block tag as well as a height
+func isHeight(fl validator.FieldLevel) bool {
+	switch h := jsonrpc.HexInt(fl.Field().String()); h {
+	case HeightLatest, HeightFinalized, HeightPending:
+		return true
+	default:
+		return hexHeight.MatchString(string(h))
+	}
+}
+
 func DataParamValidation(sl validator.StructLevel) {
 	switch sl.Current().Interface().(type) {
 	case CallParam:
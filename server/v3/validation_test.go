@@ -107,3 +107,27 @@ func TestTransactionParamValidator(t *testing.T) {
 		assert.Fail(t, "validate fail", err.Error())
 	}
 }
+
+func TestHeightValidator(t *testing.T) {
+	validator := jsonrpc.NewValidator()
+	RegisterValidationRule(validator)
+
+	for _, h := range []jsonrpc.HexInt{
+		"", "0x0", "0x1a", HeightLatest, HeightFinalized, HeightPending,
+	} {
+		param := AddressParam{
+			Address: "hx4873b94352c8c1f3b2f09aaeccea31ce9e90bd31",
+			Height:  h,
+		}
+		assert.NoError(t, validator.Validate(&param), "height=%s", h)
+	}
+	for _, h := range []jsonrpc.HexInt{
+		"0x01", "12", "-0x1", "Latest", "earliest",
+	} {
+		param := AddressParam{
+			Address: "hx4873b94352c8c1f3b2f09aaeccea31ce9e90bd31",
+			Height:  h,
+		}
+		assert.Error(t, validator.Validate(&param), "height=%s", h)
+	}
+}
@@ -32,6 +32,18 @@ type wsSession struct {
 	lock  sync.Mutex
 	c     WebSocketConn
 	chain module.Chain
+
+	// subs is the number of subscriptions of the multiplexed session.
+	// It's guarded by the lock of wsSessionManager.
+	subs int
+}
+
+// wsStream is where a request of a stream writes its response and
+// notifications.
+type wsStream interface {
+	response(code int, msg string) error
+	WriteJSON(v interface{}) error
+	RunLoop(ech chan<- error)
 }
 
 type wsSessionManager struct {
@@ -66,7 +78,7 @@ func (wm *wsSessionManager) NewSession(c WebSocketConn, chain module.Chain) *wsS
 	wm.Lock()
 	defer wm.Unlock()
 
-	if len(wm.sessions) >= wm.maxSession {
+	if wm.slotsInLock() >= wm.maxSession {
 		return nil
 	}
 	wss := &wsSession{c: c, chain: chain}
@@ -74,6 +86,46 @@ func (wm *wsSessionManager) NewSession(c WebSocketConn, chain module.Chain) *wsS
 	return wss
 }
 
+// slots returns the number of session slots which the session takes.
+// A multiplexed session takes a slot for each subscription, and one slot
+// while it has no subscriptions.
+func (wss *wsSession) slots() int {
+	if wss.subs > 1 {
+		return wss.subs
+	}
+	return 1
+}
+
+func (wm *wsSessionManager) slotsInLock() int {
+	slots := 0
+	for _, wss := range wm.sessions {
+		slots += wss.slots()
+	}
+	return slots
+}
+
+// AddSubscription takes a session slot for a new subscription of the
+// multiplexed session. It returns false if there are no slots left.
+func (wm *wsSessionManager) AddSubscription(wss *wsSession) bool {
+	wm.Lock()
+	defer wm.Unlock()
+
+	if wss.subs > 0 && wm.slotsInLock() >= wm.maxSession {
+		return false
+	}
+	wss.subs++
+	return true
+}
+
+func (wm *wsSessionManager) RemoveSubscription(wss *wsSession) {
+	wm.Lock()
+	defer wm.Unlock()
+
+	if wss.subs > 0 {
+		wss.subs--
+	}
+}
+
 func (wm *wsSessionManager) stopSessionAt(i int) {
 	wss := wm.sessions[i]
 	wss.Close()
@@ -309,6 +309,9 @@ func (bm *testBlockManager) WaitForBlock(h int64) (<-chan module.Block, error) {
 type testServiceManager struct {
 	module.ServiceManager
 	receipts blockReceipts
+
+	lock     sync.Mutex
+	watchers []module.TxPoolWatcher
 }
 
 func (sm *testServiceManager) ReceiptListFromResult(result []byte, group module.TransactionGroup) (module.ReceiptList, error) {
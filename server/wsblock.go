@@ -33,30 +33,36 @@ func (wm *wsSessionManager) RunBlockSession(ctx echo.Context) error {
 	}
 	defer wm.StopSession(wss)
 
+	wm.runBlockStream(wss, wss.chain, &br)
+	return nil
+}
+
+func (wm *wsSessionManager) runBlockStream(s wsStream, chain module.Chain, br *BlockRequest) {
 	if err := br.Compile(); err != nil {
-		_ = wss.response(int(jsonrpc.ErrorCodeInvalidParams), err.Error())
-		return nil
+		_ = s.response(int(jsonrpc.ErrorCodeInvalidParams), err.Error())
+		return
 	}
 
-	bm := wss.chain.BlockManager()
-	sm := wss.chain.ServiceManager()
+	bm := chain.BlockManager()
+	sm := chain.ServiceManager()
 	if bm == nil || sm == nil {
-		_ = wss.response(int(jsonrpc.ErrorCodeServer), "Stopped")
-		return nil
+		_ = s.response(int(jsonrpc.ErrorCodeServer), "Stopped")
+		return
 	}
 
 	h := br.Height.Value
-	if gh := wss.chain.GenesisStorage().Height(); gh > h {
-		_ = wss.response(int(jsonrpc.ErrorCodeInvalidParams),
+	if gh := chain.GenesisStorage().Height(); gh > h {
+		_ = s.response(int(jsonrpc.ErrorCodeInvalidParams),
 			fmt.Sprintf("given height(%d) is lower than genesis height(%d)", h, gh))
-		return nil
+		return
 	}
 
-	_ = wss.response(0, "")
+	_ = s.response(0, "")
 
 	ech := make(chan error, 1)
-	wss.RunLoop(ech)
+	s.RunLoop(ech)
 
+	var err error
 	var bch <-chan module.Block
 	indexes := make([][]common.HexInt32, len(br.EventFilters))
 	events := make([][][]common.HexInt32, len(br.EventFilters))
@@ -103,7 +109,7 @@ loop:
 			}
 			lb := blk.LogsBloom()
 			for i, f := range br.EventFilters {
-				if lb.Contain(f.lb) {
+				if lb.Contain(f.LogsBloom()) {
 					if rl == nil {
 						rl, err = sm.ReceiptListFromResult(blk.Result(), module.TransactionGroupNormal)
 						if err != nil {
@@ -134,7 +140,7 @@ loop:
 					}
 				}
 			}
-			if err = wss.WriteJSON(&br.bn); err != nil {
+			if err = s.WriteJSON(&br.bn); err != nil {
 				wm.logger.Infof("fail to write json BlockNotification err:%+v\n", err)
 				break loop
 			}
@@ -142,7 +148,6 @@ loop:
 		h++
 	}
 	wm.logger.Warnf("%+v\n", err)
-	return nil
 }
 
 func (r *BlockRequest) Compile() error {
@@ -32,25 +32,30 @@ func (wm *wsSessionManager) RunBtpSession(ctx echo.Context) error {
 	}
 	defer wm.StopSession(wss)
 
-	bm := wss.chain.BlockManager()
-	sm := wss.chain.ServiceManager()
-	cs := wss.chain.Consensus()
+	wm.runBtpStream(wss, wss.chain, &br)
+	return nil
+}
+
+func (wm *wsSessionManager) runBtpStream(s wsStream, chain module.Chain, br *BTPRequest) {
+	bm := chain.BlockManager()
+	sm := chain.ServiceManager()
+	cs := chain.Consensus()
 	if bm == nil || sm == nil || cs == nil {
-		_ = wss.response(int(jsonrpc.ErrorCodeServer), "Stopped")
-		return nil
+		_ = s.response(int(jsonrpc.ErrorCodeServer), "Stopped")
+		return
 	}
 
 	h := br.Height.Value
-	if gh := wss.chain.GenesisStorage().Height(); gh > h {
-		_ = wss.response(int(jsonrpc.ErrorCodeInvalidParams),
+	if gh := chain.GenesisStorage().Height(); gh > h {
+		_ = s.response(int(jsonrpc.ErrorCodeInvalidParams),
 			fmt.Sprintf("given height(%d) is lower than genesis height(%d)", h, gh))
-		return nil
+		return
 	}
 
-	_ = wss.response(0, "")
+	_ = s.response(0, "")
 
 	ech := make(chan error, 1)
-	wss.RunLoop(ech)
+	s.RunLoop(ech)
 
 	var bch <-chan module.Block
 
@@ -58,7 +63,7 @@ func (wm *wsSessionManager) RunBtpSession(ctx echo.Context) error {
 	nw, err := sm.BTPNetworkFromResult(block.Result(), br.NetworkId.Value)
 	if err != nil {
 		wm.logger.Infof("not found nid=%d height=%d, err:%+v\n", br.NetworkId.Value, h, err)
-		return nil
+		return
 	}
 
 	var pn ProgressNotification;
@@ -80,7 +85,7 @@ loop:
 				nw, err := sm.BTPNetworkFromResult(blk.Result(), br.NetworkId.Value)
 				if !nw.Open() {
 					wm.logger.Infof("network is closed (height=%d, err:%+v)\n", h, err)
-					_ = wss.response(int(jsonrpc.ErrorCodeInvalidParams),
+					_ = s.response(int(jsonrpc.ErrorCodeInvalidParams),
 						fmt.Sprintf("network is closed ( height(%d) , networkId(%d)", h, br.NetworkId))
 					break loop
 				}
@@ -99,7 +104,7 @@ loop:
 						br.bn.Proof = base64.StdEncoding.EncodeToString(proof)
 					}
 
-					if err = wss.WriteJSON(&br.bn); err != nil {
+					if err = s.WriteJSON(&br.bn); err != nil {
 						wm.logger.Infof("fail to write json BtpNotification err:%+v\n", err)
 						break loop
 					}
@@ -111,7 +116,7 @@ loop:
 				last := pn.Progress.Value
 				if last == 0 || (h-last) >= pi || msgSent > 0 {
 					pn.Progress.Value = h
-					if err := wss.WriteJSON(&pn); err != nil {
+					if err := s.WriteJSON(&pn); err != nil {
 						wm.logger.Infof("fail to write json ProgressNotification(height=%d)", h)
 						break loop
 					}
@@ -121,5 +126,4 @@ loop:
 		h++
 	}
 	wm.logger.Warnf("%+v\n", err)
-	return nil
 }
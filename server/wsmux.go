@@ -0,0 +1,253 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/icon-project/goloop/server/jsonrpc"
+)
+
+const (
+	MuxMethodSubscribe   = "subscribe"
+	MuxMethodUnsubscribe = "unsubscribe"
+
+	MuxTypeBlock  = "block"
+	MuxTypeEvent  = "event"
+	MuxTypeBTP    = "btp"
+	MuxTypeTxPool = "txpool"
+)
+
+// MuxRequest is a request of the multiplexed session.
+// Params of subscribe is the request of the stream for Type.
+type MuxRequest struct {
+	Method string          `json:"method"`
+	ID     string          `json:"id"`
+	Type   string          `json:"type,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+type MuxResponse struct {
+	ID string `json:"id"`
+	WSResponse
+}
+
+// MuxNotification carries a notification of the subscription with the ID.
+type MuxNotification struct {
+	ID           string      `json:"id"`
+	Notification interface{} `json:"notification"`
+}
+
+// wsSubscription is a stream of the multiplexed session, which writes its
+// response and notifications with its ID through the session.
+type wsSubscription struct {
+	lock    sync.Mutex
+	id      string
+	wss     *wsSession
+	ech     chan<- error
+	stopped bool
+	done    chan struct{}
+}
+
+func (s *wsSubscription) response(code int, msg string) error {
+	return s.wss.WriteJSON(&MuxResponse{
+		ID:         s.id,
+		WSResponse: WSResponse{Code: code, Message: msg},
+	})
+}
+
+func (s *wsSubscription) WriteJSON(v interface{}) error {
+	return s.wss.WriteJSON(&MuxNotification{ID: s.id, Notification: v})
+}
+
+func (s *wsSubscription) RunLoop(ech chan<- error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.stopped {
+		ech <- errors.New("Unsubscribed")
+	} else {
+		s.ech = ech
+	}
+}
+
+func (s *wsSubscription) Stop() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.stopped {
+		s.stopped = true
+		if s.ech != nil {
+			s.ech <- errors.New("Unsubscribed")
+		}
+	}
+}
+
+type wsMuxSession struct {
+	wm   *wsSessionManager
+	wss  *wsSession
+	lock sync.Mutex
+	subs map[string]*wsSubscription
+	wg   sync.WaitGroup
+}
+
+func (ms *wsMuxSession) response(id string, code int, msg string) error {
+	return ms.wss.WriteJSON(&MuxResponse{
+		ID:         id,
+		WSResponse: WSResponse{Code: code, Message: msg},
+	})
+}
+
+func (ms *wsMuxSession) subscribe(req *MuxRequest) {
+	var run func(s *wsSubscription)
+	var err error
+	chain := ms.wss.chain
+	switch req.Type {
+	case MuxTypeBlock:
+		br := new(BlockRequest)
+		err = decodeStrict(req.Params, br)
+		run = func(s *wsSubscription) { ms.wm.runBlockStream(s, chain, br) }
+	case MuxTypeEvent:
+		er := new(EventRequest)
+		err = decodeStrict(req.Params, er)
+		run = func(s *wsSubscription) { ms.wm.runEventStream(s, chain, er) }
+	case MuxTypeBTP:
+		br := new(BTPRequest)
+		err = decodeStrict(req.Params, br)
+		run = func(s *wsSubscription) { ms.wm.runBtpStream(s, chain, br) }
+	case MuxTypeTxPool:
+		tr := new(TxPoolRequest)
+		err = decodeStrict(req.Params, tr)
+		run = func(s *wsSubscription) { ms.wm.runTxPoolStream(s, chain, tr) }
+	default:
+		_ = ms.response(req.ID, int(jsonrpc.ErrorCodeInvalidParams), "unknown type")
+		return
+	}
+	if err != nil {
+		_ = ms.response(req.ID, int(jsonrpc.ErrorCodeJsonParse), "bad subscription request")
+		return
+	}
+
+	ms.lock.Lock()
+	defer ms.lock.Unlock()
+	if _, ok := ms.subs[req.ID]; ok {
+		_ = ms.response(req.ID, int(jsonrpc.ErrorCodeInvalidParams), "duplicate id")
+		return
+	}
+	if !ms.wm.AddSubscription(ms.wss) {
+		_ = ms.response(req.ID, int(jsonrpc.ErrorLackOfResource), "too many monitor")
+		return
+	}
+	s := &wsSubscription{id: req.ID, wss: ms.wss, done: make(chan struct{})}
+	ms.subs[req.ID] = s
+	ms.wg.Add(1)
+	go func() {
+		defer ms.wg.Done()
+		defer close(s.done)
+		run(s)
+		ms.lock.Lock()
+		if ms.subs[s.id] == s {
+			delete(ms.subs, s.id)
+		}
+		ms.lock.Unlock()
+		ms.wm.RemoveSubscription(ms.wss)
+	}()
+}
+
+func (ms *wsMuxSession) unsubscribe(req *MuxRequest) {
+	ms.lock.Lock()
+	s, ok := ms.subs[req.ID]
+	if ok {
+		delete(ms.subs, req.ID)
+	}
+	ms.lock.Unlock()
+
+	if !ok {
+		_ = ms.response(req.ID, int(jsonrpc.ErrorCodeInvalidParams), "unknown id")
+		return
+	}
+	// no more notifications of the subscription after the response
+	s.Stop()
+	<-s.done
+	_ = ms.response(req.ID, 0, "")
+}
+
+func (ms *wsMuxSession) stopAll() {
+	ms.lock.Lock()
+	subs := ms.subs
+	ms.subs = make(map[string]*wsSubscription)
+	ms.lock.Unlock()
+
+	for _, s := range subs {
+		s.Stop()
+	}
+	ms.wg.Wait()
+}
+
+func decodeStrict(bs []byte, v interface{}) error {
+	jd := json.NewDecoder(bytes.NewBuffer(bs))
+	jd.DisallowUnknownFields()
+	return jd.Decode(v)
+}
+
+// RunMuxSession handles the multiplexed session, where the client can
+// subscribe and unsubscribe block, event, BTP and txpool streams by the ID.
+// Each subscription takes a session slot as a session of the stream does.
+func (wm *wsSessionManager) RunMuxSession(ctx echo.Context) error {
+	chain, err := wm.chain(ctx)
+	if err != nil {
+		return err
+	}
+
+	c, err := wm.upgrader.Upgrade(ctx)
+	if err != nil {
+		return err
+	}
+
+	wss := wm.NewSession(c, chain)
+	if wss == nil {
+		wsResponse := WSResponse{
+			Code:    int(jsonrpc.ErrorLackOfResource),
+			Message: "too many monitor",
+		}
+		c.WriteJSON(&wsResponse)
+		c.Close()
+		return errors.New("too many monitor")
+	}
+	defer wm.StopSession(wss)
+
+	ms := &wsMuxSession{
+		wm:   wm,
+		wss:  wss,
+		subs: make(map[string]*wsSubscription),
+	}
+	defer ms.stopAll()
+
+	for {
+		_, msgBS, err := c.ReadMessage()
+		if err != nil {
+			wm.logger.Infof("mux session closed err:%+v\n", err)
+			return nil
+		}
+		var req MuxRequest
+		if err := decodeStrict(msgBS, &req); err != nil {
+			_ = ms.response("", int(jsonrpc.ErrorCodeJsonParse), "bad mux request")
+			continue
+		}
+		if len(req.ID) == 0 {
+			_ = ms.response(req.ID, int(jsonrpc.ErrorCodeInvalidParams), "empty id")
+			continue
+		}
+		switch req.Method {
+		case MuxMethodSubscribe:
+			ms.subscribe(&req)
+		case MuxMethodUnsubscribe:
+			ms.unsubscribe(&req)
+		default:
+			_ = ms.response(req.ID, int(jsonrpc.ErrorCodeMethodNotFound), "unknown method")
+		}
+	}
+}
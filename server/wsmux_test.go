@@ -0,0 +1,154 @@
+/*
+ * Copyright 2023 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/jsonrpc"
+)
+
+type testMuxMessage struct {
+	ID           string          `json:"id"`
+	Code         *int            `json:"code"`
+	Notification json.RawMessage `json:"notification"`
+}
+
+func TestWSSessionManager_RunMuxSession(t *testing.T) {
+	logger := log.New()
+	logger.SetOutput(io.Discard)
+
+	connCh := make(chan *testWebSocketConn, 1)
+	upgrader := newTestWebsocketUpgrader(func(ctx echo.Context, conn *testWebSocketConn) {
+		connCh <- conn
+	})
+	wm := newWSSessionManagerWithUpgrader(logger, 3, upgrader)
+
+	s1 := make(chan string)
+	blkReceipts := blockReceipts{
+		"empty": testReceiptList{},
+		"1": testReceiptList{
+			newTestReceipt([]*testEventLog{
+				newTestEventLog("cx01", "EventLog()", nil, nil),
+			}),
+		},
+	}
+	chain := newTestChain(0,
+		func(h int64) (getBlockFunc, error) {
+			if h == 1 {
+				return func() module.Block {
+					return &testBlock{
+						height: h,
+						result: "1",
+						lb:     blkReceipts["1"].LogsBloom(),
+					}
+				}, nil
+			}
+			return func() module.Block {
+				_, _ = <-s1
+				return &testBlock{
+					height: h,
+					result: "empty",
+				}
+			}, nil
+		},
+		blkReceipts,
+	)
+	done := make(chan struct{})
+	go func() {
+		_ = wm.RunMuxSession(newTestContext(chain))
+		close(done)
+	}()
+	conn := <-connCh
+
+	request := func(req map[string]interface{}) {
+		assert.NoError(t, conn.clientWriteJSON(req))
+	}
+	read := func() *testMuxMessage {
+		bs, err := conn.clientRead()
+		assert.NoError(t, err)
+		msg := new(testMuxMessage)
+		assert.NoError(t, json.Unmarshal(bs, msg))
+		return msg
+	}
+	subscribe := func(id, typ string, params map[string]interface{}) {
+		request(map[string]interface{}{
+			"method": "subscribe",
+			"id":     id,
+			"type":   typ,
+			"params": params,
+		})
+	}
+	assertResponse := func(id string, code int) {
+		msg := read()
+		assert.Equal(t, id, msg.ID)
+		if assert.NotNil(t, msg.Code) {
+			assert.Equal(t, code, *msg.Code)
+		}
+	}
+
+	// events in the block 1 are notified right after the response
+	subscribe("a", MuxTypeEvent, map[string]interface{}{
+		"height": "0x1",
+		"event":  "EventLog()",
+	})
+	assertResponse("a", 0)
+	msg := read()
+	assert.Equal(t, "a", msg.ID)
+	assert.Nil(t, msg.Code)
+	var en EventNotification
+	assert.NoError(t, json.Unmarshal(msg.Notification, &en))
+	assert.Equal(t, int64(1), en.Height.Value)
+
+	subscribe("b", MuxTypeBlock, map[string]interface{}{"height": "0x2"})
+	assertResponse("b", 0)
+	subscribe("c", MuxTypeBlock, map[string]interface{}{"height": "0x2"})
+	assertResponse("c", 0)
+
+	// subscriptions share the session slots
+	subscribe("d", MuxTypeBlock, map[string]interface{}{"height": "0x2"})
+	assertResponse("d", int(jsonrpc.ErrorLackOfResource))
+
+	subscribe("b", MuxTypeBlock, map[string]interface{}{"height": "0x2"})
+	assertResponse("b", int(jsonrpc.ErrorCodeInvalidParams))
+	subscribe("e", "unknown", nil)
+	assertResponse("e", int(jsonrpc.ErrorCodeInvalidParams))
+	subscribe("e", MuxTypeBlock, map[string]interface{}{"unknownField": "0x1"})
+	assertResponse("e", int(jsonrpc.ErrorCodeJsonParse))
+
+	// unsubscribing releases the slot
+	request(map[string]interface{}{"method": "unsubscribe", "id": "b"})
+	assertResponse("b", 0)
+	request(map[string]interface{}{"method": "unsubscribe", "id": "b"})
+	assertResponse("b", int(jsonrpc.ErrorCodeInvalidParams))
+	subscribe("d", MuxTypeBlock, map[string]interface{}{"height": "0x2"})
+	assertResponse("d", 0)
+
+	// closing the connection stops all subscriptions
+	conn.Close()
+	<-done
+	close(s1)
+
+	wm.StopAllSessions()
+}
@@ -0,0 +1,115 @@
+package server
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/jsonrpc"
+)
+
+const txPoolEventBufferSize = 1024
+
+type TxPoolRequest struct {
+	From *common.Address `json:"from,omitempty"`
+}
+
+type TxPoolNotification struct {
+	Type   string           `json:"type"`
+	Hash   common.HexBytes  `json:"txHash"`
+	From   *common.Address  `json:"from,omitempty"`
+	Height *common.HexInt64 `json:"height,omitempty"`
+	Reason string           `json:"reason,omitempty"`
+}
+
+// txPoolWatcher queues events of the transaction pool for the stream.
+// It never blocks the pool, so the stream is closed if the client can't
+// consume events fast enough.
+type txPoolWatcher struct {
+	from     module.Address
+	ch       chan *module.TxPoolEvent
+	once     sync.Once
+	overflow chan struct{}
+}
+
+func (w *txPoolWatcher) OnTxPoolEvent(e *module.TxPoolEvent) {
+	if w.from != nil && !w.from.Equal(e.From) {
+		return
+	}
+	select {
+	case w.ch <- e:
+	default:
+		w.once.Do(func() {
+			close(w.overflow)
+		})
+	}
+}
+
+func (wm *wsSessionManager) RunTxPoolSession(ctx echo.Context) error {
+	var tr TxPoolRequest
+	wss, err := wm.initSession(ctx, &tr)
+	if err != nil {
+		return err
+	}
+	defer wm.StopSession(wss)
+
+	wm.runTxPoolStream(wss, wss.chain, &tr)
+	return nil
+}
+
+func (wm *wsSessionManager) runTxPoolStream(s wsStream, chain module.Chain, tr *TxPoolRequest) {
+	sm := chain.ServiceManager()
+	if sm == nil {
+		_ = s.response(int(jsonrpc.ErrorCodeServer), "Stopped")
+		return
+	}
+
+	w := &txPoolWatcher{
+		ch:       make(chan *module.TxPoolEvent, txPoolEventBufferSize),
+		overflow: make(chan struct{}),
+	}
+	if tr.From != nil {
+		w.from = tr.From
+	}
+	sm.WatchTxPool(w)
+	defer sm.UnwatchTxPool(w)
+
+	_ = s.response(0, "")
+
+	ech := make(chan error, 1)
+	s.RunLoop(ech)
+
+	var err error
+loop:
+	for {
+		select {
+		case err = <-ech:
+			break loop
+		case <-w.overflow:
+			err = errors.New("TooManyTxPoolEvents")
+			break loop
+		case e := <-w.ch:
+			tn := TxPoolNotification{
+				Type: e.Type.String(),
+				Hash: e.ID,
+				From: common.AddressToPtr(e.From),
+			}
+			switch e.Type {
+			case module.TxPoolEventIncluded:
+				tn.Height = &common.HexInt64{Value: e.Height}
+			case module.TxPoolEventDropped:
+				if e.Err != nil {
+					tn.Reason = e.Err.Error()
+				}
+			}
+			if err = s.WriteJSON(&tn); err != nil {
+				wm.logger.Infof("fail to write json TxPoolNotification err:%+v\n", err)
+				break loop
+			}
+		}
+	}
+	wm.logger.Warnf("%+v\n", err)
+}
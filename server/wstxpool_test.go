@@ -0,0 +1,146 @@
+/*
+ * Copyright 2023 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
+)
+
+func (sm *testServiceManager) WatchTxPool(watcher module.TxPoolWatcher) {
+	sm.lock.Lock()
+	defer sm.lock.Unlock()
+
+	sm.watchers = append(sm.watchers, watcher)
+}
+
+func (sm *testServiceManager) UnwatchTxPool(watcher module.TxPoolWatcher) bool {
+	sm.lock.Lock()
+	defer sm.lock.Unlock()
+
+	for i, w := range sm.watchers {
+		if w == watcher {
+			sm.watchers = append(sm.watchers[:i], sm.watchers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (sm *testServiceManager) notifyTxPoolEvent(e *module.TxPoolEvent) {
+	sm.lock.Lock()
+	defer sm.lock.Unlock()
+
+	for _, w := range sm.watchers {
+		w.OnTxPoolEvent(e)
+	}
+}
+
+func (sm *testServiceManager) txPoolWatchers() int {
+	sm.lock.Lock()
+	defer sm.lock.Unlock()
+
+	return len(sm.watchers)
+}
+
+func TestWSSessionManager_RunTxPoolSession(t *testing.T) {
+	logger := log.New()
+	logger.SetOutput(io.Discard)
+
+	connCh := make(chan *testWebSocketConn, 1)
+	upgrader := newTestWebsocketUpgrader(func(ctx echo.Context, conn *testWebSocketConn) {
+		connCh <- conn
+	})
+	wm := newWSSessionManagerWithUpgrader(logger, 1, upgrader)
+	chain := newTestChain(0, nil, blockReceipts{})
+	sm := chain.ServiceManager().(*testServiceManager)
+
+	done := make(chan struct{})
+	go func() {
+		_ = wm.RunTxPoolSession(newTestContext(chain))
+		close(done)
+	}()
+	conn := <-connCh
+
+	addr1 := common.MustNewAddressFromString("hx1111111111111111111111111111111111111111")
+	addr2 := common.MustNewAddressFromString("hx2222222222222222222222222222222222222222")
+	assert.NoError(t, conn.clientWriteJSON(map[string]interface{}{
+		"from": addr1.String(),
+	}))
+	bs, err := conn.clientRead()
+	assert.NoError(t, err)
+	var res WSResponse
+	assert.NoError(t, json.Unmarshal(bs, &res))
+	assert.Equal(t, 0, res.Code)
+	assert.Equal(t, 1, sm.txPoolWatchers())
+
+	sm.notifyTxPoolEvent(&module.TxPoolEvent{
+		Type: module.TxPoolEventAdded, ID: []byte("tx1"), From: addr1,
+	})
+	sm.notifyTxPoolEvent(&module.TxPoolEvent{
+		Type: module.TxPoolEventAdded, ID: []byte("tx2"), From: addr2,
+	})
+	sm.notifyTxPoolEvent(&module.TxPoolEvent{
+		Type: module.TxPoolEventDropped, ID: []byte("tx1"), From: addr1,
+		Err: errors.InvalidStateError.New("AlreadyProcessed"),
+	})
+	sm.notifyTxPoolEvent(&module.TxPoolEvent{
+		Type: module.TxPoolEventIncluded, ID: []byte("tx3"), From: addr1, Height: 5,
+	})
+
+	read := func() *TxPoolNotification {
+		bs, err := conn.clientRead()
+		assert.NoError(t, err)
+		tn := new(TxPoolNotification)
+		assert.NoError(t, json.Unmarshal(bs, tn))
+		return tn
+	}
+
+	// events from other addresses are filtered out
+	tn := read()
+	assert.Equal(t, "added", tn.Type)
+	assert.Equal(t, []byte("tx1"), tn.Hash.Bytes())
+	assert.True(t, addr1.Equal(tn.From))
+	assert.Nil(t, tn.Height)
+
+	tn = read()
+	assert.Equal(t, "dropped", tn.Type)
+	assert.Equal(t, []byte("tx1"), tn.Hash.Bytes())
+	assert.Contains(t, tn.Reason, "AlreadyProcessed")
+
+	tn = read()
+	assert.Equal(t, "included", tn.Type)
+	assert.Equal(t, []byte("tx3"), tn.Hash.Bytes())
+	if assert.NotNil(t, tn.Height) {
+		assert.Equal(t, int64(5), tn.Height.Value)
+	}
+
+	conn.Close()
+	<-done
+	assert.Equal(t, 0, sm.txPoolWatchers())
+
+	wm.StopAllSessions()
+}
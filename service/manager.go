@@ -305,14 +305,14 @@ func (m *manager) Finalize(t module.Transition, opt int) error {
 			if err := tst.finalizeNormalTransaction(); err != nil {
 				return err
 			}
-			m.tm.RemoveTxs(module.TransactionGroupNormal, tst.normalTransactions)
+			m.tm.RemoveTxs(module.TransactionGroupNormal, tst.normalTransactions, tst.bi.Height())
 			m.tm.RemoveOldTxByBlockTS(module.TransactionGroupNormal, tst.bi.Timestamp())
 		}
 		if opt&module.FinalizePatchTransaction == module.FinalizePatchTransaction {
 			if err := tst.finalizePatchTransaction(); err != nil {
 				return err
 			}
-			m.tm.RemoveTxs(module.TransactionGroupPatch, tst.patchTransactions)
+			m.tm.RemoveTxs(module.TransactionGroupPatch, tst.patchTransactions, tst.bi.Height())
 			m.tm.RemoveOldTxByBlockTS(module.TransactionGroupPatch, tst.bi.Timestamp())
 		}
 		if opt&module.FinalizeResult == module.FinalizeResult {
@@ -832,6 +832,20 @@ func (m *manager) HasTransaction(id []byte) bool {
 	return m.tm.HasTx(id)
 }
 
+func (m *manager) GetPendingTransactions(
+	g module.TransactionGroup, from module.Address, limit int,
+) []module.Transaction {
+	return m.tm.GetTransactions(g, from, limit)
+}
+
+func (m *manager) WatchTxPool(watcher module.TxPoolWatcher) {
+	m.tm.Watch(watcher)
+}
+
+func (m *manager) UnwatchTxPool(watcher module.TxPoolWatcher) bool {
+	return m.tm.Unwatch(watcher)
+}
+
 func (m *manager) WaitForTransaction(
 	parent module.Transition,
 	bi module.BlockInfo,
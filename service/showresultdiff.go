@@ -43,6 +43,7 @@ type DiffContext interface {
 	Logger() log.Logger
 
 	ShowObjectMPTDiff(name string, dbase db.Database, t reflect.Type, e, r []byte, handler ObjectDetailHandler) error
+	ShowBytesMPTDiff(name string, dbase db.Database, e, r []byte) error
 }
 
 type PlatformWithShowDiff interface {
@@ -102,6 +103,12 @@ func (c *diffContext) ShowObjectMPTDiff(name string, dbase db.Database, t reflec
 	return trie_manager.CompareImmutableForObject(et, rt, c.GetObjectDiffHandlerFor(name, handler))
 }
 
+func (c *diffContext) ShowBytesMPTDiff(name string, dbase db.Database, e, r []byte) error {
+	et := trie_manager.NewImmutable(dbase, e)
+	rt := trie_manager.NewImmutable(dbase, r)
+	return trie_manager.CompareImmutable(et, rt, c.GetBytesDiffHandlerFor(name))
+}
+
 func (c *diffContext) AccountDetailHandler() ObjectDetailHandler {
 	type Storer interface {
 		Store() trie.Immutable
@@ -215,3 +222,25 @@ func ShowResultDiff(dbase db.Database, plt base.Platform, logger log.Logger, exp
 	}
 	return c.showResultDiff(eResult, rResult)
 }
+
+// ShowWorldSnapshotDiff shows the differences between two world snapshots
+// in the same way as ShowResultDiff. Both snapshots should be flushed to dbase.
+func ShowWorldSnapshotDiff(dbase db.Database, plt base.Platform, logger log.Logger, exp, real state.WorldSnapshot) error {
+	c := &diffContext{
+		plt:   plt,
+		dbase: dbase,
+		log:   logger,
+	}
+	return c.showResultDiff(
+		&transitionResult{
+			StateHash:     exp.StateHash(),
+			ExtensionData: exp.ExtensionData(),
+			BTPData:       exp.BTPData(),
+		},
+		&transitionResult{
+			StateHash:     real.StateHash(),
+			ExtensionData: real.ExtensionData(),
+			BTPData:       real.BTPData(),
+		},
+	)
+}
@@ -239,6 +239,20 @@ func (l *transactionList) Front() *txElement {
 	return l.listFront
 }
 
+// FrontOf returns the first element of the transactions from the address.
+// Following elements are linked by srcNext.
+func (l *transactionList) FrontOf(from module.Address) *txElement {
+	uidBk, uidSlot := indexAndBucketKeyFromKey(string(from.ID()))
+	e, ok := l.srcMapToLast[uidBk][uidSlot]
+	if !ok {
+		return nil
+	}
+	for e.srcPrev != nil {
+		e = e.srcPrev
+	}
+	return e
+}
+
 func (l *transactionList) Len() int {
 	return l.size
 }
@@ -29,6 +29,7 @@ type TransactionManager struct {
 	callback func()
 
 	txWaiters map[hashValue][]chan<- interface{}
+	watchers  []module.TxPoolWatcher
 }
 
 func (m *TransactionManager) getTxPool(g module.TransactionGroup) *TransactionPool {
@@ -53,9 +54,29 @@ func (m *TransactionManager) HasTx(id []byte) bool {
 }
 
 func (m *TransactionManager) RemoveTxs(
-	g module.TransactionGroup, l module.TransactionList,
+	g module.TransactionGroup, l module.TransactionList, height int64,
 ) {
-	m.getTxPool(g).RemoveList(l)
+	removed := m.getTxPool(g).RemoveList(l)
+	if len(removed) == 0 {
+		return
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, tx := range removed {
+		m.notifyTxPoolEventInLock(&module.TxPoolEvent{
+			Type:   module.TxPoolEventIncluded,
+			ID:     tx.ID(),
+			From:   tx.From(),
+			Height: height,
+		})
+	}
+}
+
+func (m *TransactionManager) GetTransactions(
+	g module.TransactionGroup, from module.Address, limit int,
+) []module.Transaction {
+	return m.getTxPool(g).GetTransactions(from, limit)
 }
 
 func (m *TransactionManager) Candidate(
@@ -124,8 +145,9 @@ func (m *TransactionManager) removeWaitersInLock(id []byte) []chan<- interface{}
 }
 
 type TxDrop struct {
-	ID  []byte
-	Err error
+	ID   []byte
+	From module.Address
+	Err  error
 }
 
 func (m *TransactionManager) OnTxDrops(drops []TxDrop) {
@@ -138,6 +160,45 @@ func (m *TransactionManager) OnTxDrops(drops []TxDrop) {
 			c <- drop.Err
 			close(c)
 		}
+		m.notifyTxPoolEventInLock(&module.TxPoolEvent{
+			Type: module.TxPoolEventDropped,
+			ID:   drop.ID,
+			From: drop.From,
+			Err:  drop.Err,
+		})
+	}
+}
+
+func (m *TransactionManager) Watch(watcher module.TxPoolWatcher) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.watchers = append(m.watchers, watcher)
+}
+
+func (m *TransactionManager) Unwatch(watcher module.TxPoolWatcher) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for i, w := range m.watchers {
+		if w == watcher {
+			if len(m.watchers) > 1 {
+				watchers := make([]module.TxPoolWatcher, len(m.watchers)-1)
+				copy(watchers, m.watchers[:i])
+				copy(watchers[i:], m.watchers[i+1:])
+				m.watchers = watchers
+			} else {
+				m.watchers = nil
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func (m *TransactionManager) notifyTxPoolEventInLock(e *module.TxPoolEvent) {
+	for _, w := range m.watchers {
+		w.OnTxPoolEvent(e)
 	}
 }
 
@@ -211,6 +272,11 @@ func (m *TransactionManager) addInLock(tx transaction.Transaction, direct bool)
 	if err := pool.Add(tx, direct); err != nil {
 		return err
 	}
+	m.notifyTxPoolEventInLock(&module.TxPoolEvent{
+		Type: module.TxPoolEventAdded,
+		ID:   tx.ID(),
+		From: tx.From(),
+	})
 	if m.callback != nil {
 		cb := m.callback
 		m.callback = nil
@@ -110,7 +110,7 @@ func (tp *TransactionPool) DropOldTXs(bts int64) {
 					"ExpiredTransaction(diff=%s)", TimestampToDuration(bts-tx.Timestamp()))
 			}
 			tp.log.Debugf("DROP TX: id=0x%x reason=%v", tx.ID(), iter.err)
-			drops = append(drops, TxDrop{tx.ID(), iter.err})
+			drops = append(drops, TxDrop{tx.ID(), tx.From(), iter.err})
 			tp.monitor.OnDropTx(len(tx.Bytes()), direct)
 		}
 		iter = next
@@ -237,19 +237,21 @@ func (tp *TransactionPool) Add(tx transaction.Transaction, direct bool) error {
 	return err
 }
 
-// removeList remove transactions when transactions are finalized.
-func (tp *TransactionPool) RemoveList(txs module.TransactionList) {
+// RemoveList remove transactions when transactions are finalized.
+// It returns transactions removed from the pool.
+func (tp *TransactionPool) RemoveList(txs module.TransactionList) []module.Transaction {
 	tp.mutex.Lock()
 	defer tp.mutex.Unlock()
 
 	now := time.Now()
 	if tp.list.Len() == 0 {
 		tp.monitor.OnCommit(txs.Hash(), now, 0)
-		return
+		return nil
 	}
 
 	var duration time.Duration
 	var count int
+	var removed []module.Transaction
 
 	for i := txs.Iterator(); i.Has(); i.Next() {
 		t, _, err := i.Get()
@@ -258,6 +260,7 @@ func (tp *TransactionPool) RemoveList(txs module.TransactionList) {
 			continue
 		}
 		if ok, ts := tp.list.RemoveTx(t); ok {
+			removed = append(removed, t)
 			if ts != 0 {
 				duration += now.Sub(time.Unix(0, ts))
 				count += 1
@@ -272,6 +275,27 @@ func (tp *TransactionPool) RemoveList(txs module.TransactionList) {
 	} else {
 		tp.monitor.OnCommit(txs.Hash(), now, 0)
 	}
+	return removed
+}
+
+// GetTransactions returns transactions in the pool in the order of the pool.
+// It returns the transactions from the address if from isn't nil.
+// It returns all for a negative limit.
+func (tp *TransactionPool) GetTransactions(from module.Address, limit int) []module.Transaction {
+	tp.mutex.Lock()
+	defer tp.mutex.Unlock()
+
+	txs := make([]module.Transaction, 0)
+	if from == nil {
+		for e := tp.list.Front(); e != nil && (limit < 0 || len(txs) < limit); e = e.Next() {
+			txs = append(txs, e.Value())
+		}
+	} else {
+		for e := tp.list.FrontOf(from); e != nil && (limit < 0 || len(txs) < limit); e = e.srcNext {
+			txs = append(txs, e.Value())
+		}
+	}
+	return txs
 }
 
 func (tp *TransactionPool) HasTx(tid []byte) bool {
@@ -327,7 +351,7 @@ func (tp *TransactionPool) dropTransactions(txs []*txElement) {
 				tp.log.Panicf("No reason to drop the tx=<%#x>", tx.ID())
 			}
 			tp.log.Debugf("DROP TX: id=0x%x reason=%v", tx.ID(), e.err)
-			drops = append(drops, TxDrop{tx.ID(), e.err})
+			drops = append(drops, TxDrop{tx.ID(), tx.From(), e.err})
 			tp.monitor.OnDropTx(len(tx.Bytes()), direct)
 		}
 	}
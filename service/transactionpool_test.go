@@ -49,3 +49,73 @@ func TestTransactionPool_Add(t *testing.T) {
 		t.Error("Fail to add transaction with valid network ID")
 	}
 }
+
+func TestTransactionPool_GetTransactions(t *testing.T) {
+	dbase := db.NewMapDB()
+	tsc := NewTimestampChecker()
+	logger := log.New()
+	lm, err := txlocator.NewManager(dbase, logger)
+	assert.NoError(t, err)
+	tim, _ := NewTXIDManager(lm, tsc, nil)
+	pool := NewTransactionPool(module.TransactionGroupNormal, 5000, tim, &mockMonitor{}, logger)
+
+	addr1 := common.MustNewAddressFromString("hx1111111111111111111111111111111111111111")
+	addr2 := common.MustNewAddressFromString("hx2222222222222222222222222222222222222222")
+	tx1 := newMockTransaction([]byte("tx1"), addr1, 3)
+	tx2 := newMockTransaction([]byte("tx2"), addr2, 2)
+	tx3 := newMockTransaction([]byte("tx3"), addr1, 1)
+	for _, tx := range []*mockTransaction{tx1, tx2, tx3} {
+		assert.NoError(t, pool.Add(tx, true))
+	}
+
+	assert.Equal(t, []module.Transaction{tx3, tx1, tx2}, pool.GetTransactions(nil, -1))
+	assert.Equal(t, []module.Transaction{tx3, tx1}, pool.GetTransactions(nil, 2))
+	assert.Equal(t, []module.Transaction{tx3, tx1}, pool.GetTransactions(addr1, -1))
+	assert.Equal(t, []module.Transaction{tx3}, pool.GetTransactions(addr1, 1))
+	assert.Equal(t, []module.Transaction{tx2}, pool.GetTransactions(addr2, -1))
+	assert.Equal(t, []module.Transaction{},
+		pool.GetTransactions(common.MustNewAddressFromString("hx3333333333333333333333333333333333333333"), -1))
+}
+
+type mockTxPoolWatcher struct {
+	events []module.TxPoolEvent
+}
+
+func (w *mockTxPoolWatcher) OnTxPoolEvent(e *module.TxPoolEvent) {
+	w.events = append(w.events, *e)
+}
+
+func TestTransactionManager_WatchTxPool(t *testing.T) {
+	dbase := db.NewMapDB()
+	tsc := NewTimestampChecker()
+	logger := log.New()
+	lm, err := txlocator.NewManager(dbase, logger)
+	assert.NoError(t, err)
+	tim, _ := NewTXIDManager(lm, tsc, nil)
+	ptp := NewTransactionPool(module.TransactionGroupPatch, 5000, tim, &mockMonitor{}, logger)
+	ntp := NewTransactionPool(module.TransactionGroupNormal, 5000, tim, &mockMonitor{}, logger)
+	tm := NewTransactionManager(1, tsc, ptp, ntp, tim, logger)
+
+	w := new(mockTxPoolWatcher)
+	tm.Watch(w)
+
+	addr := common.MustNewAddressFromString("hx1111111111111111111111111111111111111111")
+	tx1 := newMockTransaction([]byte("tx1"), addr, 1)
+	assert.NoError(t, tm.Add(tx1, true, true))
+	assert.Equal(t, 1, len(w.events))
+	assert.Equal(t, module.TxPoolEventAdded, w.events[0].Type)
+	assert.Equal(t, tx1.ID(), w.events[0].ID)
+	assert.True(t, addr.Equal(w.events[0].From))
+
+	ntp.DropOldTXs(1)
+	assert.Equal(t, 2, len(w.events))
+	assert.Equal(t, module.TxPoolEventDropped, w.events[1].Type)
+	assert.Equal(t, tx1.ID(), w.events[1].ID)
+	assert.True(t, ExpiredTransactionError.Equals(w.events[1].Err))
+	assert.Equal(t, 0, len(tm.GetTransactions(module.TransactionGroupNormal, nil, -1)))
+
+	assert.True(t, tm.Unwatch(w))
+	assert.False(t, tm.Unwatch(w))
+	assert.NoError(t, tm.Add(newMockTransaction([]byte("tx2"), addr, 2), true, true))
+	assert.Equal(t, 2, len(w.events))
+}